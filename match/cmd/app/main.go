@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net"
+	"time"
 
 	"app/match/internal/client"
 	"app/match/internal/config"
@@ -36,7 +37,11 @@ func main() {
 	defer userConn.Close()
 	userClient := client.NewUserClientAdapter(userGRPC)
 
-	uc := usecase.NewUseCase(matchRepo, userClient)
+	tz, err := time.LoadLocation(config.C.DefaultTimezone)
+	if err != nil {
+		log.Fatalf("MATCH_DEFAULT_TIMEZONE: %v", err)
+	}
+	uc := usecase.NewUseCase(matchRepo, userClient, config.C.DailyLikes, tz, float64(config.C.SearchRadiusKm))
 	h := handler.NewHandler(uc)
 
 	lis, err := net.Listen("tcp", config.C.GRPC_PORT)
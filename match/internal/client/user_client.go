@@ -31,22 +31,28 @@ func (c *UserClientAdapter) GetByTelegramID(ctx context.Context, telegramID int6
 	return fromPB(resp.User), nil
 }
 
-func (c *UserClientAdapter) GetCandidates(ctx context.Context, cand dto.Candidate) ([]*dto.User, error) {
+// GetCandidates возвращает страницу выдачи и курсор следующей ("" — если это последняя).
+func (c *UserClientAdapter) GetCandidates(ctx context.Context, cand dto.Candidate) ([]*dto.User, string, error) {
 	resp, err := c.grpc.GetCandidates(ctx, &userpb.GetCandidatesRequest{
 		TargetGender: cand.TargetGender,
 		MinAge:       int32(cand.MinAge),
 		MaxAge:       int32(cand.MaxAge),
 		Location:     cand.Location,
 		Limit:        int32(cand.Limit),
+		ExcludeIds:   cand.ExcludeIDs,
+		Geo:          geoToPB(cand.Geo),
+		RadiusKm:     cand.RadiusKm,
+		AnyCity:      cand.AnyCity,
+		Cursor:       cand.Cursor,
 	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	users := make([]*dto.User, 0, len(resp.Candidates))
 	for _, u := range resp.Candidates {
 		users = append(users, fromPB(u))
 	}
-	return users, nil
+	return users, resp.NextCursor, nil
 }
 
 func fromPB(u *userpb.User) *dto.User {
@@ -63,5 +69,28 @@ func fromPB(u *userpb.User) *dto.User {
 		Description: u.Description,
 		PhotoURL:    u.PhotoUrl,
 		IsVisible:   u.IsVisible,
+		Timezone:    u.Timezone,
+		Geo:         geoFromPB(u.Geo),
+		Prefs: dto.SearchPrefs{
+			Gender:   u.GetSearchPrefs().GetGender(),
+			MinAge:   int(u.GetSearchPrefs().GetMinAge()),
+			MaxAge:   int(u.GetSearchPrefs().GetMaxAge()),
+			RadiusKm: int(u.GetSearchPrefs().GetRadiusKm()),
+			AnyCity:  u.GetSearchPrefs().GetAnyCity(),
+		},
+	}
+}
+
+func geoFromPB(g *userpb.GeoPoint) *dto.GeoPoint {
+	if g == nil {
+		return nil
+	}
+	return &dto.GeoPoint{Latitude: g.GetLatitude(), Longitude: g.GetLongitude()}
+}
+
+func geoToPB(g *dto.GeoPoint) *userpb.GeoPoint {
+	if g == nil {
+		return nil
 	}
+	return &userpb.GeoPoint{Latitude: g.Latitude, Longitude: g.Longitude}
 }
@@ -3,12 +3,19 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 )
 
 type config struct {
 	PostgresDSN string
 	GRPC_PORT   string
 	USER_CLIENT string
+	// DailyLikes — сколько лайков в сутки можно поставить (0 — без ограничения).
+	DailyLikes int
+	// DefaultTimezone — часовой пояс для пользователей, которые его не указали.
+	DefaultTimezone string
+	// SearchRadiusKm — радиус поиска для пользователей с геопозицией (0 — искать только по городу).
+	SearchRadiusKm int
 }
 
 var C config
@@ -18,6 +25,10 @@ func Load() {
 		PostgresDSN: getEnv("MATCH_POSTGRES_DSN", ""),
 		GRPC_PORT:   getEnv("MATCH_GRPC_PORT", ":50052"),
 		USER_CLIENT: getEnv("MATCH_USER_CLIENT", "user_service:50051"),
+
+		DailyLikes:      getInt("MATCH_DAILY_LIKES", 50),
+		DefaultTimezone: getEnv("MATCH_DEFAULT_TIMEZONE", "UTC"),
+		SearchRadiusKm:  getInt("MATCH_SEARCH_RADIUS_KM", 50),
 	}
 
 	log.Println("✅ Config loaded")
@@ -29,3 +40,12 @@ func getEnv(key, def string) string {
 	}
 	return def
 }
+
+func getInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
@@ -1,10 +1,18 @@
 package dto
 
 type Candidate struct {
-	TargetGender string  `json:"target_gender"`
+	TargetGender string  `json:"target_gender"` // пустой — любой пол
 	MinAge       int     `json:"min_age"`
 	MaxAge       int     `json:"max_age"`
 	Location     string  `json:"location"`
 	Limit        int     `json:"limit"`
 	ExcludeIDs   []int64 `json:"exclude_ids"`
+	// Geo и RadiusKm включают поиск по расстоянию; без них анкеты ищутся по городу.
+	Geo      *GeoPoint `json:"geo,omitempty"`
+	RadiusKm float64   `json:"radius_km,omitempty"`
+	// AnyCity снимает ограничение по городу и расстоянию.
+	AnyCity bool `json:"any_city,omitempty"`
+
+	// Cursor — next_cursor предыдущей страницы user service, пустой — первая страница.
+	Cursor string `json:"cursor,omitempty"`
 }
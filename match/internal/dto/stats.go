@@ -0,0 +1,16 @@
+package dto
+
+// Stats — активность за период для администраторов.
+type Stats struct {
+	Likes       int64 `json:"likes"`
+	Matches     int64 `json:"matches"`      // пары, ставшие взаимными за период
+	OpenReports int64 `json:"open_reports"` // нерассмотренные жалобы за всё время
+}
+
+// UserStats — счётчики одного пользователя за всё время.
+type UserStats struct {
+	LikesGiven      int64 `json:"likes_given"`
+	LikesReceived   int64 `json:"likes_received"`
+	Matches         int64 `json:"matches"`
+	ReportsReceived int64 `json:"reports_received"`
+}
@@ -13,4 +13,26 @@ type User struct {
 	PhotoURL    string    `json:"photo_url,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	IsVisible   bool      `json:"is_visible"`
+	Timezone    string    `json:"timezone,omitempty"`
+	Geo         *GeoPoint `json:"geo,omitempty"`
+
+	Prefs SearchPrefs `json:"search_prefs"`
+}
+
+// SearchPrefs — настройки поиска; нулевые значения — поиск по умолчанию.
+type SearchPrefs struct {
+	Gender   string `json:"gender,omitempty"` // "" — противоположный пол, SeekAnyone — любой
+	MinAge   int    `json:"min_age,omitempty"`
+	MaxAge   int    `json:"max_age,omitempty"`
+	RadiusKm int    `json:"radius_km,omitempty"`
+	AnyCity  bool   `json:"any_city,omitempty"`
+}
+
+// SeekAnyone — в SearchPrefs.Gender: показывать анкеты любого пола.
+const SeekAnyone = "any"
+
+// GeoPoint — координаты в градусах.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
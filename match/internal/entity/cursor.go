@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// CandidateCursor — докуда пользователь долистал выдачу кандидатов.
+type CandidateCursor struct {
+	UserID    int64     `json:"user_id"`
+	Filter    string    `json:"filter"` // отпечаток фильтра, при котором получен курсор
+	Cursor    string    `json:"cursor"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
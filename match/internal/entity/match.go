@@ -7,5 +7,6 @@ type Match struct {
 	FromUser  int64     `json:"from_user"` // кто поставил лайк
 	ToUser    int64     `json:"to_user"`   // кому
 	IsLike    bool      `json:"is_like"`   // true=лайк, false=дизлайк
+	Message   string    `json:"message,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
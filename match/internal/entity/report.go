@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Report — жалоба пользователя, ждёт решения модератора.
+type Report struct {
+	ID        int64     `json:"id"`
+	Reporter  int64     `json:"reporter"` // кто пожаловался
+	Reported  int64     `json:"reported"` // на кого
+	Reason    string    `json:"reason"`
+	Comment   string    `json:"comment,omitempty"`
+	Status    string    `json:"status"` // "open", пока жалобу не разобрал модератор
+	CreatedAt time.Time `json:"created_at"`
+}
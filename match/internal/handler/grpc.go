@@ -2,7 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"app/match/internal/entity"
 	"app/match/internal/usecase"
 	matchpb "app/match/proto"
 )
@@ -17,12 +25,29 @@ func NewHandler(uc *usecase.Usecase) *Handler {
 }
 
 func (h *Handler) Like(ctx context.Context, req *matchpb.LikeRequest) (*matchpb.LikeResponse, error) {
-	if err := h.uc.Like(ctx, req.GetFromUser(), req.GetToUser(), req.GetIsLike()); err != nil {
+	if err := h.uc.Like(ctx, req.GetFromUser(), req.GetToUser(), req.GetIsLike(), req.GetMessage()); err != nil {
+		var quota *usecase.QuotaError
+		if errors.As(err, &quota) {
+			return nil, quotaStatus(quota)
+		}
+		if errors.Is(err, usecase.ErrLongMessage) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return &matchpb.LikeResponse{Success: true}, nil
 }
 
+func (h *Handler) UndoLike(ctx context.Context, req *matchpb.UndoLikeRequest) (*matchpb.UndoLikeResponse, error) {
+	if err := h.uc.UndoLike(ctx, req.GetFromUser(), req.GetToUser()); err != nil {
+		if errors.Is(err, usecase.ErrNothingToUndo) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+	return &matchpb.UndoLikeResponse{Success: true}, nil
+}
+
 func (h *Handler) CheckMatch(ctx context.Context, req *matchpb.CheckMatchRequest) (*matchpb.CheckMatchResponse, error) {
 	ok, err := h.uc.Match(ctx, req.GetUser1(), req.GetUser2())
 	if err != nil {
@@ -31,8 +56,118 @@ func (h *Handler) CheckMatch(ctx context.Context, req *matchpb.CheckMatchRequest
 	return &matchpb.CheckMatchResponse{Match: ok}, nil
 }
 
+func (h *Handler) HasLike(ctx context.Context, req *matchpb.HasLikeRequest) (*matchpb.HasLikeResponse, error) {
+	ok, err := h.uc.HasLike(ctx, req.GetFromUser(), req.GetToUser())
+	if err != nil {
+		return nil, err
+	}
+	return &matchpb.HasLikeResponse{Liked: ok}, nil
+}
+
+func (h *Handler) DeleteUser(ctx context.Context, req *matchpb.DeleteUserRequest) (*matchpb.DeleteUserResponse, error) {
+	n, err := h.uc.DeleteUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &matchpb.DeleteUserResponse{Deleted: n}, nil
+}
+
+func (h *Handler) ListIncomingLikes(ctx context.Context, req *matchpb.ListIncomingLikesRequest) (*matchpb.ListIncomingLikesResponse, error) {
+	likes, err := h.uc.IncomingLikes(ctx, req.GetUserId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*matchpb.IncomingLike, 0, len(likes))
+	for _, l := range likes {
+		out = append(out, &matchpb.IncomingLike{
+			FromUser:  l.FromUser,
+			CreatedAt: l.CreatedAt.Format(time.RFC3339),
+			Message:   l.Message,
+		})
+	}
+	return &matchpb.ListIncomingLikesResponse{Likes: out}, nil
+}
+
+func (h *Handler) ListMatches(ctx context.Context, req *matchpb.ListMatchesRequest) (*matchpb.ListMatchesResponse, error) {
+	list, next, err := h.uc.ListMatches(ctx, req.GetUserId(), req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	out := make([]*matchpb.MatchedUser, 0, len(list))
+	for _, m := range list {
+		out = append(out, &matchpb.MatchedUser{
+			UserId:    m.ToUser,
+			MatchedAt: m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return &matchpb.ListMatchesResponse{Matches: out, NextCursor: next}, nil
+}
+
+func (h *Handler) Unmatch(ctx context.Context, req *matchpb.UnmatchRequest) (*matchpb.UnmatchResponse, error) {
+	if err := h.uc.Unmatch(ctx, req.GetUserId(), req.GetOtherUserId()); err != nil {
+		return nil, err
+	}
+	return &matchpb.UnmatchResponse{Success: true}, nil
+}
+
+func (h *Handler) Block(ctx context.Context, req *matchpb.BlockRequest) (*matchpb.BlockResponse, error) {
+	if err := h.uc.Block(ctx, req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		if errors.Is(err, usecase.ErrSelfAction) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return &matchpb.BlockResponse{Success: true}, nil
+}
+
+func (h *Handler) Report(ctx context.Context, req *matchpb.ReportRequest) (*matchpb.ReportResponse, error) {
+	id, err := h.uc.Report(ctx, entity.Report{
+		Reporter: req.GetReporterId(),
+		Reported: req.GetReportedUserId(),
+		Reason:   req.GetReason(),
+		Comment:  req.GetComment(),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidReason) || errors.Is(err, usecase.ErrSelfAction) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return &matchpb.ReportResponse{ReportId: id}, nil
+}
+
+func (h *Handler) GetStats(ctx context.Context, req *matchpb.GetStatsRequest) (*matchpb.GetStatsResponse, error) {
+	st, err := h.uc.Stats(ctx, time.Unix(req.GetSince(), 0))
+	if err != nil {
+		return nil, err
+	}
+	return &matchpb.GetStatsResponse{
+		Likes:       st.Likes,
+		Matches:     st.Matches,
+		OpenReports: st.OpenReports,
+	}, nil
+}
+
+func (h *Handler) GetUserStats(ctx context.Context, req *matchpb.GetUserStatsRequest) (*matchpb.GetUserStatsResponse, error) {
+	st, err := h.uc.UserStats(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &matchpb.GetUserStatsResponse{
+		LikesGiven:      st.LikesGiven,
+		LikesReceived:   st.LikesReceived,
+		Matches:         st.Matches,
+		ReportsReceived: st.ReportsReceived,
+	}, nil
+}
+
 func (h *Handler) GetCandidates(ctx context.Context, req *matchpb.GetCandidatesRequest) (*matchpb.GetCandidatesResponse, error) {
-	list, err := h.uc.GetCandidats(ctx, req.GetTelegramId())
+	list, more, err := h.uc.GetCandidats(ctx, req.GetTelegramId())
 	if err != nil {
 		return nil, err
 	}
@@ -52,5 +187,17 @@ func (h *Handler) GetCandidates(ctx context.Context, req *matchpb.GetCandidatesR
 		})
 	}
 
-	return &matchpb.GetCandidatesResponse{Candidates: out}, nil
+	return &matchpb.GetCandidatesResponse{Candidates: out, HasMore: more}, nil
+}
+
+// quotaStatus отдаёт клиенту RESOURCE_EXHAUSTED и время до обновления лимита в RetryInfo.
+func quotaStatus(q *usecase.QuotaError) error {
+	st := status.New(codes.ResourceExhausted, q.Error())
+	withInfo, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Until(q.ResetAt)),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withInfo.Err()
 }
@@ -3,6 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
+
+	"app/match/internal/dto"
+	"app/match/internal/entity"
 )
 
 type PostgresDB struct {
@@ -13,21 +18,113 @@ func NewPostgresDB(db *sql.DB) *PostgresDB {
 	return &PostgresDB{db: db}
 }
 
-//Like(ctx context.Context, fromUser, toUser int64, isLike bool) error
+//Like(ctx context.Context, fromUser, toUser int64, isLike bool, message string) error
 //CheckMatch(ctx context.Context, user1, user2 int64) (bool, error)
-//TodayLikedIDs(ctx context.Context, fromUser int64) ([]int64, error)
+//RatedIDs(ctx context.Context, fromUser int64) ([]int64, error)
 
-func (p *PostgresDB) Like(ctx context.Context, fromUser, toUser int64, isLike bool) error {
-	query := `
-		INSERT INTO matches (from_user, to_user, is_like)
-		VALUES ($1, $2, $3)
+// likeQuery сохраняет оценку. Отменить можно только последнюю оценку: у остальных оценок
+// пользователя флаг снимается, а перезаписанная оценка запоминается в prev_*.
+const likeQuery = `
+		WITH reset AS (
+			UPDATE matches SET undoable = FALSE
+			WHERE from_user = $1 AND to_user <> $2 AND undoable
+		)
+		INSERT INTO matches (from_user, to_user, is_like, message, undoable)
+		VALUES ($1, $2, $3, $4, TRUE)
 		ON CONFLICT (from_user, to_user)
-		DO UPDATE SET is_like = EXCLUDED.is_like, created_at = now()
+		DO UPDATE SET
+			prev_is_like    = matches.is_like,
+			prev_message    = matches.message,
+			prev_created_at = matches.created_at,
+			is_like         = EXCLUDED.is_like,
+			message         = EXCLUDED.message,
+			created_at      = now(),
+			undoable        = TRUE
+	`
+
+func (p *PostgresDB) Like(ctx context.Context, fromUser, toUser int64, isLike bool, message string) error {
+	_, err := p.db.ExecContext(ctx, likeQuery, fromUser, toUser, isLike, message)
+	return err
+}
+
+// LikeWithinQuota ставит лайк, только если начиная с since пользователь поставил меньше limit
+// лайков; false — лимит исчерпан. Подсчёт и запись идут в одной транзакции под advisory-блокировкой
+// пользователя, поэтому параллельные лайки не превысят лимит.
+func (p *PostgresDB) LikeWithinQuota(ctx context.Context, fromUser, toUser int64, message string, since time.Time, limit int) (bool, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, fromUser); err != nil {
+		return false, err
+	}
+	query := `
+		SELECT COUNT(*)
+		FROM matches
+		WHERE from_user = $1
+		  AND is_like = TRUE
+		  AND created_at >= $2
 	`
-	_, err := p.db.ExecContext(ctx, query, fromUser, toUser, isLike)
+	var n int
+	if err := tx.QueryRowContext(ctx, query, fromUser, since).Scan(&n); err != nil {
+		return false, err
+	}
+	if n >= limit {
+		return false, nil
+	}
+	if _, err := tx.ExecContext(ctx, likeQuery, fromUser, toUser, true, message); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// Unmatch превращает лайк fromUser в дизлайк. В отличие от Like, не трогает остальные
+// оценки: последнюю из них по-прежнему можно отменить. Саму эту запись отменить уже нельзя.
+func (p *PostgresDB) Unmatch(ctx context.Context, fromUser, toUser int64) error {
+	query := `
+		UPDATE matches
+		SET is_like = FALSE, message = '', undoable = FALSE
+		WHERE from_user = $1 AND to_user = $2
+	`
+	_, err := p.db.ExecContext(ctx, query, fromUser, toUser)
 	return err
 }
 
+// UndoLike отменяет последнюю оценку fromUser, если она поставлена toUser: новая запись
+// удаляется, перезаписанная — восстанавливается. Возвращает false, если отменять нечего.
+func (p *PostgresDB) UndoLike(ctx context.Context, fromUser, toUser int64) (bool, error) {
+	query := `
+		WITH target AS (
+			SELECT id, prev_is_like
+			FROM matches
+			WHERE from_user = $1 AND to_user = $2 AND undoable
+		), removed AS (
+			DELETE FROM matches
+			WHERE id IN (SELECT id FROM target WHERE prev_is_like IS NULL)
+			RETURNING id
+		), restored AS (
+			UPDATE matches SET
+				is_like         = prev_is_like,
+				message         = prev_message,
+				created_at      = prev_created_at,
+				prev_is_like    = NULL,
+				prev_message    = '',
+				prev_created_at = NULL,
+				undoable        = FALSE
+			WHERE id IN (SELECT id FROM target WHERE prev_is_like IS NOT NULL)
+			RETURNING id
+		)
+		SELECT (SELECT COUNT(*) FROM removed) + (SELECT COUNT(*) FROM restored)
+	`
+	var n int
+	if err := p.db.QueryRowContext(ctx, query, fromUser, toUser).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
 // Проверяем взаимный лайк
 func (p *PostgresDB) CheckMatch(ctx context.Context, user1, user2 int64) (bool, error) {
 	query := `
@@ -51,13 +148,215 @@ func (p *PostgresDB) CheckMatch(ctx context.Context, user1, user2 int64) (bool,
 	return exists, nil
 }
 
-func (p *PostgresDB) TodayLikedIDs(ctx context.Context, fromUser int64) ([]int64, error) {
+// HasLike проверяет, что fromUser лайкнул toUser и никто из них не заблокировал другого.
+func (p *PostgresDB) HasLike(ctx context.Context, fromUser, toUser int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM matches m
+			WHERE m.from_user = $1
+			  AND m.to_user   = $2
+			  AND m.is_like   = TRUE
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM blocks b
+				WHERE (b.blocker = $1 AND b.blocked = $2)
+				   OR (b.blocker = $2 AND b.blocked = $1)
+			  )
+		)
+	`
+	var exists bool
+	err := p.db.QueryRowContext(ctx, query, fromUser, toUser).Scan(&exists)
+	return exists, err
+}
+
+// DeleteUser удаляет все записи, где пользователь ставил или получал оценку.
+func (p *PostgresDB) DeleteUser(ctx context.Context, userID int64) (int64, error) {
+	query := `
+		DELETE FROM matches
+		WHERE from_user = $1 OR to_user = $1
+	`
+	res, err := p.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// IncomingLikes возвращает лайки пользователю, на которые он ещё не ответил.
+func (p *PostgresDB) IncomingLikes(ctx context.Context, userID int64, limit int) ([]entity.Match, error) {
+	query := `
+		SELECT m.id, m.from_user, m.to_user, m.is_like, m.message, m.created_at
+		FROM matches m
+		WHERE m.to_user = $1
+		  AND m.is_like = TRUE
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM matches r
+			WHERE r.from_user = m.to_user
+			  AND r.to_user   = m.from_user
+		  )
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM blocks b
+			WHERE (b.blocker = m.to_user AND b.blocked = m.from_user)
+			   OR (b.blocker = m.from_user AND b.blocked = m.to_user)
+		  )
+		ORDER BY m.created_at DESC
+		LIMIT $2
+	`
+	rows, err := p.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var likes []entity.Match
+	for rows.Next() {
+		var l entity.Match
+		if err := rows.Scan(&l.ID, &l.FromUser, &l.ToUser, &l.IsLike, &l.Message, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		likes = append(likes, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return likes, nil
+}
+
+// Matches возвращает взаимные лайки, новые первыми; страница начинается после (before, beforeUser).
+func (p *PostgresDB) Matches(ctx context.Context, userID int64, before *time.Time, beforeUser int64, limit int) ([]entity.Match, error) {
+	query := `
+		SELECT id, from_user, to_user, matched_at
+		FROM (
+			SELECT m1.id, m1.from_user, m1.to_user,
+				GREATEST(m1.created_at, m2.created_at) AS matched_at
+			FROM matches m1
+			JOIN matches m2
+			  ON m1.from_user = m2.to_user
+			 AND m1.to_user   = m2.from_user
+			WHERE m1.from_user = $1
+			  AND m1.is_like   = TRUE
+			  AND m2.is_like   = TRUE
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM blocks b
+				WHERE (b.blocker = m1.from_user AND b.blocked = m1.to_user)
+				   OR (b.blocker = m1.to_user AND b.blocked = m1.from_user)
+			  )
+		) t
+		WHERE $2::timestamptz IS NULL
+		   OR (matched_at, to_user) < ($2::timestamptz, $3)
+		ORDER BY matched_at DESC, to_user DESC
+		LIMIT $4
+	`
+	rows, err := p.db.QueryContext(ctx, query, userID, before, beforeUser, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []entity.Match
+	for rows.Next() {
+		m := entity.Match{IsLike: true}
+		if err := rows.Scan(&m.ID, &m.FromUser, &m.ToUser, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Block блокирует пользователя; повторная блокировка ничего не меняет.
+func (p *PostgresDB) Block(ctx context.Context, blocker, blocked int64) error {
+	query := `
+		INSERT INTO blocks (blocker, blocked)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker, blocked) DO NOTHING
+	`
+	_, err := p.db.ExecContext(ctx, query, blocker, blocked)
+	return err
+}
+
+// CandidateCursor возвращает сохранённый курсор выдачи пользователя или nil, если его нет.
+func (p *PostgresDB) CandidateCursor(ctx context.Context, userID int64) (*entity.CandidateCursor, error) {
+	c := entity.CandidateCursor{UserID: userID}
+	err := p.db.QueryRowContext(ctx,
+		`SELECT filter, cursor, updated_at FROM candidate_cursors WHERE user_id = $1`, userID,
+	).Scan(&c.Filter, &c.Cursor, &c.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (p *PostgresDB) SaveCandidateCursor(ctx context.Context, c entity.CandidateCursor) error {
+	query := `
+		INSERT INTO candidate_cursors (user_id, filter, cursor, updated_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (user_id)
+		DO UPDATE SET filter = EXCLUDED.filter, cursor = EXCLUDED.cursor, updated_at = now()
+	`
+	_, err := p.db.ExecContext(ctx, query, c.UserID, c.Filter, c.Cursor)
+	return err
+}
+
+func (p *PostgresDB) DeleteCandidateCursor(ctx context.Context, userID int64) error {
+	_, err := p.db.ExecContext(ctx, `DELETE FROM candidate_cursors WHERE user_id = $1`, userID)
+	return err
+}
+
+// BlockedIDs возвращает пользователей, заблокированных пользователем или заблокировавших его.
+func (p *PostgresDB) BlockedIDs(ctx context.Context, userID int64) ([]int64, error) {
+	query := `
+		SELECT blocked FROM blocks WHERE blocker = $1
+		UNION
+		SELECT blocker FROM blocks WHERE blocked = $1
+	`
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (p *PostgresDB) CreateReport(ctx context.Context, r entity.Report) (int64, error) {
+	query := `
+		INSERT INTO reports (reporter, reported, reason, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	var id int64
+	err := p.db.QueryRowContext(ctx, query, r.Reporter, r.Reported, r.Reason, r.Comment).Scan(&id)
+	return id, err
+}
+
+// RatedIDs возвращает всех пользователей, которым fromUser поставил лайк или дизлайк.
+func (p *PostgresDB) RatedIDs(ctx context.Context, fromUser int64) ([]int64, error) {
 	query := `
 		SELECT to_user
 		FROM matches
 		WHERE from_user = $1
-		  AND is_like = TRUE
-		  AND (created_at AT TIME ZONE 'UTC')::date = (now() AT TIME ZONE 'UTC')::date
 	`
 	rows, err := p.db.QueryContext(ctx, query, fromUser)
 	if err != nil {
@@ -78,3 +377,45 @@ func (p *PostgresDB) TodayLikedIDs(ctx context.Context, fromUser int64) ([]int64
 	}
 	return ids, nil
 }
+
+// Stats считает лайки и новые совпадения начиная с since. Совпадение относится
+// к моменту второго лайка пары, каждая пара считается один раз.
+func (p *PostgresDB) Stats(ctx context.Context, since time.Time) (dto.Stats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM matches WHERE is_like = TRUE AND created_at >= $1),
+			(SELECT COUNT(*)
+			   FROM matches m1
+			   JOIN matches m2
+			     ON m1.from_user = m2.to_user
+			    AND m1.to_user   = m2.from_user
+			  WHERE m1.from_user < m1.to_user
+			    AND m1.is_like = TRUE
+			    AND m2.is_like = TRUE
+			    AND GREATEST(m1.created_at, m2.created_at) >= $1),
+			(SELECT COUNT(*) FROM reports WHERE status = 'open')
+	`
+	var st dto.Stats
+	err := p.db.QueryRowContext(ctx, query, since).Scan(&st.Likes, &st.Matches, &st.OpenReports)
+	return st, err
+}
+
+func (p *PostgresDB) UserStats(ctx context.Context, userID int64) (dto.UserStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM matches WHERE from_user = $1 AND is_like = TRUE),
+			(SELECT COUNT(*) FROM matches WHERE to_user = $1 AND is_like = TRUE),
+			(SELECT COUNT(*)
+			   FROM matches m1
+			   JOIN matches m2
+			     ON m1.from_user = m2.to_user
+			    AND m1.to_user   = m2.from_user
+			  WHERE m1.from_user = $1
+			    AND m1.is_like = TRUE
+			    AND m2.is_like = TRUE),
+			(SELECT COUNT(*) FROM reports WHERE reported = $1)
+	`
+	var st dto.UserStats
+	err := p.db.QueryRowContext(ctx, query, userID).Scan(&st.LikesGiven, &st.LikesReceived, &st.Matches, &st.ReportsReceived)
+	return st, err
+}
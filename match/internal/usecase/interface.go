@@ -2,16 +2,34 @@ package usecase
 
 import (
 	"app/match/internal/dto"
+	"app/match/internal/entity"
 	"context"
+	"time"
 )
 
 type MatchRepo interface {
-	Like(ctx context.Context, fromUser, toUser int64, isLike bool) error
+	Like(ctx context.Context, fromUser, toUser int64, isLike bool, message string) error
+	UndoLike(ctx context.Context, fromUser, toUser int64) (bool, error)
+	Unmatch(ctx context.Context, fromUser, toUser int64) error
 	CheckMatch(ctx context.Context, user1, user2 int64) (bool, error)
-	TodayLikedIDs(ctx context.Context, fromUser int64) ([]int64, error)
+	HasLike(ctx context.Context, fromUser, toUser int64) (bool, error)
+	RatedIDs(ctx context.Context, fromUser int64) ([]int64, error)
+	LikeWithinQuota(ctx context.Context, fromUser, toUser int64, message string, since time.Time, limit int) (bool, error)
+	DeleteUser(ctx context.Context, userID int64) (int64, error)
+	IncomingLikes(ctx context.Context, userID int64, limit int) ([]entity.Match, error)
+	Matches(ctx context.Context, userID int64, before *time.Time, beforeUser int64, limit int) ([]entity.Match, error)
+	Block(ctx context.Context, blocker, blocked int64) error
+	BlockedIDs(ctx context.Context, userID int64) ([]int64, error)
+	CandidateCursor(ctx context.Context, userID int64) (*entity.CandidateCursor, error)
+	SaveCandidateCursor(ctx context.Context, c entity.CandidateCursor) error
+	DeleteCandidateCursor(ctx context.Context, userID int64) error
+	CreateReport(ctx context.Context, r entity.Report) (int64, error)
+	Stats(ctx context.Context, since time.Time) (dto.Stats, error)
+	UserStats(ctx context.Context, userID int64) (dto.UserStats, error)
 }
 
 type UserClient interface {
+	GetProfile(ctx context.Context, userID int64) (*dto.User, error)
 	GetByTelegramID(ctx context.Context, telegramID int64) (*dto.User, error)
-	GetCandidates(context.Context, dto.Candidate) ([]*dto.User, error)
+	GetCandidates(context.Context, dto.Candidate) ([]*dto.User, string, error)
 }
@@ -2,50 +2,335 @@ package usecase
 
 import (
 	"app/match/internal/dto"
+	"app/match/internal/entity"
 	"app/match/internal/utils"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+const (
+	maxIncomingLikes = 50
+	maxMatchesPage   = 50
+	candidatesPage   = 20
+)
+
+// ageSpread — на сколько лет по умолчанию выдача отходит от возраста пользователя.
+const ageSpread = 3
+
+// candidateCursorTTL — через сколько выдача начинается с начала: за это время могут появиться
+// анкеты ближе уже пройденных. Оценённые анкеты исключаются всегда, поэтому снова не покажутся.
+const candidateCursorTTL = 24 * time.Hour
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidReason = errors.New("invalid report reason")
+	ErrSelfAction    = errors.New("cannot block or report yourself")
+	ErrLongMessage   = errors.New("like message is too long")
+	ErrNothingToUndo = errors.New("nothing to undo")
+)
+
+// QuotaError — дневной лимит лайков исчерпан; ResetAt — когда он обновится.
+type QuotaError struct {
+	Limit   int
+	ResetAt time.Time
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("daily like quota of %d exhausted until %s", e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// ReportReasons — допустимые причины жалобы.
+var ReportReasons = []string{"spam", "fake", "abuse", "underage", "other"}
+
+// maxReportComment — ограничение длины комментария к жалобе (в символах).
+const maxReportComment = 1000
+
+// MaxLikeMessage — ограничение длины сообщения к лайку (в символах).
+const MaxLikeMessage = 200
+
 type Usecase struct {
 	repo       MatchRepo
 	userClient UserClient
+
+	// dailyLikes — лимит лайков в сутки, 0 — без ограничения.
+	dailyLikes int
+	// defaultTZ — часовой пояс для пользователей, которые его не указали.
+	defaultTZ *time.Location
+	// radiusKm — радиус поиска анкет вокруг геопозиции пользователя.
+	radiusKm float64
+	now      func() time.Time
 }
 
-func NewUseCase(repo MatchRepo, userClient UserClient) *Usecase {
-	return &Usecase{repo: repo, userClient: userClient}
+func NewUseCase(repo MatchRepo, userClient UserClient, dailyLikes int, defaultTZ *time.Location, radiusKm float64) *Usecase {
+	if defaultTZ == nil {
+		defaultTZ = time.UTC
+	}
+	return &Usecase{
+		repo:       repo,
+		userClient: userClient,
+		dailyLikes: dailyLikes,
+		defaultTZ:  defaultTZ,
+		radiusKm:   radiusKm,
+		now:        time.Now,
+	}
 }
 
-func (u *Usecase) Like(ctx context.Context, fromUser, toUser int64, isLike bool) error {
-	return u.repo.Like(ctx, fromUser, toUser, isLike)
+// Like сохраняет оценку и приложенное к лайку сообщение (у дизлайка оно отбрасывается).
+// Лайк сверх дневного лимита отклоняется с *QuotaError, дизлайки не ограничены.
+func (u *Usecase) Like(ctx context.Context, fromUser, toUser int64, isLike bool, message string) error {
+	message = strings.TrimSpace(message)
+	if !isLike {
+		message = ""
+	}
+	if utf8.RuneCountInString(message) > MaxLikeMessage {
+		return ErrLongMessage
+	}
+
+	if isLike && u.dailyLikes > 0 {
+		me, err := u.userClient.GetProfile(ctx, fromUser)
+		if err != nil {
+			return err
+		}
+		start, end := u.today(me.Timezone)
+
+		ok, err := u.repo.LikeWithinQuota(ctx, fromUser, toUser, message, start, u.dailyLikes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &QuotaError{Limit: u.dailyLikes, ResetAt: end}
+		}
+		return nil
+	}
+	return u.repo.Like(ctx, fromUser, toUser, isLike, message)
+}
+
+// UndoLike отменяет последнюю оценку пользователя; отменённый лайк возвращается в дневной лимит.
+func (u *Usecase) UndoLike(ctx context.Context, fromUser, toUser int64) error {
+	ok, err := u.repo.UndoLike(ctx, fromUser, toUser)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNothingToUndo
+	}
+	return nil
+}
+
+// today возвращает начало текущих и следующих суток в часовом поясе пользователя.
+func (u *Usecase) today(tz string) (start, end time.Time) {
+	loc := u.defaultTZ
+	if tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	y, m, d := u.now().In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc), time.Date(y, m, d+1, 0, 0, 0, 0, loc)
 }
 
 func (u *Usecase) Match(ctx context.Context, fromUser int64, toUser int64) (bool, error) {
 	return u.repo.CheckMatch(ctx, fromUser, toUser)
 }
 
-func (u *Usecase) GetCandidats(ctx context.Context, telegramID int64) ([]*dto.User, error) {
+// HasLike сообщает, стоит ли лайк fromUser пользователю toUser.
+func (u *Usecase) HasLike(ctx context.Context, fromUser, toUser int64) (bool, error) {
+	return u.repo.HasLike(ctx, fromUser, toUser)
+}
+
+func (u *Usecase) DeleteUser(ctx context.Context, userID int64) (int64, error) {
+	if err := u.repo.DeleteCandidateCursor(ctx, userID); err != nil {
+		return 0, err
+	}
+	return u.repo.DeleteUser(ctx, userID)
+}
+
+// IncomingLikes возвращает лайки, на которые пользователь ещё не ответил.
+func (u *Usecase) IncomingLikes(ctx context.Context, userID int64, limit int) ([]entity.Match, error) {
+	if limit <= 0 || limit > maxIncomingLikes {
+		limit = maxIncomingLikes
+	}
+	return u.repo.IncomingLikes(ctx, userID, limit)
+}
+
+// ListMatches возвращает страницу совпадений и курсор следующей страницы ("" — если это последняя).
+func (u *Usecase) ListMatches(ctx context.Context, userID int64, cursor string, limit int) ([]entity.Match, string, error) {
+	if limit <= 0 || limit > maxMatchesPage {
+		limit = maxMatchesPage
+	}
+
+	var before *time.Time
+	var beforeUser int64
+	if cursor != "" {
+		var nanos int64
+		if _, err := fmt.Sscanf(cursor, "%d.%d", &nanos, &beforeUser); err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		t := time.Unix(0, nanos)
+		before = &t
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	list, err := u.repo.Matches(ctx, userID, before, beforeUser, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(list) <= limit {
+		return list, "", nil
+	}
+
+	list = list[:limit]
+	last := list[len(list)-1]
+	return list, fmt.Sprintf("%d.%d", last.CreatedAt.UnixNano(), last.ToUser), nil
+}
+
+// Unmatch заменяет лайк пользователя на дизлайк.
+func (u *Usecase) Unmatch(ctx context.Context, userID, otherID int64) error {
+	return u.repo.Unmatch(ctx, userID, otherID)
+}
+
+func (u *Usecase) Block(ctx context.Context, userID, blockedID int64) error {
+	if userID == blockedID {
+		return ErrSelfAction
+	}
+	return u.repo.Block(ctx, userID, blockedID)
+}
+
+// Report сохраняет жалобу в очередь модерации и блокирует пользователя для автора жалобы.
+func (u *Usecase) Report(ctx context.Context, r entity.Report) (int64, error) {
+	if r.Reporter == r.Reported {
+		return 0, ErrSelfAction
+	}
+	if !slices.Contains(ReportReasons, r.Reason) {
+		return 0, ErrInvalidReason
+	}
+	if c := []rune(r.Comment); len(c) > maxReportComment {
+		r.Comment = string(c[:maxReportComment])
+	}
+
+	id, err := u.repo.CreateReport(ctx, r)
+	if err != nil {
+		return 0, err
+	}
+	if err := u.repo.Block(ctx, r.Reporter, r.Reported); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (u *Usecase) Stats(ctx context.Context, since time.Time) (dto.Stats, error) {
+	return u.repo.Stats(ctx, since)
+}
+
+func (u *Usecase) UserStats(ctx context.Context, userID int64) (dto.UserStats, error) {
+	return u.repo.UserStats(ctx, userID)
+}
+
+// GetCandidats возвращает следующую страницу выдачи с места, где пользователь остановился.
+// Оценённые анкеты не возвращаются. hasMore == false — анкеты закончились, и следующий вызов
+// вернёт только анкеты, появившиеся после этого.
+func (u *Usecase) GetCandidats(ctx context.Context, telegramID int64) (list []*dto.User, hasMore bool, err error) {
 	me, err := u.userClient.GetByTelegramID(ctx, telegramID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	exclude, err := u.repo.TodayLikedIDs(ctx, me.ID)
+	exclude, err := u.repo.RatedIDs(ctx, me.ID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
+	blocked, err := u.repo.BlockedIDs(ctx, me.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	// свою анкету не показываем, даже если она подходит под настройки поиска
+	exclude = append(exclude, blocked...)
+	exclude = append(exclude, me.ID)
+
+	filter := u.candidateFilter(me)
+	filter.ExcludeIDs = exclude
 
+	// курсор годится, только пока не поменялся фильтр: иначе позиция в выдаче ничего не значит
+	key := filterKey(filter)
+	cur, err := u.repo.CandidateCursor(ctx, me.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if cur != nil && cur.Filter == key && u.now().Sub(cur.UpdatedAt) < candidateCursorTTL {
+		filter.Cursor = cur.Cursor
+	}
+
+	list, next, err := u.userClient.GetCandidates(ctx, filter)
+	if err != nil {
+		return nil, false, err
+	}
+	// долистанную выдачу не начинаем заново: курсор остаётся на последней странице,
+	// и с него выдача продолжится, когда появятся новые анкеты
+	pos := next
+	if pos == "" {
+		pos = filter.Cursor
+	}
+	if err := u.repo.SaveCandidateCursor(ctx, entity.CandidateCursor{UserID: me.ID, Filter: key, Cursor: pos}); err != nil {
+		return nil, false, err
+	}
+	return list, next != "", nil
+}
+
+// filterKey — отпечаток фильтра выдачи без исключённых анкет и размера страницы.
+func filterKey(f dto.Candidate) string {
+	geo := "-"
+	if f.Geo != nil {
+		geo = fmt.Sprintf("%.4f,%.4f", f.Geo.Latitude, f.Geo.Longitude)
+	}
+	return fmt.Sprintf("%s|%d-%d|%s|%s|%g|%t",
+		f.TargetGender, f.MinAge, f.MaxAge, strings.ToLower(strings.TrimSpace(f.Location)), geo, f.RadiusKm, f.AnyCity)
+}
+
+// candidateFilter строит фильтр выдачи из настроек поиска пользователя; незаданные настройки
+// заменяются значениями по умолчанию: противоположный пол, возраст ±3 года, свой город.
+// Если задана одна граница возраста, другая сдвигается так, чтобы диапазон был не уже 2*ageSpread лет.
+func (u *Usecase) candidateFilter(me *dto.User) dto.Candidate {
+	p := me.Prefs
 	filter := dto.Candidate{
 		TargetGender: utils.OppositeGender(me.Gender),
-		MinAge:       me.Age - 3,
-		MaxAge:       me.Age + 3,
+		MinAge:       me.Age - ageSpread,
+		MaxAge:       me.Age + ageSpread,
 		Location:     me.Location,
-		Limit:        20,
-		ExcludeIDs:   exclude,
+		Limit:        candidatesPage,
+		AnyCity:      p.AnyCity,
 	}
 
-	list, err := u.userClient.GetCandidates(ctx, filter)
-	if err != nil {
-		return nil, err
+	switch p.Gender {
+	case "":
+	case dto.SeekAnyone:
+		filter.TargetGender = ""
+	default:
+		filter.TargetGender = p.Gender
+	}
+	if p.MinAge > 0 {
+		filter.MinAge = p.MinAge
+		if p.MaxAge == 0 {
+			filter.MaxAge = max(filter.MaxAge, p.MinAge+2*ageSpread)
+		}
+	}
+	if p.MaxAge > 0 {
+		filter.MaxAge = p.MaxAge
+		if p.MinAge == 0 {
+			filter.MinAge = min(filter.MinAge, p.MaxAge-2*ageSpread)
+		}
+	}
+
+	if me.Geo != nil {
+		filter.Geo = me.Geo
+		filter.RadiusKm = u.radiusKm
+		if p.RadiusKm > 0 {
+			filter.RadiusKm = float64(p.RadiusKm)
+		}
 	}
-	return list, nil
+	return filter
 }
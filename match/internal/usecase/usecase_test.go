@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"app/match/internal/dto"
+	"app/match/internal/entity"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRepo — хранилище оценок без базы; нужные тесту методы переопределены.
+// Оценки повторяют семантику SQL: отменить можно только последнюю из них.
+type fakeRepo struct {
+	MatchRepo
+	blocked []int64
+	cursor  *entity.CandidateCursor
+	ratings map[[2]int64]*rating
+}
+
+type rating struct {
+	isLike   bool
+	undoable bool
+	prev     *bool
+}
+
+func (r *fakeRepo) Like(_ context.Context, from, to int64, isLike bool, _ string) error {
+	if r.ratings == nil {
+		r.ratings = make(map[[2]int64]*rating)
+	}
+	for k, v := range r.ratings {
+		if k[0] == from && k[1] != to {
+			v.undoable = false
+		}
+	}
+	next := &rating{isLike: isLike, undoable: true}
+	if old, ok := r.ratings[[2]int64{from, to}]; ok {
+		next.prev = &old.isLike
+	}
+	r.ratings[[2]int64{from, to}] = next
+	return nil
+}
+
+// LikeWithinQuota считает все лайки пользователя: тесты укладываются в одни сутки.
+func (r *fakeRepo) LikeWithinQuota(ctx context.Context, from, to int64, message string, _ time.Time, limit int) (bool, error) {
+	n := 0
+	for k, v := range r.ratings {
+		if k[0] == from && v.isLike {
+			n++
+		}
+	}
+	if n >= limit {
+		return false, nil
+	}
+	return true, r.Like(ctx, from, to, true, message)
+}
+
+func (r *fakeRepo) Unmatch(_ context.Context, from, to int64) error {
+	if v, ok := r.ratings[[2]int64{from, to}]; ok {
+		v.isLike, v.undoable = false, false
+	}
+	return nil
+}
+
+func (r *fakeRepo) UndoLike(_ context.Context, from, to int64) (bool, error) {
+	k := [2]int64{from, to}
+	v, ok := r.ratings[k]
+	if !ok || !v.undoable {
+		return false, nil
+	}
+	if v.prev == nil {
+		delete(r.ratings, k)
+	} else {
+		r.ratings[k] = &rating{isLike: *v.prev}
+	}
+	return true, nil
+}
+
+func (r *fakeRepo) RatedIDs(_ context.Context, from int64) ([]int64, error) {
+	var ids []int64
+	for k := range r.ratings {
+		if k[0] == from {
+			ids = append(ids, k[1])
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeRepo) BlockedIDs(context.Context, int64) ([]int64, error) {
+	return slices.Clone(r.blocked), nil
+}
+
+func (r *fakeRepo) CandidateCursor(context.Context, int64) (*entity.CandidateCursor, error) {
+	return r.cursor, nil
+}
+
+func (r *fakeRepo) SaveCandidateCursor(_ context.Context, c entity.CandidateCursor) error {
+	c.UpdatedAt = time.Now()
+	r.cursor = &c
+	return nil
+}
+
+func (r *fakeRepo) DeleteCandidateCursor(context.Context, int64) error {
+	r.cursor = nil
+	return nil
+}
+
+// fakeUsers отдаёт анкеты так же, как user service: по полу, без исключённых и страницами
+// по Limit в порядке all; курсор — id последней анкеты страницы.
+type fakeUsers struct {
+	UserClient
+	me     *dto.User
+	all    []*dto.User
+	filter dto.Candidate
+}
+
+func (f *fakeUsers) GetProfile(context.Context, int64) (*dto.User, error) {
+	return f.me, nil
+}
+
+func (f *fakeUsers) GetByTelegramID(context.Context, int64) (*dto.User, error) {
+	return f.me, nil
+}
+
+func (f *fakeUsers) GetCandidates(_ context.Context, filter dto.Candidate) ([]*dto.User, string, error) {
+	f.filter = filter
+	var after int64
+	if filter.Cursor != "" {
+		var err error
+		if after, err = strconv.ParseInt(filter.Cursor, 10, 64); err != nil {
+			return nil, "", err
+		}
+	}
+	var out []*dto.User
+	for _, u := range f.all {
+		if u.ID <= after || slices.Contains(filter.ExcludeIDs, u.ID) {
+			continue
+		}
+		if filter.TargetGender != "" && u.Gender != filter.TargetGender {
+			continue
+		}
+		if len(out) == filter.Limit {
+			return out, strconv.FormatInt(out[len(out)-1].ID, 10), nil
+		}
+		out = append(out, u)
+	}
+	return out, "", nil
+}
+
+func TestUsecase_GetCandidats_NeverSelf(t *testing.T) {
+	tests := []struct {
+		name   string
+		seek   string
+		wantID []int64
+	}{
+		{name: "opposite gender", seek: "", wantID: []int64{2}},
+		{name: "anyone", seek: dto.SeekAnyone, wantID: []int64{2, 3}},
+		{name: "own gender", seek: "Парень", wantID: []int64{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			me := &dto.User{ID: 1, TelegramID: 1001, Age: 27, Gender: "Парень", Location: "Berlin",
+				Prefs: dto.SearchPrefs{Gender: tt.seek}}
+			users := &fakeUsers{me: me, all: []*dto.User{
+				me,
+				{ID: 2, Age: 26, Gender: "Девушка", Location: "Berlin"},
+				{ID: 3, Age: 28, Gender: "Парень", Location: "Berlin"},
+			}}
+			uc := NewUseCase(&fakeRepo{blocked: []int64{9}}, users, 0, nil, 0)
+
+			list, _, err := uc.GetCandidats(t.Context(), me.TelegramID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, u := range list {
+				got = append(got, u.ID)
+			}
+			if !slices.Equal(got, tt.wantID) {
+				t.Fatalf("candidates %v, want %v", got, tt.wantID)
+			}
+			if !slices.Contains(users.filter.ExcludeIDs, 9) || !slices.Contains(users.filter.ExcludeIDs, me.ID) {
+				t.Fatalf("exclude ids %v must contain the blocked user and me", users.filter.ExcludeIDs)
+			}
+		})
+	}
+}
+
+func TestUsecase_CandidateFilter_Age(t *testing.T) {
+	tests := []struct {
+		name             string
+		min, max         int
+		wantMin, wantMax int
+	}{
+		{name: "defaults", wantMin: 22, wantMax: 28},
+		{name: "both bounds", min: 30, max: 35, wantMin: 30, wantMax: 35},
+		{name: "min inside default range", min: 24, wantMin: 24, wantMax: 30},
+		{name: "min above default max", min: 40, wantMin: 40, wantMax: 46},
+		{name: "max inside default range", max: 27, wantMin: 21, wantMax: 27},
+		{name: "max below default min", max: 19, wantMin: 13, wantMax: 19},
+	}
+	uc := NewUseCase(&fakeRepo{}, &fakeUsers{}, 0, nil, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := uc.candidateFilter(&dto.User{Age: 25, Gender: "Парень", Prefs: dto.SearchPrefs{MinAge: tt.min, MaxAge: tt.max}})
+			if f.MinAge != tt.wantMin || f.MaxAge != tt.wantMax {
+				t.Fatalf("age range %d..%d, want %d..%d", f.MinAge, f.MaxAge, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestUsecase_UnmatchKeepsUndo(t *testing.T) {
+	repo := &fakeRepo{}
+	uc := NewUseCase(repo, &fakeUsers{}, 0, nil, 0)
+	ctx := t.Context()
+
+	// 1 и 3 — давнее совпадение, 2 — последний лайк, который ещё можно отменить
+	if err := uc.Like(ctx, 1, 3, true, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.Like(ctx, 1, 2, true, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.Unmatch(ctx, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uc.UndoLike(ctx, 1, 2); err != nil {
+		t.Fatalf("undo after unmatching someone else: %v", err)
+	}
+	if _, ok := repo.ratings[[2]int64{1, 2}]; ok {
+		t.Fatal("the undone like is still stored")
+	}
+	if r := repo.ratings[[2]int64{1, 3}]; r == nil || r.isLike {
+		t.Fatalf("unmatch must leave a dislike, got %+v", r)
+	}
+	// сам разрыв совпадения не отменяется
+	if err := uc.UndoLike(ctx, 1, 3); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("undo of unmatch: want ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestUsecase_LikeQuota(t *testing.T) {
+	repo := &fakeRepo{}
+	uc := NewUseCase(repo, &fakeUsers{me: &dto.User{ID: 1}}, 2, nil, 0)
+	ctx := t.Context()
+
+	for _, to := range []int64{2, 3} {
+		if err := uc.Like(ctx, 1, to, true, ""); err != nil {
+			t.Fatalf("like %d: %v", to, err)
+		}
+	}
+	var quota *QuotaError
+	if err := uc.Like(ctx, 1, 4, true, ""); !errors.As(err, &quota) || quota.Limit != 2 {
+		t.Fatalf("like over the limit: want *QuotaError, got %v", err)
+	}
+	if _, ok := repo.ratings[[2]int64{1, 4}]; ok {
+		t.Fatal("like over the limit was stored")
+	}
+	// дизлайки лимитом не ограничены
+	if err := uc.Like(ctx, 1, 4, false, ""); err != nil {
+		t.Fatalf("dislike over the limit: %v", err)
+	}
+}
+
+func TestUsecase_GetCandidats_BrowseToTheEnd(t *testing.T) {
+	me := &dto.User{ID: 1, TelegramID: 1001, Age: 27, Gender: "Парень", Location: "Berlin"}
+	users := &fakeUsers{me: me, all: []*dto.User{me}}
+	add := func(id int64) {
+		users.all = append(users.all, &dto.User{ID: id, Age: 27, Gender: "Девушка", Location: "Berlin"})
+	}
+	const total = 2*candidatesPage + 5
+	for id := int64(2); id < 2+total; id++ {
+		add(id)
+	}
+	repo := &fakeRepo{}
+	uc := NewUseCase(repo, users, 0, nil, 0)
+	ctx := t.Context()
+
+	// browse листает выдачу до конца, оценивая каждую анкету, и возвращает показанные
+	browse := func() []int64 {
+		t.Helper()
+		var shown []int64
+		for {
+			list, more, err := uc.GetCandidats(ctx, me.TelegramID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, u := range list {
+				shown = append(shown, u.ID)
+				if err := uc.Like(ctx, me.ID, u.ID, i%2 == 0, ""); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !more {
+				return shown
+			}
+		}
+	}
+
+	shown := browse()
+	if len(shown) != total {
+		t.Fatalf("shown %d profiles, want %d", len(shown), total)
+	}
+	seen := make(map[int64]bool)
+	for _, id := range shown {
+		if seen[id] {
+			t.Fatalf("profile %d was shown twice", id)
+		}
+		seen[id] = true
+	}
+
+	// долистанная выдача не начинается заново, даже когда курсор устарел
+	if again := browse(); len(again) != 0 {
+		t.Fatalf("exhausted feed showed %v again", again)
+	}
+	repo.cursor.UpdatedAt = time.Now().Add(-2 * candidateCursorTTL)
+	if again := browse(); len(again) != 0 {
+		t.Fatalf("feed after cursor ttl showed %v again", again)
+	}
+
+	// новая анкета появляется в выдаче
+	add(100)
+	if got := browse(); !slices.Equal(got, []int64{100}) {
+		t.Fatalf("after a new profile: shown %v, want [100]", got)
+	}
+}
@@ -4,9 +4,9 @@ import "strings"
 
 func OppositeGender(g string) string {
 	switch strings.ToLower(g) {
-	case "Девушка":
+	case "девушка":
 		return "Парень"
-	case "Парень":
+	case "парень":
 		return "Девушка"
 	default:
 		return ""
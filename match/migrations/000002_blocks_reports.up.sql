@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker    BIGINT      NOT NULL,
+    blocked    BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker, blocked)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked ON blocks(blocked);
+
+-- очередь модерации: новые жалобы имеют статус 'open'
+CREATE TABLE IF NOT EXISTS reports (
+    id         BIGSERIAL PRIMARY KEY,
+    reporter   BIGINT      NOT NULL,
+    reported   BIGINT      NOT NULL,
+    reason     TEXT        NOT NULL,
+    comment    TEXT        NOT NULL DEFAULT '',
+    status     TEXT        NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_reported ON reports(reported);
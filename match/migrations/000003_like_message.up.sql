@@ -0,0 +1,2 @@
+-- короткое сообщение, которое пользователь приложил к лайку
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS message TEXT NOT NULL DEFAULT '';
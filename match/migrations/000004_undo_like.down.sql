@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_matches_undoable;
+ALTER TABLE matches DROP COLUMN IF EXISTS prev_created_at;
+ALTER TABLE matches DROP COLUMN IF EXISTS prev_message;
+ALTER TABLE matches DROP COLUMN IF EXISTS prev_is_like;
+ALTER TABLE matches DROP COLUMN IF EXISTS undoable;
@@ -0,0 +1,8 @@
+-- последнюю оценку пользователя можно отменить: undoable стоит только у неё,
+-- prev_* хранят оценку, которую она перезаписала (prev_is_like IS NULL — оценки не было)
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS undoable BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS prev_is_like BOOLEAN;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS prev_message TEXT NOT NULL DEFAULT '';
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS prev_created_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_matches_undoable ON matches(from_user) WHERE undoable;
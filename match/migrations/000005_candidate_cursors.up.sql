@@ -0,0 +1,8 @@
+-- докуда пользователь долистал выдачу: cursor — next_cursor user service, filter — отпечаток
+-- фильтра, при котором он получен (при смене настроек выдача начинается заново)
+CREATE TABLE IF NOT EXISTS candidate_cursors (
+    user_id    BIGINT      PRIMARY KEY,
+    filter     TEXT        NOT NULL,
+    cursor     TEXT        NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -22,10 +22,12 @@ const (
 )
 
 type LikeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromUser      int64                  `protobuf:"varint,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
-	ToUser        int64                  `protobuf:"varint,2,opt,name=to_user,json=toUser,proto3" json:"to_user,omitempty"`
-	IsLike        bool                   `protobuf:"varint,3,opt,name=is_like,json=isLike,proto3" json:"is_like,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FromUser int64                  `protobuf:"varint,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
+	ToUser   int64                  `protobuf:"varint,2,opt,name=to_user,json=toUser,proto3" json:"to_user,omitempty"`
+	IsLike   bool                   `protobuf:"varint,3,opt,name=is_like,json=isLike,proto3" json:"is_like,omitempty"`
+	// Сообщение к лайку, до 200 символов (длиннее — INVALID_ARGUMENT). У дизлайка игнорируется.
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +83,13 @@ func (x *LikeRequest) GetIsLike() bool {
 	return false
 }
 
+func (x *LikeRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type CheckMatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User1         int64                  `protobuf:"varint,1,opt,name=user1,proto3" json:"user1,omitempty"`
@@ -133,27 +142,28 @@ func (x *CheckMatchRequest) GetUser2() int64 {
 	return 0
 }
 
-type GetCandidatesRequest struct {
+type HasLikeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TelegramId    int64                  `protobuf:"varint,1,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	FromUser      int64                  `protobuf:"varint,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
+	ToUser        int64                  `protobuf:"varint,2,opt,name=to_user,json=toUser,proto3" json:"to_user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCandidatesRequest) Reset() {
-	*x = GetCandidatesRequest{}
+func (x *HasLikeRequest) Reset() {
+	*x = HasLikeRequest{}
 	mi := &file_match_proto_match_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCandidatesRequest) String() string {
+func (x *HasLikeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCandidatesRequest) ProtoMessage() {}
+func (*HasLikeRequest) ProtoMessage() {}
 
-func (x *GetCandidatesRequest) ProtoReflect() protoreflect.Message {
+func (x *HasLikeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_match_proto_match_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -165,39 +175,48 @@ func (x *GetCandidatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCandidatesRequest.ProtoReflect.Descriptor instead.
-func (*GetCandidatesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use HasLikeRequest.ProtoReflect.Descriptor instead.
+func (*HasLikeRequest) Descriptor() ([]byte, []int) {
 	return file_match_proto_match_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetCandidatesRequest) GetTelegramId() int64 {
+func (x *HasLikeRequest) GetFromUser() int64 {
 	if x != nil {
-		return x.TelegramId
+		return x.FromUser
 	}
 	return 0
 }
 
-type LikeResponse struct {
+func (x *HasLikeRequest) GetToUser() int64 {
+	if x != nil {
+		return x.ToUser
+	}
+	return 0
+}
+
+// Следующая страница выдачи: курсор хранит match service, поэтому повторный вызов
+// продолжает с места, где остановился предыдущий. Смена настроек поиска начинает выдачу заново.
+type GetCandidatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TelegramId    int64                  `protobuf:"varint,1,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikeResponse) Reset() {
-	*x = LikeResponse{}
+func (x *GetCandidatesRequest) Reset() {
+	*x = GetCandidatesRequest{}
 	mi := &file_match_proto_match_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikeResponse) String() string {
+func (x *GetCandidatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeResponse) ProtoMessage() {}
+func (*GetCandidatesRequest) ProtoMessage() {}
 
-func (x *LikeResponse) ProtoReflect() protoreflect.Message {
+func (x *GetCandidatesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_match_proto_match_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -209,39 +228,41 @@ func (x *LikeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeResponse.ProtoReflect.Descriptor instead.
-func (*LikeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCandidatesRequest.ProtoReflect.Descriptor instead.
+func (*GetCandidatesRequest) Descriptor() ([]byte, []int) {
 	return file_match_proto_match_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *LikeResponse) GetSuccess() bool {
+func (x *GetCandidatesRequest) GetTelegramId() int64 {
 	if x != nil {
-		return x.Success
+		return x.TelegramId
 	}
-	return false
+	return 0
 }
 
-type CheckMatchResponse struct {
+// Отменяет последнюю оценку: удаляет её или возвращает оценку, которая была до неё.
+type UndoLikeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Match         bool                   `protobuf:"varint,1,opt,name=match,proto3" json:"match,omitempty"`
+	FromUser      int64                  `protobuf:"varint,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
+	ToUser        int64                  `protobuf:"varint,2,opt,name=to_user,json=toUser,proto3" json:"to_user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckMatchResponse) Reset() {
-	*x = CheckMatchResponse{}
+func (x *UndoLikeRequest) Reset() {
+	*x = UndoLikeRequest{}
 	mi := &file_match_proto_match_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckMatchResponse) String() string {
+func (x *UndoLikeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckMatchResponse) ProtoMessage() {}
+func (*UndoLikeRequest) ProtoMessage() {}
 
-func (x *CheckMatchResponse) ProtoReflect() protoreflect.Message {
+func (x *UndoLikeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_match_proto_match_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -253,39 +274,47 @@ func (x *CheckMatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckMatchResponse.ProtoReflect.Descriptor instead.
-func (*CheckMatchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UndoLikeRequest.ProtoReflect.Descriptor instead.
+func (*UndoLikeRequest) Descriptor() ([]byte, []int) {
 	return file_match_proto_match_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CheckMatchResponse) GetMatch() bool {
+func (x *UndoLikeRequest) GetFromUser() int64 {
 	if x != nil {
-		return x.Match
+		return x.FromUser
 	}
-	return false
+	return 0
 }
 
-type GetCandidatesResponse struct {
+func (x *UndoLikeRequest) GetToUser() int64 {
+	if x != nil {
+		return x.ToUser
+	}
+	return 0
+}
+
+// Удаляет все лайки пользователя: и поставленные им, и полученные.
+type DeleteUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Candidates    []*User                `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCandidatesResponse) Reset() {
-	*x = GetCandidatesResponse{}
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
 	mi := &file_match_proto_match_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCandidatesResponse) String() string {
+func (x *DeleteUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCandidatesResponse) ProtoMessage() {}
+func (*DeleteUserRequest) ProtoMessage() {}
 
-func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_match_proto_match_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,48 +326,41 @@ func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCandidatesResponse.ProtoReflect.Descriptor instead.
-func (*GetCandidatesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
 	return file_match_proto_match_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetCandidatesResponse) GetCandidates() []*User {
+func (x *DeleteUserRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Candidates
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-type User struct {
+// Лайки, на которые пользователь ещё не ответил, новые первыми.
+type ListIncomingLikesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TelegramId    int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	Age           int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
-	Gender        string                 `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
-	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	PhotoUrl      string                 `protobuf:"bytes,8,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
-	IsVisible     bool                   `protobuf:"varint,9,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *ListIncomingLikesRequest) Reset() {
+	*x = ListIncomingLikesRequest{}
 	mi := &file_match_proto_match_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *ListIncomingLikesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*ListIncomingLikesRequest) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *ListIncomingLikesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_match_proto_match_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -350,104 +372,1289 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListIncomingLikesRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomingLikesRequest) Descriptor() ([]byte, []int) {
 	return file_match_proto_match_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *User) GetId() int64 {
+func (x *ListIncomingLikesRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *User) GetTelegramId() int64 {
+func (x *ListIncomingLikesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.TelegramId
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *User) GetUsername() string {
+// Взаимные лайки, новые первыми. cursor — next_cursor из предыдущей страницы.
+type ListMatchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[7]
 	if x != nil {
-		return x.Username
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetAge() int32 {
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMatchesRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Age
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *User) GetGender() string {
+func (x *ListMatchesRequest) GetCursor() string {
 	if x != nil {
-		return x.Gender
+		return x.Cursor
 	}
 	return ""
 }
 
-func (x *User) GetLocation() string {
+func (x *ListMatchesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Location
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetDescription() string {
+// Отменяет лайк пользователя, совпадение пропадает у обоих.
+type UnmatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtherUserId   int64                  `protobuf:"varint,2,opt,name=other_user_id,json=otherUserId,proto3" json:"other_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchRequest) Reset() {
+	*x = UnmatchRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchRequest) ProtoMessage() {}
+
+func (x *UnmatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[8]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetPhotoUrl() string {
+// Deprecated: Use UnmatchRequest.ProtoReflect.Descriptor instead.
+func (*UnmatchRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnmatchRequest) GetUserId() int64 {
 	if x != nil {
-		return x.PhotoUrl
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetIsVisible() bool {
+func (x *UnmatchRequest) GetOtherUserId() int64 {
 	if x != nil {
-		return x.IsVisible
+		return x.OtherUserId
 	}
-	return false
+	return 0
 }
 
-func (x *User) GetCreatedAt() string {
+// Заблокированные пользователи не видят друг друга в выдаче, входящих лайках и совпадениях.
+type BlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId int64                  `protobuf:"varint,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockRequest) Reset() {
+	*x = BlockRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRequest) ProtoMessage() {}
+
+func (x *BlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[9]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRequest.ProtoReflect.Descriptor instead.
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BlockRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BlockRequest) GetBlockedUserId() int64 {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return 0
+}
+
+// Жалоба попадает в очередь модерации, а пользователь блокируется.
+// reason: spam, fake, abuse, underage, other.
+type ReportRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReporterId     int64                  `protobuf:"varint,1,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	ReportedUserId int64                  `protobuf:"varint,2,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Comment        string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportRequest) Reset() {
+	*x = ReportRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest) ProtoMessage() {}
+
+func (x *ReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest.ProtoReflect.Descriptor instead.
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReportRequest) GetReporterId() int64 {
+	if x != nil {
+		return x.ReporterId
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetReportedUserId() int64 {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-var File_match_proto_match_proto protoreflect.FileDescriptor
+func (x *ReportRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
 
-const file_match_proto_match_proto_rawDesc = "" +
-	"\n" +
-	"\x17match/proto/match.proto\x12\x05match\"\\\n" +
-	"\vLikeRequest\x12\x1b\n" +
-	"\tfrom_user\x18\x01 \x01(\x03R\bfromUser\x12\x17\n" +
-	"\ato_user\x18\x02 \x01(\x03R\x06toUser\x12\x17\n" +
-	"\ais_like\x18\x03 \x01(\bR\x06isLike\"?\n" +
-	"\x11CheckMatchRequest\x12\x14\n" +
-	"\x05user1\x18\x01 \x01(\x03R\x05user1\x12\x14\n" +
-	"\x05user2\x18\x02 \x01(\x03R\x05user2\"7\n" +
-	"\x14GetCandidatesRequest\x12\x1f\n" +
-	"\vtelegram_id\x18\x01 \x01(\x03R\n" +
-	"telegramId\"(\n" +
-	"\fLikeResponse\x12\x18\n" +
+type LikeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeResponse) Reset() {
+	*x = LikeResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeResponse) ProtoMessage() {}
+
+func (x *LikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeResponse.ProtoReflect.Descriptor instead.
+func (*LikeResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LikeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CheckMatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         bool                   `protobuf:"varint,1,opt,name=match,proto3" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckMatchResponse) Reset() {
+	*x = CheckMatchResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckMatchResponse) ProtoMessage() {}
+
+func (x *CheckMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckMatchResponse.ProtoReflect.Descriptor instead.
+func (*CheckMatchResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckMatchResponse) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+type HasLikeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Liked         bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasLikeResponse) Reset() {
+	*x = HasLikeResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasLikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasLikeResponse) ProtoMessage() {}
+
+func (x *HasLikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasLikeResponse.ProtoReflect.Descriptor instead.
+func (*HasLikeResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HasLikeResponse) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+type GetCandidatesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Candidates []*User                `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// false — анкеты закончились, следующий вызов вернёт только анкеты, появившиеся после этого.
+	HasMore       bool `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandidatesResponse) Reset() {
+	*x = GetCandidatesResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidatesResponse) ProtoMessage() {}
+
+func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidatesResponse.ProtoReflect.Descriptor instead.
+func (*GetCandidatesResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCandidatesResponse) GetCandidates() []*User {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *GetCandidatesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteUserResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type ListIncomingLikesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*IncomingLike        `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingLikesResponse) Reset() {
+	*x = ListIncomingLikesResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingLikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingLikesResponse) ProtoMessage() {}
+
+func (x *ListIncomingLikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingLikesResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomingLikesResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListIncomingLikesResponse) GetLikes() []*IncomingLike {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+type ListMatchesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Matches []*MatchedUser         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Пустой, если страниц больше нет.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*MatchedUser {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type MatchedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MatchedAt     string                 `protobuf:"bytes,2,opt,name=matched_at,json=matchedAt,proto3" json:"matched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchedUser) Reset() {
+	*x = MatchedUser{}
+	mi := &file_match_proto_match_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchedUser) ProtoMessage() {}
+
+func (x *MatchedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchedUser.ProtoReflect.Descriptor instead.
+func (*MatchedUser) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MatchedUser) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MatchedUser) GetMatchedAt() string {
+	if x != nil {
+		return x.MatchedAt
+	}
+	return ""
+}
+
+type UndoLikeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoLikeResponse) Reset() {
+	*x = UndoLikeResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoLikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoLikeResponse) ProtoMessage() {}
+
+func (x *UndoLikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoLikeResponse.ProtoReflect.Descriptor instead.
+func (*UndoLikeResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UndoLikeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnmatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchResponse) Reset() {
+	*x = UnmatchResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchResponse) ProtoMessage() {}
+
+func (x *UnmatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchResponse.ProtoReflect.Descriptor instead.
+func (*UnmatchResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnmatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockResponse) Reset() {
+	*x = BlockResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockResponse) ProtoMessage() {}
+
+func (x *BlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockResponse.ProtoReflect.Descriptor instead.
+func (*BlockResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BlockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      int64                  `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportResponse) Reset() {
+	*x = ReportResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResponse) ProtoMessage() {}
+
+func (x *ReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResponse.ProtoReflect.Descriptor instead.
+func (*ReportResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReportResponse) GetReportId() int64 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+type IncomingLike struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUser      int64                  `protobuf:"varint,1,opt,name=from_user,json=fromUser,proto3" json:"from_user,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomingLike) Reset() {
+	*x = IncomingLike{}
+	mi := &file_match_proto_match_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomingLike) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomingLike) ProtoMessage() {}
+
+func (x *IncomingLike) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomingLike.ProtoReflect.Descriptor instead.
+func (*IncomingLike) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IncomingLike) GetFromUser() int64 {
+	if x != nil {
+		return x.FromUser
+	}
+	return 0
+}
+
+func (x *IncomingLike) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *IncomingLike) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TelegramId    int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Age           int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	Gender        string                 `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
+	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	PhotoUrl      string                 `protobuf:"bytes,8,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	IsVisible     bool                   `protobuf:"varint,9,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_match_proto_match_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetTelegramId() int64 {
+	if x != nil {
+		return x.TelegramId
+	}
+	return 0
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *User) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *User) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *User) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *User) GetIsVisible() bool {
+	if x != nil {
+		return x.IsVisible
+	}
+	return false
+}
+
+func (x *User) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начало периода, unix-время в секундах.
+	Since         int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetStatsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// Совпадение относится к моменту второго лайка пары.
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         int64                  `protobuf:"varint,1,opt,name=likes,proto3" json:"likes,omitempty"`
+	Matches       int64                  `protobuf:"varint,2,opt,name=matches,proto3" json:"matches,omitempty"`
+	OpenReports   int64                  `protobuf:"varint,3,opt,name=open_reports,json=openReports,proto3" json:"open_reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetStatsResponse) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMatches() int64 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetOpenReports() int64 {
+	if x != nil {
+		return x.OpenReports
+	}
+	return 0
+}
+
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_match_proto_match_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetUserStatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetUserStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	LikesGiven      int64                  `protobuf:"varint,1,opt,name=likes_given,json=likesGiven,proto3" json:"likes_given,omitempty"`
+	LikesReceived   int64                  `protobuf:"varint,2,opt,name=likes_received,json=likesReceived,proto3" json:"likes_received,omitempty"`
+	Matches         int64                  `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`
+	ReportsReceived int64                  `protobuf:"varint,4,opt,name=reports_received,json=reportsReceived,proto3" json:"reports_received,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetUserStatsResponse) Reset() {
+	*x = GetUserStatsResponse{}
+	mi := &file_match_proto_match_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsResponse) ProtoMessage() {}
+
+func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_match_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStatsResponse) Descriptor() ([]byte, []int) {
+	return file_match_proto_match_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetUserStatsResponse) GetLikesGiven() int64 {
+	if x != nil {
+		return x.LikesGiven
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetLikesReceived() int64 {
+	if x != nil {
+		return x.LikesReceived
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetMatches() int64 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetReportsReceived() int64 {
+	if x != nil {
+		return x.ReportsReceived
+	}
+	return 0
+}
+
+var File_match_proto_match_proto protoreflect.FileDescriptor
+
+const file_match_proto_match_proto_rawDesc = "" +
+	"\n" +
+	"\x17match/proto/match.proto\x12\x05match\"v\n" +
+	"\vLikeRequest\x12\x1b\n" +
+	"\tfrom_user\x18\x01 \x01(\x03R\bfromUser\x12\x17\n" +
+	"\ato_user\x18\x02 \x01(\x03R\x06toUser\x12\x17\n" +
+	"\ais_like\x18\x03 \x01(\bR\x06isLike\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"?\n" +
+	"\x11CheckMatchRequest\x12\x14\n" +
+	"\x05user1\x18\x01 \x01(\x03R\x05user1\x12\x14\n" +
+	"\x05user2\x18\x02 \x01(\x03R\x05user2\"F\n" +
+	"\x0eHasLikeRequest\x12\x1b\n" +
+	"\tfrom_user\x18\x01 \x01(\x03R\bfromUser\x12\x17\n" +
+	"\ato_user\x18\x02 \x01(\x03R\x06toUser\"7\n" +
+	"\x14GetCandidatesRequest\x12\x1f\n" +
+	"\vtelegram_id\x18\x01 \x01(\x03R\n" +
+	"telegramId\"G\n" +
+	"\x0fUndoLikeRequest\x12\x1b\n" +
+	"\tfrom_user\x18\x01 \x01(\x03R\bfromUser\x12\x17\n" +
+	"\ato_user\x18\x02 \x01(\x03R\x06toUser\",\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"I\n" +
+	"\x18ListIncomingLikesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"[\n" +
+	"\x12ListMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"M\n" +
+	"\x0eUnmatchRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\"\n" +
+	"\rother_user_id\x18\x02 \x01(\x03R\votherUserId\"O\n" +
+	"\fBlockRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\x03R\rblockedUserId\"\x8c\x01\n" +
+	"\rReportRequest\x12\x1f\n" +
+	"\vreporter_id\x18\x01 \x01(\x03R\n" +
+	"reporterId\x12(\n" +
+	"\x10reported_user_id\x18\x02 \x01(\x03R\x0ereportedUserId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"(\n" +
+	"\fLikeResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"*\n" +
 	"\x12CheckMatchResponse\x12\x14\n" +
-	"\x05match\x18\x01 \x01(\bR\x05match\"D\n" +
+	"\x05match\x18\x01 \x01(\bR\x05match\"'\n" +
+	"\x0fHasLikeResponse\x12\x14\n" +
+	"\x05liked\x18\x01 \x01(\bR\x05liked\"_\n" +
 	"\x15GetCandidatesResponse\x12+\n" +
 	"\n" +
 	"candidates\x18\x01 \x03(\v2\v.match.UserR\n" +
-	"candidates\"\x96\x02\n" +
+	"candidates\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\".\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"F\n" +
+	"\x19ListIncomingLikesResponse\x12)\n" +
+	"\x05likes\x18\x01 \x03(\v2\x13.match.IncomingLikeR\x05likes\"d\n" +
+	"\x13ListMatchesResponse\x12,\n" +
+	"\amatches\x18\x01 \x03(\v2\x12.match.MatchedUserR\amatches\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"E\n" +
+	"\vMatchedUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"matched_at\x18\x02 \x01(\tR\tmatchedAt\",\n" +
+	"\x10UndoLikeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"+\n" +
+	"\x0fUnmatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\")\n" +
+	"\rBlockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"-\n" +
+	"\x0eReportResponse\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\x03R\breportId\"d\n" +
+	"\fIncomingLike\x12\x1b\n" +
+	"\tfrom_user\x18\x01 \x01(\x03R\bfromUser\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x96\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vtelegram_id\x18\x02 \x01(\x03R\n" +
@@ -462,12 +1669,37 @@ const file_match_proto_match_proto_rawDesc = "" +
 	"is_visible\x18\t \x01(\bR\tisVisible\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\tR\tcreatedAt2\xce\x01\n" +
+	" \x01(\tR\tcreatedAt\"'\n" +
+	"\x0fGetStatsRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\"e\n" +
+	"\x10GetStatsResponse\x12\x14\n" +
+	"\x05likes\x18\x01 \x01(\x03R\x05likes\x12\x18\n" +
+	"\amatches\x18\x02 \x01(\x03R\amatches\x12!\n" +
+	"\fopen_reports\x18\x03 \x01(\x03R\vopenReports\".\n" +
+	"\x13GetUserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xa3\x01\n" +
+	"\x14GetUserStatsResponse\x12\x1f\n" +
+	"\vlikes_given\x18\x01 \x01(\x03R\n" +
+	"likesGiven\x12%\n" +
+	"\x0elikes_received\x18\x02 \x01(\x03R\rlikesReceived\x12\x18\n" +
+	"\amatches\x18\x03 \x01(\x03R\amatches\x12)\n" +
+	"\x10reports_received\x18\x04 \x01(\x03R\x0freportsReceived2\xd1\x06\n" +
 	"\fMatchService\x12/\n" +
 	"\x04Like\x12\x12.match.LikeRequest\x1a\x13.match.LikeResponse\x12A\n" +
 	"\n" +
-	"CheckMatch\x12\x18.match.CheckMatchRequest\x1a\x19.match.CheckMatchResponse\x12J\n" +
-	"\rGetCandidates\x12\x1b.match.GetCandidatesRequest\x1a\x1c.match.GetCandidatesResponseB\x15Z\x13match/proto;matchpbb\x06proto3"
+	"CheckMatch\x12\x18.match.CheckMatchRequest\x1a\x19.match.CheckMatchResponse\x128\n" +
+	"\aHasLike\x12\x15.match.HasLikeRequest\x1a\x16.match.HasLikeResponse\x12J\n" +
+	"\rGetCandidates\x12\x1b.match.GetCandidatesRequest\x1a\x1c.match.GetCandidatesResponse\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x18.match.DeleteUserRequest\x1a\x19.match.DeleteUserResponse\x12V\n" +
+	"\x11ListIncomingLikes\x12\x1f.match.ListIncomingLikesRequest\x1a .match.ListIncomingLikesResponse\x12D\n" +
+	"\vListMatches\x12\x19.match.ListMatchesRequest\x1a\x1a.match.ListMatchesResponse\x128\n" +
+	"\aUnmatch\x12\x15.match.UnmatchRequest\x1a\x16.match.UnmatchResponse\x122\n" +
+	"\x05Block\x12\x13.match.BlockRequest\x1a\x14.match.BlockResponse\x125\n" +
+	"\x06Report\x12\x14.match.ReportRequest\x1a\x15.match.ReportResponse\x12;\n" +
+	"\bGetStats\x12\x16.match.GetStatsRequest\x1a\x17.match.GetStatsResponse\x12G\n" +
+	"\fGetUserStats\x12\x1a.match.GetUserStatsRequest\x1a\x1b.match.GetUserStatsResponse\x12;\n" +
+	"\bUndoLike\x12\x16.match.UndoLikeRequest\x1a\x17.match.UndoLikeResponseB\x15Z\x13match/proto;matchpbb\x06proto3"
 
 var (
 	file_match_proto_match_proto_rawDescOnce sync.Once
@@ -481,29 +1713,73 @@ func file_match_proto_match_proto_rawDescGZIP() []byte {
 	return file_match_proto_match_proto_rawDescData
 }
 
-var file_match_proto_match_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_match_proto_match_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_match_proto_match_proto_goTypes = []any{
-	(*LikeRequest)(nil),           // 0: match.LikeRequest
-	(*CheckMatchRequest)(nil),     // 1: match.CheckMatchRequest
-	(*GetCandidatesRequest)(nil),  // 2: match.GetCandidatesRequest
-	(*LikeResponse)(nil),          // 3: match.LikeResponse
-	(*CheckMatchResponse)(nil),    // 4: match.CheckMatchResponse
-	(*GetCandidatesResponse)(nil), // 5: match.GetCandidatesResponse
-	(*User)(nil),                  // 6: match.User
+	(*LikeRequest)(nil),               // 0: match.LikeRequest
+	(*CheckMatchRequest)(nil),         // 1: match.CheckMatchRequest
+	(*HasLikeRequest)(nil),            // 2: match.HasLikeRequest
+	(*GetCandidatesRequest)(nil),      // 3: match.GetCandidatesRequest
+	(*UndoLikeRequest)(nil),           // 4: match.UndoLikeRequest
+	(*DeleteUserRequest)(nil),         // 5: match.DeleteUserRequest
+	(*ListIncomingLikesRequest)(nil),  // 6: match.ListIncomingLikesRequest
+	(*ListMatchesRequest)(nil),        // 7: match.ListMatchesRequest
+	(*UnmatchRequest)(nil),            // 8: match.UnmatchRequest
+	(*BlockRequest)(nil),              // 9: match.BlockRequest
+	(*ReportRequest)(nil),             // 10: match.ReportRequest
+	(*LikeResponse)(nil),              // 11: match.LikeResponse
+	(*CheckMatchResponse)(nil),        // 12: match.CheckMatchResponse
+	(*HasLikeResponse)(nil),           // 13: match.HasLikeResponse
+	(*GetCandidatesResponse)(nil),     // 14: match.GetCandidatesResponse
+	(*DeleteUserResponse)(nil),        // 15: match.DeleteUserResponse
+	(*ListIncomingLikesResponse)(nil), // 16: match.ListIncomingLikesResponse
+	(*ListMatchesResponse)(nil),       // 17: match.ListMatchesResponse
+	(*MatchedUser)(nil),               // 18: match.MatchedUser
+	(*UndoLikeResponse)(nil),          // 19: match.UndoLikeResponse
+	(*UnmatchResponse)(nil),           // 20: match.UnmatchResponse
+	(*BlockResponse)(nil),             // 21: match.BlockResponse
+	(*ReportResponse)(nil),            // 22: match.ReportResponse
+	(*IncomingLike)(nil),              // 23: match.IncomingLike
+	(*User)(nil),                      // 24: match.User
+	(*GetStatsRequest)(nil),           // 25: match.GetStatsRequest
+	(*GetStatsResponse)(nil),          // 26: match.GetStatsResponse
+	(*GetUserStatsRequest)(nil),       // 27: match.GetUserStatsRequest
+	(*GetUserStatsResponse)(nil),      // 28: match.GetUserStatsResponse
 }
 var file_match_proto_match_proto_depIdxs = []int32{
-	6, // 0: match.GetCandidatesResponse.candidates:type_name -> match.User
-	0, // 1: match.MatchService.Like:input_type -> match.LikeRequest
-	1, // 2: match.MatchService.CheckMatch:input_type -> match.CheckMatchRequest
-	2, // 3: match.MatchService.GetCandidates:input_type -> match.GetCandidatesRequest
-	3, // 4: match.MatchService.Like:output_type -> match.LikeResponse
-	4, // 5: match.MatchService.CheckMatch:output_type -> match.CheckMatchResponse
-	5, // 6: match.MatchService.GetCandidates:output_type -> match.GetCandidatesResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	24, // 0: match.GetCandidatesResponse.candidates:type_name -> match.User
+	23, // 1: match.ListIncomingLikesResponse.likes:type_name -> match.IncomingLike
+	18, // 2: match.ListMatchesResponse.matches:type_name -> match.MatchedUser
+	0,  // 3: match.MatchService.Like:input_type -> match.LikeRequest
+	1,  // 4: match.MatchService.CheckMatch:input_type -> match.CheckMatchRequest
+	2,  // 5: match.MatchService.HasLike:input_type -> match.HasLikeRequest
+	3,  // 6: match.MatchService.GetCandidates:input_type -> match.GetCandidatesRequest
+	5,  // 7: match.MatchService.DeleteUser:input_type -> match.DeleteUserRequest
+	6,  // 8: match.MatchService.ListIncomingLikes:input_type -> match.ListIncomingLikesRequest
+	7,  // 9: match.MatchService.ListMatches:input_type -> match.ListMatchesRequest
+	8,  // 10: match.MatchService.Unmatch:input_type -> match.UnmatchRequest
+	9,  // 11: match.MatchService.Block:input_type -> match.BlockRequest
+	10, // 12: match.MatchService.Report:input_type -> match.ReportRequest
+	25, // 13: match.MatchService.GetStats:input_type -> match.GetStatsRequest
+	27, // 14: match.MatchService.GetUserStats:input_type -> match.GetUserStatsRequest
+	4,  // 15: match.MatchService.UndoLike:input_type -> match.UndoLikeRequest
+	11, // 16: match.MatchService.Like:output_type -> match.LikeResponse
+	12, // 17: match.MatchService.CheckMatch:output_type -> match.CheckMatchResponse
+	13, // 18: match.MatchService.HasLike:output_type -> match.HasLikeResponse
+	14, // 19: match.MatchService.GetCandidates:output_type -> match.GetCandidatesResponse
+	15, // 20: match.MatchService.DeleteUser:output_type -> match.DeleteUserResponse
+	16, // 21: match.MatchService.ListIncomingLikes:output_type -> match.ListIncomingLikesResponse
+	17, // 22: match.MatchService.ListMatches:output_type -> match.ListMatchesResponse
+	20, // 23: match.MatchService.Unmatch:output_type -> match.UnmatchResponse
+	21, // 24: match.MatchService.Block:output_type -> match.BlockResponse
+	22, // 25: match.MatchService.Report:output_type -> match.ReportResponse
+	26, // 26: match.MatchService.GetStats:output_type -> match.GetStatsResponse
+	28, // 27: match.MatchService.GetUserStats:output_type -> match.GetUserStatsResponse
+	19, // 28: match.MatchService.UndoLike:output_type -> match.UndoLikeResponse
+	16, // [16:29] is the sub-list for method output_type
+	3,  // [3:16] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_match_proto_match_proto_init() }
@@ -517,7 +1793,7 @@ func file_match_proto_match_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_match_proto_match_proto_rawDesc), len(file_match_proto_match_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchService_Like_FullMethodName          = "/match.MatchService/Like"
-	MatchService_CheckMatch_FullMethodName    = "/match.MatchService/CheckMatch"
-	MatchService_GetCandidates_FullMethodName = "/match.MatchService/GetCandidates"
+	MatchService_Like_FullMethodName              = "/match.MatchService/Like"
+	MatchService_CheckMatch_FullMethodName        = "/match.MatchService/CheckMatch"
+	MatchService_HasLike_FullMethodName           = "/match.MatchService/HasLike"
+	MatchService_GetCandidates_FullMethodName     = "/match.MatchService/GetCandidates"
+	MatchService_DeleteUser_FullMethodName        = "/match.MatchService/DeleteUser"
+	MatchService_ListIncomingLikes_FullMethodName = "/match.MatchService/ListIncomingLikes"
+	MatchService_ListMatches_FullMethodName       = "/match.MatchService/ListMatches"
+	MatchService_Unmatch_FullMethodName           = "/match.MatchService/Unmatch"
+	MatchService_Block_FullMethodName             = "/match.MatchService/Block"
+	MatchService_Report_FullMethodName            = "/match.MatchService/Report"
+	MatchService_GetStats_FullMethodName          = "/match.MatchService/GetStats"
+	MatchService_GetUserStats_FullMethodName      = "/match.MatchService/GetUserStats"
+	MatchService_UndoLike_FullMethodName          = "/match.MatchService/UndoLike"
 )
 
 // MatchServiceClient is the client API for MatchService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MatchServiceClient interface {
+	// Like возвращает RESOURCE_EXHAUSTED с RetryInfo (время до обновления лимита),
+	// если дневной лимит лайков исчерпан.
 	Like(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*LikeResponse, error)
 	CheckMatch(ctx context.Context, in *CheckMatchRequest, opts ...grpc.CallOption) (*CheckMatchResponse, error)
+	// HasLike сообщает, стоит ли сейчас лайк from_user пользователю to_user: отменённый лайк
+	// и лайк между заблокировавшими друг друга пользователями не считаются.
+	HasLike(ctx context.Context, in *HasLikeRequest, opts ...grpc.CallOption) (*HasLikeResponse, error)
 	GetCandidates(ctx context.Context, in *GetCandidatesRequest, opts ...grpc.CallOption) (*GetCandidatesResponse, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	ListIncomingLikes(ctx context.Context, in *ListIncomingLikesRequest, opts ...grpc.CallOption) (*ListIncomingLikesResponse, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error)
+	Block(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	Report(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*GetUserStatsResponse, error)
+	// UndoLike возвращает FAILED_PRECONDITION, если оценка to_user — не последняя
+	// оценка from_user или уже отменена.
+	UndoLike(ctx context.Context, in *UndoLikeRequest, opts ...grpc.CallOption) (*UndoLikeResponse, error)
 }
 
 type matchServiceClient struct {
@@ -61,6 +87,16 @@ func (c *matchServiceClient) CheckMatch(ctx context.Context, in *CheckMatchReque
 	return out, nil
 }
 
+func (c *matchServiceClient) HasLike(ctx context.Context, in *HasLikeRequest, opts ...grpc.CallOption) (*HasLikeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HasLikeResponse)
+	err := c.cc.Invoke(ctx, MatchService_HasLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *matchServiceClient) GetCandidates(ctx context.Context, in *GetCandidatesRequest, opts ...grpc.CallOption) (*GetCandidatesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCandidatesResponse)
@@ -71,13 +107,119 @@ func (c *matchServiceClient) GetCandidates(ctx context.Context, in *GetCandidate
 	return out, nil
 }
 
+func (c *matchServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserResponse)
+	err := c.cc.Invoke(ctx, MatchService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) ListIncomingLikes(ctx context.Context, in *ListIncomingLikesRequest, opts ...grpc.CallOption) (*ListIncomingLikesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomingLikesResponse)
+	err := c.cc.Invoke(ctx, MatchService_ListIncomingLikes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, MatchService_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnmatchResponse)
+	err := c.cc.Invoke(ctx, MatchService_Unmatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) Block(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, MatchService_Block_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) Report(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportResponse)
+	err := c.cc.Invoke(ctx, MatchService_Report_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*GetUserStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserStatsResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetUserStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) UndoLike(ctx context.Context, in *UndoLikeRequest, opts ...grpc.CallOption) (*UndoLikeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndoLikeResponse)
+	err := c.cc.Invoke(ctx, MatchService_UndoLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchServiceServer is the server API for MatchService service.
 // All implementations must embed UnimplementedMatchServiceServer
 // for forward compatibility.
 type MatchServiceServer interface {
+	// Like возвращает RESOURCE_EXHAUSTED с RetryInfo (время до обновления лимита),
+	// если дневной лимит лайков исчерпан.
 	Like(context.Context, *LikeRequest) (*LikeResponse, error)
 	CheckMatch(context.Context, *CheckMatchRequest) (*CheckMatchResponse, error)
+	// HasLike сообщает, стоит ли сейчас лайк from_user пользователю to_user: отменённый лайк
+	// и лайк между заблокировавшими друг друга пользователями не считаются.
+	HasLike(context.Context, *HasLikeRequest) (*HasLikeResponse, error)
 	GetCandidates(context.Context, *GetCandidatesRequest) (*GetCandidatesResponse, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	ListIncomingLikes(context.Context, *ListIncomingLikesRequest) (*ListIncomingLikesResponse, error)
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error)
+	Block(context.Context, *BlockRequest) (*BlockResponse, error)
+	Report(context.Context, *ReportRequest) (*ReportResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	GetUserStats(context.Context, *GetUserStatsRequest) (*GetUserStatsResponse, error)
+	// UndoLike возвращает FAILED_PRECONDITION, если оценка to_user — не последняя
+	// оценка from_user или уже отменена.
+	UndoLike(context.Context, *UndoLikeRequest) (*UndoLikeResponse, error)
 	mustEmbedUnimplementedMatchServiceServer()
 }
 
@@ -94,9 +236,39 @@ func (UnimplementedMatchServiceServer) Like(context.Context, *LikeRequest) (*Lik
 func (UnimplementedMatchServiceServer) CheckMatch(context.Context, *CheckMatchRequest) (*CheckMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckMatch not implemented")
 }
+func (UnimplementedMatchServiceServer) HasLike(context.Context, *HasLikeRequest) (*HasLikeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasLike not implemented")
+}
 func (UnimplementedMatchServiceServer) GetCandidates(context.Context, *GetCandidatesRequest) (*GetCandidatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCandidates not implemented")
 }
+func (UnimplementedMatchServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedMatchServiceServer) ListIncomingLikes(context.Context, *ListIncomingLikesRequest) (*ListIncomingLikesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomingLikes not implemented")
+}
+func (UnimplementedMatchServiceServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedMatchServiceServer) Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unmatch not implemented")
+}
+func (UnimplementedMatchServiceServer) Block(context.Context, *BlockRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
+}
+func (UnimplementedMatchServiceServer) Report(context.Context, *ReportRequest) (*ReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Report not implemented")
+}
+func (UnimplementedMatchServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedMatchServiceServer) GetUserStats(context.Context, *GetUserStatsRequest) (*GetUserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStats not implemented")
+}
+func (UnimplementedMatchServiceServer) UndoLike(context.Context, *UndoLikeRequest) (*UndoLikeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoLike not implemented")
+}
 func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
 func (UnimplementedMatchServiceServer) testEmbeddedByValue()                      {}
 
@@ -154,6 +326,24 @@ func _MatchService_CheckMatch_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_HasLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).HasLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_HasLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).HasLike(ctx, req.(*HasLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MatchService_GetCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCandidatesRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +362,168 @@ func _MatchService_GetCandidates_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_ListIncomingLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomingLikesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).ListIncomingLikes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_ListIncomingLikes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).ListIncomingLikes(ctx, req.(*ListIncomingLikesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_Unmatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).Unmatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_Unmatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).Unmatch(ctx, req.(*UnmatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).Block(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_Block_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).Block(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_Report_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).Report(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_Report_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).Report(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetUserStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetUserStats(ctx, req.(*GetUserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_UndoLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).UndoLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_UndoLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).UndoLike(ctx, req.(*UndoLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MatchService_ServiceDesc is the grpc.ServiceDesc for MatchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -187,10 +539,50 @@ var MatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckMatch",
 			Handler:    _MatchService_CheckMatch_Handler,
 		},
+		{
+			MethodName: "HasLike",
+			Handler:    _MatchService_HasLike_Handler,
+		},
 		{
 			MethodName: "GetCandidates",
 			Handler:    _MatchService_GetCandidates_Handler,
 		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _MatchService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "ListIncomingLikes",
+			Handler:    _MatchService_ListIncomingLikes_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _MatchService_ListMatches_Handler,
+		},
+		{
+			MethodName: "Unmatch",
+			Handler:    _MatchService_Unmatch_Handler,
+		},
+		{
+			MethodName: "Block",
+			Handler:    _MatchService_Block_Handler,
+		},
+		{
+			MethodName: "Report",
+			Handler:    _MatchService_Report_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _MatchService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetUserStats",
+			Handler:    _MatchService_GetUserStats_Handler,
+		},
+		{
+			MethodName: "UndoLike",
+			Handler:    _MatchService_UndoLike_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "match/proto/match.proto",
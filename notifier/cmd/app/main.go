@@ -3,14 +3,17 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"app/notifier/internal"
+	"app/notifier/internal/broadcast"
 	"app/notifier/internal/client"
 	"app/notifier/internal/config"
+	"app/notifier/internal/database"
 	"app/notifier/internal/tg"
 )
 
@@ -33,14 +36,89 @@ func main() {
 	userAdapter := client.NewUserClientAdapter(userCli)
 	matchAdapter := client.NewMatchClientAdapter(matchCli)
 
-	core := internal.NewCore(userAdapter, matchAdapter)
+	var (
+		sessions   internal.SessionStore
+		bans       internal.BanCache
+		limiter    tg.Limiter
+		broadcasts broadcast.Store
+	)
+	if config.C.RedisDSN != "" {
+		redisCon, err := database.ConnectRedis(ctx, config.C.RedisDSN)
+		if err != nil {
+			log.Fatalf("redis: %v", err)
+		}
+		defer redisCon.Close()
+		sessions = internal.NewRedisSessionStore(redisCon, config.C.SessionTTL)
+		bans = internal.NewRedisBanCache(redisCon)
+		if config.C.RateLimit > 0 && config.C.RateBurst > 0 {
+			limiter = tg.NewRedisRateLimiter(redisCon, config.C.RateLimit, config.C.RateBurst)
+		}
+		broadcasts = broadcast.NewRedisStore(redisCon)
+	} else {
+		log.Println("NOTIFIER_REDIS_DSN is empty, sessions are kept in memory")
+		sessions = internal.NewMemorySessionStore(config.C.SessionTTL)
+		bans = internal.NewMemoryBanCache()
+		if config.C.RateLimit > 0 && config.C.RateBurst > 0 {
+			limiter = tg.NewRateLimiter(config.C.RateLimit, config.C.RateBurst)
+		}
+		broadcasts = broadcast.NewMemoryStore()
+	}
+
+	core := internal.NewCore(userAdapter, matchAdapter, sessions, bans, config.C.AdminIDs)
+
+	var hook *tg.WebhookPoller
+	switch config.C.Mode {
+	case "polling":
+	case "webhook":
+		if config.C.WebhookSecret == "" {
+			log.Println("NOTIFIER_WEBHOOK_SECRET is empty, webhook requests are not authenticated")
+		}
+		hook, err = tg.NewWebhookPoller(tg.WebhookConfig{
+			Listen:         config.C.WebhookListen,
+			PublicURL:      config.C.WebhookURL,
+			Path:           config.C.WebhookPath,
+			SecretToken:    config.C.WebhookSecret,
+			CertFile:       config.C.WebhookCert,
+			KeyFile:        config.C.WebhookKey,
+			SelfSigned:     config.C.WebhookSelfSign,
+			MaxConnections: config.C.WebhookMaxConns,
+		})
+		if err != nil {
+			log.Fatalf("webhook: %v", err)
+		}
+	default:
+		log.Fatalf("unknown NOTIFIER_MODE %q, expected polling or webhook", config.C.Mode)
+	}
 
-	bot, err := tg.NewBot(config.C.TelegramToken)
+	bot, err := tg.NewBot(config.C.TelegramToken, hook)
 	if err != nil {
 		log.Fatalf("telebot init: %v", err)
 	}
 
-	h := tg.NewHandler(bot, core)
+	if limiter == nil {
+		log.Println("rate limiting is disabled")
+	}
+
+	if config.C.MetricsAddr != "" {
+		// expvar публикует счётчики на /debug/vars
+		go func() {
+			if err := http.ListenAndServe(config.C.MetricsAddr, nil); err != nil {
+				log.Printf("metrics server: %v", err)
+			}
+		}()
+	}
+
+	var bc *broadcast.Broadcaster
+	if config.C.BroadcastRate > 0 {
+		bc = broadcast.New(userAdapter, tg.NewBroadcastSender(bot), broadcasts, config.C.BroadcastRate)
+		if err := bc.Restore(ctx); err != nil {
+			log.Printf("broadcast restore: %v", err)
+		}
+	} else {
+		log.Println("broadcasts are disabled")
+	}
+
+	h := tg.NewHandler(bot, core, limiter, bc)
 	h.Register()
 
 	go bot.Start()
@@ -50,5 +128,8 @@ func main() {
 	<-sig
 
 	bot.Stop()
+	if bc != nil {
+		bc.Stop()
+	}
 	time.Sleep(200 * time.Millisecond)
 }
@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+)
+
+// IsAdmin сообщает, доступны ли пользователю админские команды.
+func (c *Core) IsAdmin(chatID int64) bool {
+	return c.admins[chatID]
+}
+
+// Banned сообщает, заблокирован ли пользователь. Если user service недоступен,
+// пользователь считается незаблокированным: бот не должен отказывать всем из-за сбоя.
+func (c *Core) Banned(ctx context.Context, chatID int64) bool {
+	if c.IsAdmin(chatID) {
+		return false
+	}
+
+	banned, ok, err := c.bans.Get(ctx, chatID)
+	if err != nil {
+		log.Printf("core: ban cache get %d: %v", chatID, err)
+	}
+	if ok {
+		return banned
+	}
+
+	u, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "user not found") {
+		log.Printf("core: ban check %d: %v", chatID, err)
+		return false
+	}
+	banned = u.GetIsBanned()
+
+	if err := c.bans.Set(ctx, chatID, banned); err != nil {
+		log.Printf("core: ban cache set %d: %v", chatID, err)
+	}
+	return banned
+}
+
+// AdminStats показывает регистрации, лайки и совпадения с полуночи по времени сервера.
+func (c *Core) AdminStats(ctx context.Context, chatID int64) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if !c.IsAdmin(chatID) {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	us, err := c.users.GetStats(ctx, since)
+	if err != nil {
+		log.Printf("core: users.GetStats: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	ms, err := c.match.GetStats(ctx, since)
+	if err != nil {
+		log.Printf("core: match.GetStats: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	return Output{Text: i18n.M("admin.stats",
+		since.Format("2006-01-02"),
+		us.GetRegistered(), ms.GetLikes(), ms.GetMatches(),
+		us.GetTotal(), us.GetVisible(), us.GetBanned(), ms.GetOpenReports(),
+	)}, nil
+}
+
+// AdminUser показывает анкету пользователя по Telegram ID вместе со счётчиками.
+func (c *Core) AdminUser(ctx context.Context, chatID int64, arg string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if !c.IsAdmin(chatID) {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	u, res, ok := c.adminTarget(ctx, arg, "/user")
+	if !ok {
+		return res, nil
+	}
+	st, err := c.match.GetUserStats(ctx, u.GetId())
+	if err != nil {
+		log.Printf("core: GetUserStats(%d): %v", u.GetId(), err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	status := "admin.status.hidden"
+	switch {
+	case u.GetIsBanned():
+		status = "admin.status.banned"
+	case u.GetIsVisible():
+		status = "admin.status.visible"
+	}
+	return Output{
+		Text: i18n.M("admin.user",
+			u.GetId(), u.GetTelegramId(), profileCaption(u), i18n.M(status),
+			st.GetLikesGiven(), st.GetLikesReceived(), st.GetMatches(), st.GetReportsReceived(),
+		),
+		Photos: photoURLs(u),
+	}, nil
+}
+
+// AdminBan блокирует или разблокирует пользователя по Telegram ID. Заблокированный
+// пропадает из поиска, а бот перестаёт отвечать ему на что-либо, кроме отказа.
+func (c *Core) AdminBan(ctx context.Context, chatID int64, arg string, banned bool) (Output, error) {
+	out, target, err := c.adminBan(ctx, chatID, arg, banned)
+	if target != 0 {
+		// незаконченная анкета или просмотр больше не понадобятся; сессию цели стираем
+		// под её блокировкой, уже отпустив чат админа, чтобы блокировки не ждали друг друга
+		defer c.lock(ctx, target)()
+		c.reset(ctx, target)
+	}
+	return out, err
+}
+
+// adminBan меняет блокировку под блокировкой чата админа; target — чат, сессию которого
+// нужно стереть, или 0.
+func (c *Core) adminBan(ctx context.Context, chatID int64, arg string, banned bool) (out Output, target int64, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if !c.IsAdmin(chatID) {
+		return Output{Text: i18n.M("action.unknown")}, 0, nil
+	}
+
+	cmd := "/unban"
+	if banned {
+		cmd = "/ban"
+	}
+	u, res, ok := c.adminTarget(ctx, arg, cmd)
+	if !ok {
+		return res, 0, nil
+	}
+	if banned && c.IsAdmin(u.GetTelegramId()) {
+		return Output{Text: i18n.M("admin.ban.admin")}, 0, nil
+	}
+
+	if err := c.users.SetBanned(ctx, u.GetId(), banned); err != nil {
+		log.Printf("core: SetBanned(%d, %v): %v", u.GetId(), banned, err)
+		return Output{Text: i18n.M("error.unavailable")}, 0, nil
+	}
+	if err := c.bans.Forget(ctx, u.GetTelegramId()); err != nil {
+		log.Printf("core: ban cache forget %d: %v", u.GetTelegramId(), err)
+	}
+
+	if !banned {
+		return Output{Text: i18n.M("admin.unbanned", u.GetUsername(), u.GetTelegramId())}, 0, nil
+	}
+	return Output{Text: i18n.M("admin.banned", u.GetUsername(), u.GetTelegramId())}, u.GetTelegramId(), nil
+}
+
+// adminTarget находит пользователя по Telegram ID из аргумента команды cmd.
+// Если ok == false, out — готовый ответ админу.
+func (c *Core) adminTarget(ctx context.Context, arg, cmd string) (u *userpb.User, out Output, ok bool) {
+	id, err := strconv.ParseInt(strings.TrimSpace(arg), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, Output{Text: i18n.M("admin.usage", cmd)}, false
+	}
+	u, err = c.users.GetByTelegramID(ctx, id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return nil, Output{Text: i18n.M("admin.not_found", id)}, false
+		}
+		log.Printf("core: GetByTelegramID(%d): %v", id, err)
+		return nil, Output{Text: i18n.M("error.unavailable")}, false
+	}
+	return u, Output{}, true
+}
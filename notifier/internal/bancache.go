@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// banTTL — сколько помнить, заблокирован ли пользователь, чтобы не спрашивать
+// user service на каждый апдейт.
+const banTTL = time.Minute
+
+// MemoryBanCache хранит результаты проверки в памяти процесса (для тестов и локального запуска).
+type MemoryBanCache struct {
+	mu        sync.Mutex
+	entries   map[int64]banEntry
+	lastSweep time.Time
+}
+
+type banEntry struct {
+	banned bool
+	at     time.Time
+}
+
+func NewMemoryBanCache() *MemoryBanCache {
+	return &MemoryBanCache{entries: make(map[int64]banEntry)}
+}
+
+func (m *MemoryBanCache) Get(_ context.Context, chatID int64) (bool, bool, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.Sub(m.lastSweep) > banTTL {
+		for id, e := range m.entries {
+			if now.Sub(e.at) > banTTL {
+				delete(m.entries, id)
+			}
+		}
+		m.lastSweep = now
+	}
+	e, ok := m.entries[chatID]
+	if !ok || now.Sub(e.at) > banTTL {
+		return false, false, nil
+	}
+	return e.banned, true, nil
+}
+
+func (m *MemoryBanCache) Set(_ context.Context, chatID int64, banned bool) error {
+	m.mu.Lock()
+	m.entries[chatID] = banEntry{banned: banned, at: time.Now()}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryBanCache) Forget(_ context.Context, chatID int64) error {
+	m.mu.Lock()
+	delete(m.entries, chatID)
+	m.mu.Unlock()
+	return nil
+}
+
+// RedisBanCache хранит результаты проверки в Redis, общем для всех реплик notifier:
+// /ban и /unban на одной реплике сразу видны остальным.
+type RedisBanCache struct {
+	client *redis.Client
+}
+
+func NewRedisBanCache(client *redis.Client) *RedisBanCache {
+	return &RedisBanCache{client: client}
+}
+
+func (r *RedisBanCache) Get(ctx context.Context, chatID int64) (bool, bool, error) {
+	v, err := r.client.Get(ctx, banKey(chatID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return v == "1", true, nil
+}
+
+func (r *RedisBanCache) Set(ctx context.Context, chatID int64, banned bool) error {
+	v := "0"
+	if banned {
+		v = "1"
+	}
+	return r.client.Set(ctx, banKey(chatID), v, banTTL).Err()
+}
+
+func (r *RedisBanCache) Forget(ctx context.Context, chatID int64) error {
+	return r.client.Del(ctx, banKey(chatID)).Err()
+}
+
+func banKey(chatID int64) string {
+	return fmt.Sprintf("notifier:ban:%d", chatID)
+}
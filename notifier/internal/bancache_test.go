@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisBanCache_SharedBetweenReplicas(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	replica := func() *RedisBanCache {
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		return NewRedisBanCache(client)
+	}
+	a, b := replica(), replica()
+
+	if _, ok, err := a.Get(ctx, 1); err != nil || ok {
+		t.Fatalf("empty cache: ok=%v, err=%v", ok, err)
+	}
+	if err := a.Set(ctx, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if banned, ok, _ := b.Get(ctx, 1); !ok || banned {
+		t.Fatalf("other replica: banned=%v, ok=%v", banned, ok)
+	}
+
+	// /ban на реплике b: a больше не должна отвечать устаревшим «не заблокирован»
+	if err := b.Forget(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := a.Get(ctx, 1); ok {
+		t.Fatal("forgotten entry is still cached")
+	}
+
+	if err := a.Set(ctx, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	mr.FastForward(banTTL)
+	if _, ok, _ := b.Get(ctx, 1); ok {
+		t.Fatal("entry outlived banTTL")
+	}
+}
@@ -0,0 +1,488 @@
+// Package broadcast рассылает объявления всем пользователям бота с учётом
+// глобального лимита Telegram (~30 сообщений в секунду).
+package broadcast
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	userpb "app/user/proto"
+)
+
+// Status — состояние рассылки.
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusPaused  Status = "paused"
+	StatusDone    Status = "done"
+)
+
+// Delivery — результат отправки одному получателю.
+type Delivery string
+
+const (
+	DeliverySent    Delivery = "sent"
+	DeliveryBlocked Delivery = "blocked"
+	DeliveryFailed  Delivery = "failed"
+	// DeliveryUnknown — отправку прервали на полпути; повторно такому получателю не пишем,
+	// а в счётчиках он среди неудачных.
+	DeliveryUnknown Delivery = "unknown"
+)
+
+// Job — рассылка. Получатели обходятся по возрастанию id, Cursor — id последнего обработанного.
+type Job struct {
+	ID        string
+	Text      string
+	CreatedBy int64
+	Status    Status
+	Cursor    int64
+	Sent      int
+	Blocked   int
+	Failed    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var (
+	ErrActive     = errors.New("broadcast: another broadcast is in progress")
+	ErrNoJob      = errors.New("broadcast: no broadcast yet")
+	ErrNotRunning = errors.New("broadcast: broadcast is not running")
+	ErrNotPaused  = errors.New("broadcast: broadcast is not paused")
+
+	// ErrBlocked возвращает Sender, если пользователь заблокировал бота.
+	ErrBlocked = errors.New("broadcast: recipient blocked the bot")
+)
+
+// RetryError возвращает Sender, когда Telegram просит подождать перед следующей отправкой.
+type RetryError struct {
+	After time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("broadcast: flood limit, retry after %s", e.After)
+}
+
+type Sender interface {
+	Send(ctx context.Context, chatID int64, text string) error
+}
+
+type Users interface {
+	ListUsers(ctx context.Context, afterID int64, limit int32) ([]*userpb.User, error)
+	SetBotBlocked(ctx context.Context, userID int64, blocked bool) error
+}
+
+// Store хранит текущую рассылку и результат отправки каждому получателю.
+type Store interface {
+	// Current возвращает последнюю рассылку или nil, если рассылок не было.
+	Current(ctx context.Context) (*Job, error)
+	// Update атомарно меняет последнюю рассылку: fn получает её копию (nil, если рассылок
+	// не было) и возвращает новую версию или nil, если записывать нечего. Если рассылку
+	// параллельно изменила другая реплика, fn вызывается снова.
+	Update(ctx context.Context, fn func(cur *Job) *Job) error
+	// Delivery возвращает "", если получателю ещё ничего не отправлялось.
+	Delivery(ctx context.Context, jobID string, userID int64) (Delivery, error)
+	SetDelivery(ctx context.Context, jobID string, userID int64, d Delivery) error
+	// Acquire берёт или продлевает аренду рассылки на ttl: отправляет только её владелец,
+	// поэтому рядом могут работать несколько реплик. false — аренда у другого владельца.
+	Acquire(ctx context.Context, jobID, owner string, ttl time.Duration) (bool, error)
+	// Release снимает аренду, если она принадлежит owner.
+	Release(ctx context.Context, jobID, owner string) error
+}
+
+const (
+	pageSize   = 500
+	retryDelay = 5 * time.Second
+	// maxRetries — сколько раз повторять отправку одному получателю после flood wait.
+	maxRetries = 3
+	// leaseTTL — срок аренды рассылки; владелец продлевает её между отправками,
+	// поэтому он должен быть заметно больше flood wait Telegram.
+	leaseTTL = 2 * time.Minute
+)
+
+// Broadcaster выполняет одну рассылку за раз в фоне.
+type Broadcaster struct {
+	users    Users
+	sender   Sender
+	store    Store
+	interval time.Duration
+	// owner отличает этот процесс от других реплик в аренде рассылки.
+	owner string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New создаёт рассыльщика, который отправляет не больше rate сообщений в секунду.
+func New(users Users, sender Sender, store Store, rate float64) *Broadcaster {
+	return &Broadcaster{
+		users:    users,
+		sender:   sender,
+		store:    store,
+		interval: time.Duration(float64(time.Second) / rate),
+		owner:    rand.Text(),
+	}
+}
+
+// Start запускает новую рассылку, если предыдущая завершена. Проверка и запись атомарны
+// в Store, поэтому из нескольких реплик рассылку запустит одна.
+func (b *Broadcaster) Start(ctx context.Context, text string, createdBy int64) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	job := &Job{
+		ID:        strconv.FormatInt(now.UnixNano(), 36),
+		Text:      text,
+		CreatedBy: createdBy,
+		Status:    StatusRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	var active *Job
+	err := b.store.Update(ctx, func(cur *Job) *Job {
+		if cur != nil && cur.Status != StatusDone {
+			active = cur
+			return nil
+		}
+		active = nil
+		return job
+	})
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return active, ErrActive
+	}
+	b.run(job)
+	return job, nil
+}
+
+// Pause останавливает отправку; получатели после Cursor получат сообщение после Resume.
+// Если рассылку ведёт другая реплика, она заметит паузу после текущей отправки.
+func (b *Broadcaster) Pause(ctx context.Context) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stop()
+	return b.transition(ctx, StatusRunning, StatusPaused, ErrNotRunning)
+}
+
+func (b *Broadcaster) Resume(ctx context.Context) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	job, err := b.transition(ctx, StatusPaused, StatusRunning, ErrNotPaused)
+	if err != nil {
+		return job, err
+	}
+	b.run(job)
+	return job, nil
+}
+
+// transition атомарно переводит последнюю рассылку из from в to. Если статус уже другой,
+// возвращает рассылку и wrong.
+func (b *Broadcaster) transition(ctx context.Context, from, to Status, wrong error) (*Job, error) {
+	var (
+		job *Job
+		ok  bool
+	)
+	err := b.store.Update(ctx, func(cur *Job) *Job {
+		job, ok = cur, cur != nil && cur.Status == from
+		if !ok {
+			return nil
+		}
+		cur.Status = to
+		cur.UpdatedAt = time.Now()
+		return cur
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case job == nil:
+		return nil, ErrNoJob
+	case !ok:
+		return job, wrong
+	}
+	return job, nil
+}
+
+// Status возвращает последнюю рассылку.
+func (b *Broadcaster) Status(ctx context.Context) (*Job, error) {
+	return b.current(ctx)
+}
+
+// Restore продолжает рассылку, прерванную перезапуском бота. Из нескольких реплик
+// отправлять будет одна — та, что возьмёт аренду.
+func (b *Broadcaster) Restore(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	job, err := b.store.Current(ctx)
+	if err != nil || job == nil || job.Status != StatusRunning {
+		return err
+	}
+	log.Printf("broadcast %s: resuming after user %d", job.ID, job.Cursor)
+	b.run(job)
+	return nil
+}
+
+// Stop останавливает отправку при выключении бота. Рассылка остаётся в статусе
+// running и продолжится после Restore.
+func (b *Broadcaster) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stop()
+}
+
+func (b *Broadcaster) current(ctx context.Context) (*Job, error) {
+	job, err := b.store.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrNoJob
+	}
+	return job, nil
+}
+
+// run и stop вызываются под b.mu.
+func (b *Broadcaster) run(job *Job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	b.cancel, b.done = cancel, done
+
+	go func() {
+		defer close(done)
+		if !b.acquire(ctx, job.ID) {
+			return
+		}
+		defer b.release(job.ID)
+		b.loop(ctx, job.ID)
+	}()
+}
+
+func (b *Broadcaster) stop() {
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	<-b.done
+	b.cancel, b.done = nil, nil
+}
+
+// acquire ждёт аренду рассылки: пока её держит другая реплика, эта только проверяет,
+// что рассылка ещё идёт, и подхватывает её, если та реплика пропала.
+func (b *Broadcaster) acquire(ctx context.Context, jobID string) bool {
+	for waiting := false; ; waiting = true {
+		ok, err := b.store.Acquire(ctx, jobID, b.owner, leaseTTL)
+		if ok {
+			return true
+		}
+		if err != nil {
+			log.Printf("broadcast %s: acquire lease: %v", jobID, err)
+		} else if !waiting {
+			log.Printf("broadcast %s: running on another instance", jobID)
+		}
+		if !sleep(ctx, leaseTTL/2) {
+			return false
+		}
+		job, err := b.store.Current(ctx)
+		if err == nil && (job == nil || job.ID != jobID || job.Status != StatusRunning) {
+			return false
+		}
+	}
+}
+
+func (b *Broadcaster) release(jobID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), retryDelay)
+	defer cancel()
+	if err := b.store.Release(ctx, jobID, b.owner); err != nil {
+		log.Printf("broadcast %s: release lease: %v", jobID, err)
+	}
+}
+
+// renew продлевает аренду; false — аренду перехватили или продлить её не удалось вовремя.
+func (b *Broadcaster) renew(ctx context.Context, jobID string, expires *time.Time) bool {
+	now := time.Now()
+	if expires.Sub(now) > leaseTTL/2 {
+		return true
+	}
+	ok, err := b.store.Acquire(ctx, jobID, b.owner, leaseTTL)
+	switch {
+	case err != nil:
+		log.Printf("broadcast %s: renew lease: %v", jobID, err)
+		return now.Before(*expires)
+	case !ok:
+		log.Printf("broadcast %s: lease taken by another instance", jobID)
+		return false
+	}
+	*expires = now.Add(leaseTTL)
+	return true
+}
+
+// loop рассылает сообщения, пока рассылка не закончится, её не поставят на паузу
+// (в том числе с другой реплики) или не отменят ctx. Состояние читается из Store уже
+// под арендой: предыдущий владелец мог продвинуть курсор.
+func (b *Broadcaster) loop(ctx context.Context, jobID string) {
+	expires := time.Now().Add(leaseTTL)
+	job, err := b.store.Current(ctx)
+	if err != nil {
+		log.Printf("broadcast %s: load: %v", jobID, err)
+		return
+	}
+	if job == nil || job.ID != jobID || job.Status != StatusRunning {
+		return
+	}
+
+	tick := time.NewTicker(b.interval)
+	defer tick.Stop()
+
+	for {
+		page, err := b.users.ListUsers(ctx, job.Cursor, pageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("broadcast %s: list users after %d: %v", job.ID, job.Cursor, err)
+			if !sleep(ctx, retryDelay) {
+				return
+			}
+			continue
+		}
+		if len(page) == 0 {
+			if b.saveProgress(job, true) {
+				log.Printf("broadcast %s: done, sent %d, blocked %d, failed %d", job.ID, job.Sent, job.Blocked, job.Failed)
+			}
+			return
+		}
+
+		for _, u := range page {
+			// после перезапуска получатель мог уже получить сообщение до сохранения курсора
+			d, err := b.store.Delivery(ctx, job.ID, u.GetId())
+			if err != nil {
+				log.Printf("broadcast %s: delivery of %d: %v", job.ID, u.GetId(), err)
+			}
+			if d == "" {
+				select {
+				case <-ctx.Done():
+					return
+				case <-tick.C:
+				}
+				if !b.renew(ctx, job.ID, &expires) {
+					return
+				}
+				d = b.deliver(ctx, job, u)
+				if d == "" {
+					// пауза пришла во время flood wait — сообщение не отправлялось, повторим после Resume
+					return
+				}
+				b.record(job, u.GetId(), d)
+			}
+			job.Cursor = u.GetId()
+			if !b.saveProgress(job, false) || ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// record сохраняет результат отправки и после отмены ctx: иначе после Resume
+// получатель получит сообщение второй раз.
+func (b *Broadcaster) record(job *Job, userID int64, d Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), retryDelay)
+	defer cancel()
+	if err := b.store.SetDelivery(ctx, job.ID, userID, d); err != nil {
+		log.Printf("broadcast %s: save delivery of %d: %v", job.ID, userID, err)
+	}
+	switch d {
+	case DeliverySent:
+		job.Sent++
+	case DeliveryBlocked:
+		job.Blocked++
+	default:
+		job.Failed++
+	}
+}
+
+// saveProgress сохраняет курсор и счётчики, не затирая статус: паузу могли поставить
+// с другой реплики. finish завершает рассылку, если она всё ещё идёт. Сохраняется и после
+// отмены, чтобы не потерять прогресс. Возвращает false, если рассылку больше не нужно
+// продолжать, а с finish — если завершить её не удалось.
+func (b *Broadcaster) saveProgress(job *Job, finish bool) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), retryDelay)
+	defer cancel()
+	status := job.Status
+	err := b.store.Update(ctx, func(cur *Job) *Job {
+		if cur == nil || cur.ID != job.ID {
+			status = ""
+			return nil
+		}
+		cur.Cursor, cur.Sent, cur.Blocked, cur.Failed = job.Cursor, job.Sent, job.Blocked, job.Failed
+		if finish && cur.Status == StatusRunning {
+			cur.Status = StatusDone
+		}
+		cur.UpdatedAt = time.Now()
+		status = cur.Status
+		return cur
+	})
+	if err != nil {
+		log.Printf("broadcast %s: save: %v", job.ID, err)
+		return job.Status == StatusRunning && !finish
+	}
+	job.Status = status
+	if finish {
+		return status == StatusDone
+	}
+	return status == StatusRunning
+}
+
+// deliver отправляет сообщение, повторяя попытку, если Telegram просит подождать.
+// Возвращает "", если ctx отменили до отправки, и DeliveryUnknown, если во время неё:
+// сообщение могло уйти, поэтому повторять его нельзя.
+func (b *Broadcaster) deliver(ctx context.Context, job *Job, u *userpb.User) Delivery {
+	for attempt := 0; ; attempt++ {
+		err := b.sender.Send(ctx, u.GetTelegramId(), job.Text)
+		if err == nil {
+			return DeliverySent
+		}
+
+		var retry *RetryError
+		switch {
+		case errors.Is(err, ErrBlocked):
+			if err := b.users.SetBotBlocked(ctx, u.GetId(), true); err != nil {
+				log.Printf("broadcast %s: mark %d as blocked: %v", job.ID, u.GetId(), err)
+			}
+			return DeliveryBlocked
+		case ctx.Err() != nil:
+			log.Printf("broadcast %s: send to %d interrupted: %v", job.ID, u.GetTelegramId(), err)
+			return DeliveryUnknown
+		case errors.As(err, &retry) && attempt < maxRetries:
+			if !sleep(ctx, retry.After) {
+				return ""
+			}
+		default:
+			log.Printf("broadcast %s: send to %d: %v", job.ID, u.GetTelegramId(), err)
+			return DeliveryFailed
+		}
+	}
+}
+
+// sleep ждёт d и возвращает false, если ctx отменили раньше.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,369 @@
+package broadcast_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"app/notifier/internal/broadcast"
+	"app/notifier/internal/fake"
+	userpb "app/user/proto"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// sender записывает получателей; hold задерживает отправку, пока её не отменят.
+type sender struct {
+	mu     sync.Mutex
+	sent   []int64
+	flood  map[int64]int // сколько раз ответить flood wait
+	hold   chan struct{} // закрывается, когда отправка ждёт отмены
+	holdOn int64
+}
+
+func (s *sender) Send(ctx context.Context, chatID int64, _ string) error {
+	s.mu.Lock()
+	if s.flood[chatID] > 0 {
+		s.flood[chatID]--
+		s.mu.Unlock()
+		return &broadcast.RetryError{After: time.Millisecond}
+	}
+	if chatID == s.holdOn && s.hold != nil {
+		hold := s.hold
+		s.hold = nil
+		s.mu.Unlock()
+		close(hold)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	s.sent = append(s.sent, chatID)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *sender) recipients() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.sent...)
+}
+
+func waitDone(t *testing.T, b *broadcast.Broadcaster) *broadcast.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := b.Status(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == broadcast.StatusDone {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("broadcast didn't finish: %+v", job)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBroadcaster_PauseResume(t *testing.T) {
+	users := fake.NewUsers()
+	for i := range 4 {
+		users.Put(&userpb.User{TelegramId: int64(100 + i)})
+	}
+	s := &sender{
+		flood:  map[int64]int{100: 2},
+		hold:   make(chan struct{}),
+		holdOn: 102,
+	}
+	hold := s.hold
+	b := broadcast.New(users, s, broadcast.NewMemoryStore(), 1000)
+	t.Cleanup(b.Stop)
+
+	if _, err := b.Start(t.Context(), "hi", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Start(t.Context(), "again", 1); err != broadcast.ErrActive {
+		t.Fatalf("second broadcast: want ErrActive, got %v", err)
+	}
+
+	// пауза во время отправки третьему получателю: сообщение могло уйти,
+	// поэтому его исход неизвестен и повторно оно не отправляется
+	<-hold
+	job, err := b.Pause(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != broadcast.StatusPaused || job.Sent != 2 || job.Failed != 1 {
+		t.Fatalf("unexpected paused job: %+v", job)
+	}
+	if _, err := b.Pause(t.Context()); err != broadcast.ErrNotRunning {
+		t.Fatalf("second pause: want ErrNotRunning, got %v", err)
+	}
+
+	if _, err := b.Resume(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	job = waitDone(t, b)
+	if job.Sent != 3 || job.Blocked != 0 || job.Failed != 1 {
+		t.Fatalf("unexpected counters: %+v", job)
+	}
+
+	// flood wait повторяется, а каждый получатель получает сообщение не больше одного раза
+	got := s.recipients()
+	want := []int64{100, 101, 103}
+	if len(got) != len(want) {
+		t.Fatalf("recipients: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("recipients: got %v, want %v", got, want)
+		}
+	}
+}
+
+// gateSender отправляет сообщение, только когда тест пропустит его через gate.
+type gateSender struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	sent []int64
+}
+
+func (s *gateSender) Send(ctx context.Context, chatID int64, _ string) error {
+	select {
+	case <-s.gate:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	s.sent = append(s.sent, chatID)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *gateSender) recipients() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.sent...)
+}
+
+// pass пропускает n отправок и ждёт, пока их запишут.
+func (s *gateSender) pass(t *testing.T, n int) {
+	t.Helper()
+	want := len(s.recipients()) + n
+	for range n {
+		select {
+		case s.gate <- struct{}{}:
+		case <-time.After(5 * time.Second):
+			t.Fatal("nobody is sending")
+		}
+	}
+	for len(s.recipients()) < want {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBroadcaster_Replicas(t *testing.T) {
+	users := fake.NewUsers()
+	for i := range 5 {
+		users.Put(&userpb.User{TelegramId: int64(100 + i)})
+	}
+	store := broadcast.NewMemoryStore()
+	s := &gateSender{gate: make(chan struct{})}
+	first := broadcast.New(users, s, store, 1000)
+	second := broadcast.New(users, s, store, 1000)
+	t.Cleanup(first.Stop)
+	t.Cleanup(second.Stop)
+
+	job, err := first.Start(t.Context(), "hi", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.pass(t, 2)
+
+	// вторая реплика после перезапуска не отправляет, пока аренда у первой
+	if err := second.Restore(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	// пауза со второй реплики: первая досылает текущее сообщение и останавливается,
+	// не затирая статус
+	if _, err := second.Pause(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	s.pass(t, 1)
+	for {
+		ok, err := store.Acquire(t.Context(), job.ID, "probe", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			store.Release(t.Context(), job.ID, "probe")
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case s.gate <- struct{}{}:
+		t.Fatal("a message was sent after the pause")
+	case <-time.After(50 * time.Millisecond):
+	}
+	paused, err := second.Status(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paused.Status != broadcast.StatusPaused || paused.Sent != 3 {
+		t.Fatalf("unexpected paused job: %+v", paused)
+	}
+
+	// продолжает вторая реплика с курсора, до которого дошла первая
+	if _, err := second.Resume(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	s.pass(t, 2)
+	done := waitDone(t, second)
+	if done.Sent != 5 || done.Failed != 0 {
+		t.Fatalf("unexpected counters: %+v", done)
+	}
+	got := s.recipients()
+	want := []int64{100, 101, 102, 103, 104}
+	if len(got) != len(want) {
+		t.Fatalf("recipients: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("recipients: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStore_Lease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	stores := map[string]broadcast.Store{
+		"memory": broadcast.NewMemoryStore(),
+		"redis":  broadcast.NewRedisStore(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			acquire := func(owner string, want bool) {
+				t.Helper()
+				ok, err := store.Acquire(ctx, "job", owner, time.Minute)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ok != want {
+					t.Fatalf("Acquire(%s) = %v, want %v", owner, ok, want)
+				}
+			}
+
+			acquire("a", true)
+			acquire("a", true) // продление
+			acquire("b", false)
+			// чужой Release аренду не снимает
+			if err := store.Release(ctx, "job", "b"); err != nil {
+				t.Fatal(err)
+			}
+			acquire("b", false)
+			if err := store.Release(ctx, "job", "a"); err != nil {
+				t.Fatal(err)
+			}
+			acquire("b", true)
+		})
+	}
+
+	// просроченную аренду может взять другой владелец
+	store := stores["redis"]
+	if ok, _ := store.Acquire(t.Context(), "old", "a", time.Second); !ok {
+		t.Fatal("lease is not acquired")
+	}
+	mr.FastForward(2 * time.Second)
+	if ok, err := store.Acquire(t.Context(), "old", "b", time.Second); err != nil || !ok {
+		t.Fatalf("expired lease: Acquire = %v, %v", ok, err)
+	}
+}
+
+func TestBroadcaster_StartOnce(t *testing.T) {
+	users := fake.NewUsers()
+	users.Put(&userpb.User{TelegramId: 100})
+	mr := miniredis.RunT(t)
+	s := &gateSender{gate: make(chan struct{})}
+
+	// реплики с общим Redis одновременно запускают рассылку: запустить её должна одна
+	const replicas = 8
+	errs := make(chan error, replicas)
+	for range replicas {
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		b := broadcast.New(users, s, broadcast.NewRedisStore(client), 1000)
+		t.Cleanup(b.Stop)
+		go func() {
+			_, err := b.Start(t.Context(), "hi", 1)
+			errs <- err
+		}()
+	}
+	started := 0
+	for range replicas {
+		switch err := <-errs; {
+		case err == nil:
+			started++
+		case !errors.Is(err, broadcast.ErrActive):
+			t.Fatal(err)
+		}
+	}
+	if started != 1 {
+		t.Fatalf("started %d broadcasts, want 1", started)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	mr := miniredis.RunT(t)
+	stores := map[string]broadcast.Store{
+		"memory": broadcast.NewMemoryStore(),
+		"redis":  broadcast.NewRedisStore(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			if err := store.Update(ctx, func(cur *broadcast.Job) *broadcast.Job {
+				if cur != nil {
+					t.Fatalf("unexpected job: %+v", cur)
+				}
+				return &broadcast.Job{ID: "job", Status: broadcast.StatusRunning}
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			// параллельные изменения не теряются: каждое видит результат предыдущего
+			const n = 5
+			var wg sync.WaitGroup
+			for range n {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := store.Update(ctx, func(cur *broadcast.Job) *broadcast.Job {
+						cur.Sent++
+						return cur
+					})
+					if err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			// nil ничего не записывает
+			if err := store.Update(ctx, func(*broadcast.Job) *broadcast.Job { return nil }); err != nil {
+				t.Fatal(err)
+			}
+			job, err := store.Current(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if job.ID != "job" || job.Sent != n {
+				t.Fatalf("got %+v, want job with %d sent", job, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MemoryStore хранит рассылку в памяти процесса (для тестов и локального запуска).
+type MemoryStore struct {
+	mu         sync.Mutex
+	job        *Job
+	deliveries map[string]map[int64]Delivery
+	leases     map[string]lease
+}
+
+type lease struct {
+	owner string
+	until time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		deliveries: make(map[string]map[int64]Delivery),
+		leases:     make(map[string]lease),
+	}
+}
+
+func (m *MemoryStore) Current(_ context.Context) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.job == nil {
+		return nil, nil
+	}
+	job := *m.job
+	return &job, nil
+}
+
+func (m *MemoryStore) Update(_ context.Context, fn func(cur *Job) *Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var cur *Job
+	if m.job != nil {
+		job := *m.job
+		cur = &job
+	}
+	if next := fn(cur); next != nil {
+		saved := *next
+		m.job = &saved
+	}
+	return nil
+}
+
+func (m *MemoryStore) Delivery(_ context.Context, jobID string, userID int64) (Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deliveries[jobID][userID], nil
+}
+
+func (m *MemoryStore) SetDelivery(_ context.Context, jobID string, userID int64, d Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.deliveries[jobID] == nil {
+		m.deliveries[jobID] = make(map[int64]Delivery)
+	}
+	m.deliveries[jobID][userID] = d
+	return nil
+}
+
+func (m *MemoryStore) Acquire(_ context.Context, jobID, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if l, ok := m.leases[jobID]; ok && l.owner != owner && now.Before(l.until) {
+		return false, nil
+	}
+	m.leases[jobID] = lease{owner: owner, until: now.Add(ttl)}
+	return true, nil
+}
+
+func (m *MemoryStore) Release(_ context.Context, jobID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.leases[jobID].owner == owner {
+		delete(m.leases, jobID)
+	}
+	return nil
+}
+
+const (
+	// deliveryTTL — сколько хранить результаты отправки после последнего изменения.
+	deliveryTTL = 30 * 24 * time.Hour
+	// updateAttempts — сколько раз повторять Update, если рассылку параллельно изменили.
+	updateAttempts = 10
+)
+
+// RedisStore хранит рассылку в Redis: саму рассылку строкой JSON,
+// результаты отправки — хешем user id -> Delivery.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Current(ctx context.Context) (*Job, error) {
+	return loadJob(ctx, r.client)
+}
+
+func loadJob(ctx context.Context, c redis.Cmdable) (*Job, error) {
+	b, err := c.Get(ctx, jobKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var job Job
+	if err := json.Unmarshal(b, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update читает и записывает рассылку под WATCH: если ключ успели изменить,
+// транзакция не выполнится, и fn вызывается заново с новым состоянием.
+func (r *RedisStore) Update(ctx context.Context, fn func(cur *Job) *Job) error {
+	for range updateAttempts {
+		err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+			cur, err := loadJob(ctx, tx)
+			if err != nil {
+				return err
+			}
+			next := fn(cur)
+			if next == nil {
+				return nil
+			}
+			b, err := json.Marshal(next)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, jobKey, b, 0)
+				return nil
+			})
+			return err
+		}, jobKey)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+func (r *RedisStore) Delivery(ctx context.Context, jobID string, userID int64) (Delivery, error) {
+	d, err := r.client.HGet(ctx, deliveriesKey(jobID), strconv.FormatInt(userID, 10)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return Delivery(d), err
+}
+
+func (r *RedisStore) SetDelivery(ctx context.Context, jobID string, userID int64, d Delivery) error {
+	key := deliveriesKey(jobID)
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, strconv.FormatInt(userID, 10), string(d))
+	pipe.Expire(ctx, key, deliveryTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// acquireScript занимает аренду, если она свободна или уже принадлежит owner, и продлевает её.
+var acquireScript = redis.NewScript(`
+local cur = redis.call('GET', KEYS[1])
+if cur == false or cur == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+return 0`)
+
+// releaseScript снимает аренду, только если она всё ещё принадлежит owner.
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`)
+
+func (r *RedisStore) Acquire(ctx context.Context, jobID, owner string, ttl time.Duration) (bool, error) {
+	n, err := acquireScript.Run(ctx, r.client, []string{leaseKey(jobID)}, owner, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+func (r *RedisStore) Release(ctx context.Context, jobID, owner string) error {
+	return releaseScript.Run(ctx, r.client, []string{leaseKey(jobID)}, owner).Err()
+}
+
+const jobKey = "notifier:broadcast:current"
+
+func deliveriesKey(jobID string) string {
+	return fmt.Sprintf("notifier:broadcast:%s:deliveries", jobID)
+}
+
+func leaseKey(jobID string) string {
+	return fmt.Sprintf("notifier:broadcast:%s:lease", jobID)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"time"
 
 	matchpb "app/match/proto"
 )
@@ -17,24 +18,25 @@ func NewMatchClientAdapter(grpc matchpb.MatchServiceClient) *MatchClientAdapter
 	return &MatchClientAdapter{grpc: grpc}
 }
 
-func (c *MatchClientAdapter) GetCandidates(ctx context.Context, telegramID int64) ([]*matchpb.User, error) {
+func (c *MatchClientAdapter) GetCandidates(ctx context.Context, telegramID int64) ([]*matchpb.User, bool, error) {
 	resp, err := c.grpc.GetCandidates(ctx, &matchpb.GetCandidatesRequest{
 		TelegramId: telegramID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if resp == nil || resp.Candidates == nil {
-		return []*matchpb.User{}, nil
+		return []*matchpb.User{}, false, nil
 	}
-	return resp.Candidates, nil
+	return resp.Candidates, resp.HasMore, nil
 }
 
-func (c *MatchClientAdapter) Like(ctx context.Context, fromUserID, toUserID int64, isLike bool) error {
+func (c *MatchClientAdapter) Like(ctx context.Context, fromUserID, toUserID int64, isLike bool, message string) error {
 	resp, err := c.grpc.Like(ctx, &matchpb.LikeRequest{
 		FromUser: fromUserID,
 		ToUser:   toUserID,
 		IsLike:   isLike,
+		Message:  message,
 	})
 	if err != nil {
 		return err
@@ -48,6 +50,23 @@ func (c *MatchClientAdapter) Like(ctx context.Context, fromUserID, toUserID int6
 	return nil
 }
 
+func (c *MatchClientAdapter) UndoLike(ctx context.Context, fromUserID, toUserID int64) error {
+	resp, err := c.grpc.UndoLike(ctx, &matchpb.UndoLikeRequest{
+		FromUser: fromUserID,
+		ToUser:   toUserID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrMatchEmptyResponse
+	}
+	if !resp.Success {
+		return errors.New("undo not processed")
+	}
+	return nil
+}
+
 func (c *MatchClientAdapter) Match(ctx context.Context, fromUserID, toUserID int64) (bool, error) {
 	resp, err := c.grpc.CheckMatch(ctx, &matchpb.CheckMatchRequest{
 		User1: fromUserID,
@@ -61,3 +80,126 @@ func (c *MatchClientAdapter) Match(ctx context.Context, fromUserID, toUserID int
 	}
 	return resp.Match, nil
 }
+
+func (c *MatchClientAdapter) HasLike(ctx context.Context, fromUserID, toUserID int64) (bool, error) {
+	resp, err := c.grpc.HasLike(ctx, &matchpb.HasLikeRequest{
+		FromUser: fromUserID,
+		ToUser:   toUserID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if resp == nil {
+		return false, ErrMatchEmptyResponse
+	}
+	return resp.Liked, nil
+}
+
+func (c *MatchClientAdapter) DeleteUser(ctx context.Context, userID int64) error {
+	resp, err := c.grpc.DeleteUser(ctx, &matchpb.DeleteUserRequest{UserId: userID})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrMatchEmptyResponse
+	}
+	return nil
+}
+
+func (c *MatchClientAdapter) ListIncomingLikes(ctx context.Context, userID int64, limit int32) ([]*matchpb.IncomingLike, error) {
+	resp, err := c.grpc.ListIncomingLikes(ctx, &matchpb.ListIncomingLikesRequest{
+		UserId: userID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrMatchEmptyResponse
+	}
+	return resp.Likes, nil
+}
+
+func (c *MatchClientAdapter) ListMatches(ctx context.Context, userID int64, cursor string, limit int32) ([]*matchpb.MatchedUser, string, error) {
+	resp, err := c.grpc.ListMatches(ctx, &matchpb.ListMatchesRequest{
+		UserId: userID,
+		Cursor: cursor,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if resp == nil {
+		return nil, "", ErrMatchEmptyResponse
+	}
+	return resp.Matches, resp.NextCursor, nil
+}
+
+func (c *MatchClientAdapter) Unmatch(ctx context.Context, userID, otherUserID int64) error {
+	resp, err := c.grpc.Unmatch(ctx, &matchpb.UnmatchRequest{
+		UserId:      userID,
+		OtherUserId: otherUserID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrMatchEmptyResponse
+	}
+	if !resp.Success {
+		return errors.New("unmatch not processed")
+	}
+	return nil
+}
+
+func (c *MatchClientAdapter) Block(ctx context.Context, userID, blockedUserID int64) error {
+	resp, err := c.grpc.Block(ctx, &matchpb.BlockRequest{
+		UserId:        userID,
+		BlockedUserId: blockedUserID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrMatchEmptyResponse
+	}
+	return nil
+}
+
+func (c *MatchClientAdapter) Report(ctx context.Context, reporterID, reportedUserID int64, reason, comment string) error {
+	resp, err := c.grpc.Report(ctx, &matchpb.ReportRequest{
+		ReporterId:     reporterID,
+		ReportedUserId: reportedUserID,
+		Reason:         reason,
+		Comment:        comment,
+	})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrMatchEmptyResponse
+	}
+	return nil
+}
+
+func (c *MatchClientAdapter) GetStats(ctx context.Context, since time.Time) (*matchpb.GetStatsResponse, error) {
+	resp, err := c.grpc.GetStats(ctx, &matchpb.GetStatsRequest{Since: since.Unix()})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrMatchEmptyResponse
+	}
+	return resp, nil
+}
+
+func (c *MatchClientAdapter) GetUserStats(ctx context.Context, userID int64) (*matchpb.GetUserStatsResponse, error) {
+	resp, err := c.grpc.GetUserStats(ctx, &matchpb.GetUserStatsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrMatchEmptyResponse
+	}
+	return resp, nil
+}
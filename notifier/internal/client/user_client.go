@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"time"
 
 	userpb "app/user/proto"
 )
@@ -53,6 +54,10 @@ func (c *UserClientAdapter) Create(ctx context.Context, u *userpb.User) (*userpb
 		Location:    u.GetLocation(),
 		Description: u.GetDescription(),
 		IsVisible:   u.GetIsVisible(),
+		Geo:         u.GetGeo(),
+		ReferrerId:  u.GetReferrerId(),
+		Campaign:    u.GetCampaign(),
+		TgUsername:  u.GetTgUsername(),
 	}
 	resp, err := c.grpc.RegisterUser(ctx, req)
 	if err != nil {
@@ -76,6 +81,9 @@ func (c *UserClientAdapter) Update(ctx context.Context, u *userpb.User) (*userpb
 		Location:    u.GetLocation(),
 		Description: u.GetDescription(),
 		IsVisible:   u.GetIsVisible(),
+		Timezone:    u.GetTimezone(),
+		Geo:         u.GetGeo(),
+		TgUsername:  u.GetTgUsername(),
 	}
 	resp, err := c.grpc.UpdateProfile(ctx, req)
 	if err != nil {
@@ -106,6 +114,46 @@ func (c *UserClientAdapter) UpdatePhoto(ctx context.Context, userID int64, photo
 	return c.GetByID(ctx, userID)
 }
 
+func (c *UserClientAdapter) AddPhoto(ctx context.Context, userID int64, photo io.Reader) ([]*userpb.Photo, error) {
+	if photo == nil {
+		return nil, errors.New("nil photo reader")
+	}
+	data, err := ioutil.ReadAll(photo)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.grpc.AddPhoto(ctx, &userpb.AddPhotoRequest{UserId: userID, File: data})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Photos, nil
+}
+
+func (c *UserClientAdapter) RemovePhoto(ctx context.Context, userID, photoID int64) ([]*userpb.Photo, error) {
+	resp, err := c.grpc.RemovePhoto(ctx, &userpb.RemovePhotoRequest{UserId: userID, PhotoId: photoID})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Photos, nil
+}
+
+func (c *UserClientAdapter) ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]*userpb.Photo, error) {
+	resp, err := c.grpc.ReorderPhotos(ctx, &userpb.ReorderPhotosRequest{UserId: userID, PhotoIds: photoIDs})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Photos, nil
+}
+
 func (c *UserClientAdapter) ToggleVisibility(ctx context.Context, userID int64, isVisible bool) error {
 	req := &userpb.ToggleVisibilityRequest{
 		UserId:    userID,
@@ -120,3 +168,115 @@ func (c *UserClientAdapter) ToggleVisibility(ctx context.Context, userID int64,
 	}
 	return nil
 }
+
+func (c *UserClientAdapter) Delete(ctx context.Context, userID int64) error {
+	resp, err := c.grpc.DeleteAccount(ctx, &userpb.DeleteAccountRequest{UserId: userID})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrEmptyResponse
+	}
+	return nil
+}
+
+func (c *UserClientAdapter) SetBanned(ctx context.Context, userID int64, banned bool) error {
+	resp, err := c.grpc.SetBanned(ctx, &userpb.SetBannedRequest{UserId: userID, Banned: banned})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrEmptyResponse
+	}
+	return nil
+}
+
+func (c *UserClientAdapter) GetStats(ctx context.Context, since time.Time) (*userpb.GetStatsResponse, error) {
+	resp, err := c.grpc.GetStats(ctx, &userpb.GetStatsRequest{Since: since.Unix()})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp, nil
+}
+
+func (c *UserClientAdapter) SetBotBlocked(ctx context.Context, userID int64, blocked bool) error {
+	resp, err := c.grpc.SetBotBlocked(ctx, &userpb.SetBotBlockedRequest{UserId: userID, Blocked: blocked})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrEmptyResponse
+	}
+	return nil
+}
+
+func (c *UserClientAdapter) MarkActivated(ctx context.Context, userID int64) error {
+	resp, err := c.grpc.MarkActivated(ctx, &userpb.MarkActivatedRequest{UserId: userID})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return ErrEmptyResponse
+	}
+	return nil
+}
+
+func (c *UserClientAdapter) GetCampaignStats(ctx context.Context, since time.Time) ([]*userpb.CampaignStats, error) {
+	resp, err := c.grpc.GetCampaignStats(ctx, &userpb.GetCampaignStatsRequest{Since: since.Unix()})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Campaigns, nil
+}
+
+func (c *UserClientAdapter) SetIntro(ctx context.Context, userID int64, intro *userpb.Intro, file io.Reader) (*userpb.User, error) {
+	if file == nil {
+		return nil, errors.New("nil intro reader")
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.grpc.SetIntro(ctx, &userpb.SetIntroRequest{
+		UserId:   userID,
+		Kind:     intro.GetKind(),
+		File:     data,
+		Duration: intro.GetDuration(),
+		FileId:   intro.GetFileId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.User == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.User, nil
+}
+
+func (c *UserClientAdapter) ListUsers(ctx context.Context, afterID int64, limit int32) ([]*userpb.User, error) {
+	resp, err := c.grpc.ListUsers(ctx, &userpb.ListUsersRequest{AfterId: afterID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.Users, nil
+}
+
+func (c *UserClientAdapter) UpdateSearchPrefs(ctx context.Context, userID int64, prefs *userpb.SearchPrefs) (*userpb.User, error) {
+	resp, err := c.grpc.UpdateSearchPrefs(ctx, &userpb.UpdateSearchPrefsRequest{UserId: userID, Prefs: prefs})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.User == nil {
+		return nil, ErrEmptyResponse
+	}
+	return resp.User, nil
+}
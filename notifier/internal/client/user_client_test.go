@@ -0,0 +1,62 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"app/notifier/internal/client"
+	userpb "app/user/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// userService запоминает запросы на создание и изменение анкеты.
+type userService struct {
+	userpb.UserServiceClient
+	register *userpb.RegisterUserRequest
+	update   *userpb.UpdateProfileRequest
+}
+
+func (s *userService) RegisterUser(_ context.Context, req *userpb.RegisterUserRequest, _ ...grpc.CallOption) (*userpb.UserResponse, error) {
+	s.register = req
+	return &userpb.UserResponse{User: &userpb.User{Id: 1, Geo: req.GetGeo()}}, nil
+}
+
+func (s *userService) UpdateProfile(_ context.Context, req *userpb.UpdateProfileRequest, _ ...grpc.CallOption) (*userpb.UserResponse, error) {
+	s.update = req
+	return &userpb.UserResponse{User: &userpb.User{Id: req.GetUserId(), Geo: req.GetGeo()}}, nil
+}
+
+func TestUserClientAdapter_Geo(t *testing.T) {
+	geo := &userpb.GeoPoint{Latitude: 52.52, Longitude: 13.405}
+
+	tests := []struct {
+		name string
+		geo  *userpb.GeoPoint
+	}{
+		{name: "with geo", geo: geo},
+		{name: "without geo", geo: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &userService{}
+			c := client.NewUserClientAdapter(svc)
+
+			if _, err := c.Create(t.Context(), &userpb.User{TelegramId: 1001, Username: "Alice", Geo: tt.geo}); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(svc.register.GetGeo(), tt.geo) {
+				t.Fatalf("RegisterUser geo = %v, want %v", svc.register.GetGeo(), tt.geo)
+			}
+
+			// пустая геопозиция в UpdateProfile значит "не менять"
+			if _, err := c.Update(t.Context(), &userpb.User{Id: 1, Geo: tt.geo}); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(svc.update.GetGeo(), tt.geo) {
+				t.Fatalf("UpdateProfile geo = %v, want %v", svc.update.GetGeo(), tt.geo)
+			}
+		})
+	}
+}
@@ -1,13 +1,38 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type config struct {
 	TelegramToken string
 	UserGRPCAddr  string
 	MatchGRPCAddr string
+	RedisDSN      string
+	SessionTTL    time.Duration
+	RateLimit     float64
+	RateBurst     int
+	MetricsAddr   string
+	// AdminIDs — Telegram ID администраторов (NOTIFIER_ADMIN_IDS через запятую).
+	AdminIDs []int64
+	// BroadcastRate — сообщений рассылки в секунду; Telegram пропускает около 30
+	// на всего бота, часть оставляем обычным ответам.
+	BroadcastRate float64
+
+	// Mode — "polling" (по умолчанию) или "webhook".
+	Mode            string
+	WebhookListen   string
+	WebhookURL      string
+	WebhookPath     string
+	WebhookSecret   string
+	WebhookCert     string
+	WebhookKey      string
+	WebhookSelfSign bool
+	WebhookMaxConns int
 }
 
 var C config
@@ -17,6 +42,23 @@ func Load() {
 		TelegramToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
 		UserGRPCAddr:  getEnv("USER_CLIENT", "user_service:50051"),
 		MatchGRPCAddr: getEnv("MATCH_CLIENT", "match_service:50052"),
+		RedisDSN:      getEnv("NOTIFIER_REDIS_DSN", ""),
+		SessionTTL:    getDuration("NOTIFIER_SESSION_TTL", 7*24*time.Hour),
+		RateLimit:     getFloat("NOTIFIER_RATE_LIMIT", 1),
+		RateBurst:     getInt("NOTIFIER_RATE_BURST", 5),
+		MetricsAddr:   getEnv("NOTIFIER_METRICS_ADDR", ""),
+		AdminIDs:      getIDs("NOTIFIER_ADMIN_IDS"),
+		BroadcastRate: getFloat("NOTIFIER_BROADCAST_RATE", 25),
+
+		Mode:            getEnv("NOTIFIER_MODE", "polling"),
+		WebhookListen:   getEnv("NOTIFIER_WEBHOOK_LISTEN", ":8080"),
+		WebhookURL:      getEnv("NOTIFIER_WEBHOOK_URL", ""),
+		WebhookPath:     getEnv("NOTIFIER_WEBHOOK_PATH", ""),
+		WebhookSecret:   getEnv("NOTIFIER_WEBHOOK_SECRET", ""),
+		WebhookCert:     getEnv("NOTIFIER_WEBHOOK_TLS_CERT", ""),
+		WebhookKey:      getEnv("NOTIFIER_WEBHOOK_TLS_KEY", ""),
+		WebhookSelfSign: getBool("NOTIFIER_WEBHOOK_SELF_SIGNED", false),
+		WebhookMaxConns: getInt("NOTIFIER_WEBHOOK_MAX_CONNECTIONS", 0),
 	}
 
 }
@@ -27,3 +69,61 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+func getFloat(key string, fallback float64) float64 {
+	if value, ok := os.LookupEnv(key); ok {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// getIDs разбирает список ID через запятую; некорректные значения пропускаются.
+func getIDs(key string) []int64 {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	var ids []int64
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			log.Printf("config: %s: skipping invalid id %q", key, part)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
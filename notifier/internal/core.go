@@ -1,11 +1,13 @@
 package internal
 
 import (
+	"app/notifier/internal/i18n"
 	userpb "app/user/proto"
 	"bytes"
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,181 +20,326 @@ const (
 	ReplyMenu
 	ReplyGender
 	ReplyBrowse
+	ReplyLiked
+	ReplyMatch
+	ReplyLanguage
+	ReplyEdit
+	ReplyEditField
+	ReplyEditGender
+	ReplyPhotosDone
+	ReplyEditPhotos
+	ReplyResume
+	ReplyDeleteConfirm
+	ReplyRemoveKeyboard
+	ReplyMatchItem
+	ReplyMatchesMore
+	ReplyReportReason
+	ReplyReportComment
+	ReplyLikeMessage
+	// ReplyAskCity и ReplyEditGeo — клавиатура с кнопкой отправки геопозиции.
+	ReplyAskCity
+	ReplyEditGeo
+	ReplySearch
+	ReplySearchGender
+	ReplySearchAge
+	ReplySearchRadius
+	ReplyIntro
 )
 
-type Output struct {
-	Text        string
-	PhotoString string
-	Kind        ReplyKind
-}
-
-type state int
-
+// Значения пола, которые хранит user service.
 const (
-	stIdle state = iota
-	stAskName
-	stAskAge
-	stAskCity
-	stAskGender
-	stAskDesc
-	stAskPhoto
-	stMenu
-	stBrowsing
+	genderMale   = "Парень"
+	genderFemale = "Девушка"
 )
 
-type candidate struct {
-	UserID     int64
-	TelegramID int64
-}
-
-type session struct {
-	State         state
-	Draft         draftProfile
-	Candidates    []candidate
-	CurrentTarget *candidate
-	UpdatedAt     time.Time
+type Output struct {
+	Text i18n.Msg
+	Lang string
+	// Photos — фото сообщения; если их несколько, отправляются альбомом.
+	Photos []string
+	Kind   ReplyKind
+	// TargetID — пользователь, к которому относятся inline-кнопки сообщения.
+	TargetID int64
+	// PhotoIDs — id фото в порядке Photos (для ReplyEditPhotos).
+	PhotoIDs []int64
+	// Link — ссылка на чат с пользователем (для ReplyMatch и ReplyMatchItem).
+	Link string
+	// UndoID — пользователь, оценку которого можно отменить кнопкой под анкетой (для ReplyBrowse).
+	UndoID int64
+	// Intro — голосовое или кружок анкеты, отправляется после сообщения.
+	Intro *Intro
+	// Notify — сообщения другим пользователям, отправляются вместе с ответом.
+	Notify []Notification
 }
 
-type draftProfile struct {
-	Name        string
-	Age         int32
-	City        string
-	Gender      string
-	Description string
-	PhotoString string
+type Notification struct {
+	ChatID int64
+	Output Output
 }
 
 type Core struct {
-	users UserClient
-	match MatchClient
-
-	mu       sync.RWMutex
-	sessions map[int64]*session
+	users    UserClient
+	match    MatchClient
+	sessions SessionStore
+	admins   map[int64]bool
+	bans     BanCache
+
+	// Telegram присылает альбом отдельными сообщениями, и бот обрабатывает их параллельно,
+	// поэтому апдейты одного чата выполняются по очереди; между репликами их упорядочивает
+	// SessionStore.Lock.
+	locks [64]sync.Mutex
 }
 
-func NewCore(users UserClient, match MatchClient) *Core {
-	return &Core{
+// NewCore создаёт ядро бота; admins — Telegram ID пользователей с доступом к админским командам.
+func NewCore(users UserClient, match MatchClient, sessions SessionStore, bans BanCache, admins []int64) *Core {
+	c := &Core{
 		users:    users,
 		match:    match,
-		sessions: make(map[int64]*session),
+		sessions: sessions,
+		admins:   make(map[int64]bool, len(admins)),
+		bans:     bans,
+	}
+	for _, id := range admins {
+		c.admins[id] = true
 	}
+	return c
+}
+
+// lock блокирует чат в процессе и в хранилище сессий и возвращает функцию разблокировки.
+func (c *Core) lock(ctx context.Context, chatID int64) func() {
+	mu := c.mutex(chatID)
+	mu.Lock()
+	return c.lockSession(ctx, chatID, mu.Unlock)
 }
 
-func (c *Core) get(chatID int64) *session {
-	c.mu.RLock()
-	s := c.sessions[chatID]
-	c.mu.RUnlock()
+func (c *Core) mutex(chatID int64) *sync.Mutex {
+	return &c.locks[uint64(chatID)%uint64(len(c.locks))]
+}
+
+// lockSession блокирует сессию чата в хранилище; unlock вызывается после её разблокировки.
+// Если хранилище недоступно, апдейт обрабатывается под одной локальной блокировкой.
+func (c *Core) lockSession(ctx context.Context, chatID int64, unlock func()) func() {
+	release, err := c.sessions.Lock(ctx, chatID)
+	if err != nil {
+		log.Printf("core: lock session %d: %v", chatID, err)
+		return unlock
+	}
+	return func() {
+		release()
+		unlock()
+	}
+}
+
+func (c *Core) get(ctx context.Context, chatID int64) *session {
+	s, err := c.sessions.Load(ctx, chatID)
+	if err != nil {
+		log.Printf("core: load session %d: %v", chatID, err)
+	}
 	if s == nil {
-		s = &session{State: stIdle, UpdatedAt: time.Now()}
-		c.mu.Lock()
-		c.sessions[chatID] = s
-		c.mu.Unlock()
+		s = &session{State: stIdle}
+	}
+	if s.Lang == "" {
+		s.Lang = i18n.FromContext(ctx)
 	}
 	return s
 }
 
-func (c *Core) reset(chatID int64) {
-	c.mu.Lock()
-	delete(c.sessions, chatID)
-	c.mu.Unlock()
+// done сохраняет сессию и проставляет язык ответу и уведомлениям.
+func (c *Core) done(ctx context.Context, chatID int64, s *session, out *Output) {
+	out.Lang = s.Lang
+	for i := range out.Notify {
+		n := &out.Notify[i]
+		if n.ChatID == chatID {
+			n.Output.Lang = s.Lang
+		} else {
+			n.Output.Lang = c.langOf(ctx, n.ChatID)
+		}
+	}
+	if s.State == stDeleted {
+		c.reset(ctx, chatID)
+		return
+	}
+	c.save(ctx, chatID, s)
 }
 
-func (c *Core) OnStart(ctx context.Context, chatID int64) (Output, error) {
+// langOf возвращает язык другого пользователя по его сохранённой сессии.
+func (c *Core) langOf(ctx context.Context, chatID int64) string {
+	s, err := c.sessions.Load(ctx, chatID)
+	if err != nil || s == nil || s.Lang == "" {
+		return i18n.Default
+	}
+	return s.Lang
+}
+
+// Lang возвращает язык пользователя, например для сообщений об ошибках.
+func (c *Core) Lang(ctx context.Context, chatID int64) string {
+	return c.get(ctx, chatID).Lang
+}
+
+// save сохраняет сессию; TTL хранилища отсчитывается от последнего апдейта чата.
+func (c *Core) save(ctx context.Context, chatID int64, s *session) {
+	s.UpdatedAt = time.Now()
+	if err := c.sessions.Save(ctx, chatID, s); err != nil {
+		log.Printf("core: save session %d: %v", chatID, err)
+	}
+}
+
+func (c *Core) reset(ctx context.Context, chatID int64) {
+	if err := c.sessions.Delete(ctx, chatID); err != nil {
+		log.Printf("core: delete session %d: %v", chatID, err)
+	}
+}
+
+// OnStart начинает регистрацию или открывает меню. payload — параметр deep-link ссылки,
+// он запоминается только у новых пользователей.
+func (c *Core) OnStart(ctx context.Context, chatID int64, payload string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
 	u, err := c.users.GetByTelegramID(ctx, chatID)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
 			u = nil
 		} else {
 			log.Printf("core: GetByTelegramID: %v", err)
-			return Output{Text: "Сервис недоступен. Попробуй позже."}, nil
+			return Output{Text: i18n.M("error.unavailable")}, nil
 		}
 	}
 
-	s := c.get(chatID)
 	if u == nil {
 		s.State = stAskName
 		s.Draft = draftProfile{}
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Привет! Давай создадим анкету.\nКак тебя зовут?"}, nil
+		s.Draft.Referrer, s.Draft.Campaign = parseStartPayload(payload)
+		return Output{Text: i18n.M("start.new")}, nil
+	}
+
+	// пользователь снова пишет боту — значит, разблокировал его, и рассылки можно присылать
+	if u.GetBotBlocked() {
+		if err := c.users.SetBotBlocked(ctx, u.GetId(), false); err != nil {
+			log.Printf("core: SetBotBlocked(%d, false): %v", u.GetId(), err)
+		}
+	}
+	// имя в Telegram можно сменить в любой момент, а ссылка на контакт строится по нему
+	if name := tgUsername(ctx); name != "" && name != u.GetTgUsername() {
+		if _, err := c.users.Update(ctx, &userpb.User{Id: u.GetId(), TgUsername: name}); err != nil {
+			log.Printf("core: Update tg username(%d): %v", u.GetId(), err)
+		}
 	}
 
 	s.State = stMenu
-	s.UpdatedAt = time.Now()
 	return Output{
-		Text: "\nВыбери действие:\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏️",
+		Text: withMenu("menu.choose"),
 		Kind: ReplyMenu,
 	}, nil
 }
 
-func (c *Core) OnText(ctx context.Context, chatID int64, text string) (Output, error) {
-	s := c.get(chatID)
+func (c *Core) OnText(ctx context.Context, chatID int64, text string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
 	switch s.State {
 	case stAskName:
 		s.Draft.Name = text
 		s.State = stAskAge
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Сколько тебе лет?"}, nil
+		return Output{Text: i18n.M("ask.age")}, nil
 
 	case stAskAge:
 		var age int32
 		_, err := fmt.Sscanf(text, "%d", &age)
 		if err != nil || age <= 0 {
-			return Output{Text: "Возраст должен быть числом. Введи корректный возраст."}, nil
+			return Output{Text: i18n.M("ask.age.invalid")}, nil
 		}
 		s.Draft.Age = age
 		s.State = stAskCity
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Где ты живёшь? Укажи город."}, nil
+		return Output{Text: i18n.M("ask.city"), Kind: ReplyAskCity}, nil
 
 	case stAskCity:
 		s.Draft.City = text
 		s.State = stAskGender
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Выбери пол:", Kind: ReplyGender}, nil
+		return Output{Text: i18n.M("ask.gender"), Kind: ReplyGender}, nil
 
 	case stAskGender:
-		if text != "Парень" && text != "Девушка" {
-			return Output{Text: "Пожалуйста, выбери кнопкой: Парень или Девушка.", Kind: ReplyGender}, nil
+		switch {
+		case i18n.Matches(text, "gender.male"):
+			s.Draft.Gender = genderMale
+		case i18n.Matches(text, "gender.female"):
+			s.Draft.Gender = genderFemale
+		default:
+			return Output{Text: i18n.M("ask.gender.invalid"), Kind: ReplyGender}, nil
 		}
-		s.Draft.Gender = text
 		s.State = stAskDesc
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Кратко опиши себя (интересы, что ищешь)."}, nil
+		return Output{Text: i18n.M("ask.desc")}, nil
 
 	case stAskDesc:
 		s.Draft.Description = text
 		s.State = stAskPhoto
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Пришли фото для анкеты (одно изображение)."}, nil
+		return Output{Text: i18n.M("ask.photo", maxPhotos)}, nil
+
+	case stAskMorePhotos:
+		return c.finishPhotos(s), nil
+
+	case stAskIntro:
+		return finishProfile(s), nil
 
 	case stMenu:
 		switch text {
 		case "1":
-			return c.startBrowsing(ctx, chatID)
+			return c.startBrowsing(ctx, chatID, s)
 		case "2":
 			return c.showProfile(ctx, chatID)
 		case "3":
-			s.State = stAskName
-			s.Draft = draftProfile{}
-			s.UpdatedAt = time.Now()
-			return Output{Text: "Ок, обновим анкету. Как тебя зовут?"}, nil
+			return c.editMenu(ctx, chatID, s, "edit.choose")
+		case "4":
+			return c.togglePause(ctx, chatID, s)
+		case "5":
+			return c.startInbox(ctx, chatID, s)
+		case "6":
+			return c.showMatches(ctx, chatID, s, "")
+		case "7":
+			return c.searchSettings(ctx, chatID, s, "search.choose")
 		default:
-			return Output{Text: "Выбери пункт меню: 1 (смотреть), 2 (моя анкета), 3 (изменить).", Kind: ReplyMenu}, nil
+			return Output{Text: i18n.M("menu.hint"), Kind: ReplyMenu}, nil
 		}
 
+	case stEditField:
+		return c.onEditText(ctx, chatID, s, text)
+
 	case stBrowsing:
-		return Output{Text: "Используй кнопки: ❤️ / 👎 / 💤", Kind: ReplyBrowse}, nil
+		return Output{Text: i18n.M("browse.hint")}, nil
+
+	case stConfirmDelete:
+		return c.cancelDelete(s), nil
+
+	case stReportComment:
+		return c.submitReport(ctx, chatID, s, text)
+
+	case stLikeMessage:
+		return c.submitLikeMessage(ctx, chatID, s, text)
+
+	case stSearchAge:
+		return c.submitSearchAge(ctx, chatID, s, text)
 
 	default:
 		s.State = stAskName
-		return Output{Text: "Давай начнём с начала. Как тебя зовут?"}, nil
+		return Output{Text: i18n.M("start.over")}, nil
 	}
 }
 
-func (c *Core) OnPhoto(ctx context.Context, chatID int64, photo []byte) (Output, error) {
-	s := c.get(chatID)
+func (c *Core) OnPhoto(ctx context.Context, chatID int64, photo []byte) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if s.State == stEditField && s.EditField == fieldPhoto {
+		return c.onEditPhoto(ctx, chatID, s, photo)
+	}
+	if s.State == stAskMorePhotos {
+		return c.onMorePhoto(ctx, chatID, s, photo)
+	}
 	if s.State != stAskPhoto {
-		return Output{Text: "Фото сейчас не требуется. Используй меню."}, nil
+		return Output{Text: i18n.M("photo.unexpected")}, nil
 	}
 
 	existing, _ := c.users.GetByTelegramID(ctx, chatID)
@@ -205,169 +352,384 @@ func (c *Core) OnPhoto(ctx context.Context, chatID int64, photo []byte) (Output,
 		Location:    s.Draft.City,
 		Description: s.Draft.Description,
 		IsVisible:   true,
+		Geo:         s.Draft.Geo.pb(),
+		ReferrerId:  s.Draft.Referrer,
+		Campaign:    s.Draft.Campaign,
+		TgUsername:  tgUsername(ctx),
 	}
 
 	var saved *userpb.User
-	var err error
 
 	if existing == nil {
 		saved, err = c.users.Create(ctx, u)
 		if err != nil {
 			log.Printf("core: Create user: %v", err)
-			return Output{Text: "Не удалось сохранить анкету. Попробуй ещё раз."}, nil
+			return Output{Text: i18n.M("profile.save_failed")}, nil
 		}
 	} else {
 		u.Id = existing.GetId()
 		saved, err = c.users.Update(ctx, u)
 		if err != nil {
 			log.Printf("core: Update user: %v", err)
-			return Output{Text: "Не удалось обновить анкету. Попробуй ещё раз."}, nil
+			return Output{Text: i18n.M("profile.update_failed")}, nil
 		}
 	}
 
 	if len(photo) > 0 {
 		if u2, err := c.users.UpdatePhoto(ctx, saved.GetId(), bytes.NewReader(photo)); err == nil && u2 != nil {
 			saved = u2
+		} else if err != nil {
+			log.Printf("core: UpdatePhoto: %v", err)
 		}
 	}
 
-	s.State = stMenu
 	s.Draft = draftProfile{}
-	s.UpdatedAt = time.Now()
+	s.State = stAskMorePhotos
 
 	return Output{
-		Text: "Анкета сохранена! Что дальше?\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏️",
-		Kind: ReplyMenu,
+		Text: i18n.M("photo.more", len(saved.GetPhotos()), maxPhotos),
+		Kind: ReplyPhotosDone,
 	}, nil
 }
 
-func (c *Core) OnCallback(ctx context.Context, chatID int64, action string) (Output, error) {
-	s := c.get(chatID)
+func (c *Core) OnCallback(ctx context.Context, chatID int64, action string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
 
 	if s.State == stAskGender && (action == "gender_male" || action == "gender_female") {
 		if action == "gender_male" {
-			s.Draft.Gender = "Парень"
+			s.Draft.Gender = genderMale
 		} else {
-			s.Draft.Gender = "Девушка"
+			s.Draft.Gender = genderFemale
 		}
 		s.State = stAskDesc
-		s.UpdatedAt = time.Now()
-		return Output{Text: "Кратко опиши себя."}, nil
+		return Output{Text: i18n.M("ask.desc")}, nil
+	}
+
+	if s.State == stEditField && s.EditField == fieldGender && (action == "gender_male" || action == "gender_female") {
+		patch := &userpb.User{Gender: genderMale}
+		if action == "gender_female" {
+			patch.Gender = genderFemale
+		}
+		return c.applyEdit(ctx, chatID, s, patch)
+	}
+
+	// данные кнопки: "<action>" или "<action>:<arg>"
+	name, arg, _ := strings.Cut(action, ":")
+	switch name {
+	case "liker":
+		return c.showLiker(ctx, chatID, arg)
+	case "lang":
+		return c.setLanguage(s, arg), nil
+	case "edit":
+		return c.onEditAction(ctx, chatID, s, arg)
+	case "photos":
+		if s.State != stAskMorePhotos {
+			return Output{Text: i18n.M("action.unavailable")}, nil
+		}
+		return c.finishPhotos(s), nil
+	case "photo":
+		return c.onPhotoAction(ctx, chatID, s, arg)
+	case "intro":
+		if s.State != stAskIntro || arg != "skip" {
+			return Output{Text: i18n.M("action.unavailable")}, nil
+		}
+		return finishProfile(s), nil
+	case "resume":
+		return c.onResumeAction(ctx, chatID, s, arg)
+	case "delete":
+		return c.onDeleteAction(ctx, chatID, s, arg)
+	case "matches":
+		return c.onMatchesAction(ctx, chatID, s, arg)
+	case "unmatch":
+		return c.unmatch(ctx, chatID, arg)
+	case "report":
+		return c.onReportAction(ctx, chatID, s, arg)
+	case "likemsg":
+		return c.onLikeMessageAction(s, arg), nil
+	case "search":
+		return c.onSearchAction(ctx, chatID, s, arg)
 	}
 
 	if s.State != stBrowsing {
-		return Output{Text: "Действие сейчас недоступно. Используй меню."}, nil
+		return Output{Text: i18n.M("action.unavailable")}, nil
 	}
 
-	switch action {
+	switch name {
 	case "like", "dislike":
 		if s.CurrentTarget == nil {
 			s.State = stMenu
-			s.UpdatedAt = time.Now()
-			return Output{Text: "Кандидатов больше нет.\nЧто дальше?\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏", Kind: ReplyMenu}, nil
-		}
-
-		me, err := c.users.GetByTelegramID(ctx, chatID)
-		if err != nil {
-			if strings.Contains(strings.ToLower(err.Error()), "user not found") {
-				return Output{Text: "Сначала зарегистрируй анкету: /start"}, nil
-			}
-			log.Printf("core: GetByTelegramID: %v", err)
-			return Output{Text: "Сервис недоступен, попробуй позже."}, nil
+			return Output{Text: withMenu("browse.no_more"), Kind: ReplyMenu}, nil
 		}
-
-		isLike := action == "like"
-		if err := c.match.Like(ctx, me.GetId(), s.CurrentTarget.UserID, isLike); err != nil {
-			log.Printf("core: Like(%v): %v", isLike, err)
+		// кнопка должна относиться к анкете, которая сейчас на экране
+		targetID, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || targetID != s.CurrentTarget.UserID {
+			return Output{Text: i18n.M("browse.stale")}, nil
 		}
+		return c.rate(ctx, chatID, s, name == "like", "")
 
-		if isLike {
-			if ok, err := c.match.Match(ctx, me.GetId(), s.CurrentTarget.UserID); err == nil && ok {
-				out, err := c.nextCandidate(ctx, chatID)
-				if err == nil {
-					if out.Text != "" {
-						out.Text = "🎉 У тебя совпадение!\n\n" + out.Text
-					} else {
-						out.Text = "🎉 У тебя совпадение!"
-					}
-					return out, nil
-				}
-			}
-		}
-		return c.nextCandidate(ctx, chatID)
+	case "undo":
+		return c.undoSwipe(ctx, chatID, s, arg)
 
 	case "sleep":
 		s.State = stMenu
-		s.UpdatedAt = time.Now()
 		return Output{
-			Text: "Ок, вернулись в меню.\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏️",
+			Text: withMenu("browse.sleep"),
 			Kind: ReplyMenu,
 		}, nil
 	}
 
-	return Output{Text: "Неизвестное действие."}, nil
+	return Output{Text: i18n.M("action.unknown")}, nil
 }
 
-func (c *Core) startBrowsing(ctx context.Context, chatID int64) (Output, error) {
-	u, err := c.users.GetByTelegramID(ctx, chatID)
+// rate оценивает анкету на экране, сообщает о лайке или совпадении и показывает следующую.
+func (c *Core) rate(ctx context.Context, chatID int64, s *session, isLike bool, message string) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
-			s := c.get(chatID)
-			s.State = stAskName
-			return Output{Text: "Похоже, анкеты нет. Давай создадим! Как тебя зовут?"}, nil
+			return Output{Text: i18n.M("register.first")}, nil
 		}
-		return Output{Text: "Сервис недоступен, попробуй позже."}, nil
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
 	}
 
-	cands, err := c.match.GetCandidates(ctx, u.GetId())
-	if err != nil {
-		return Output{Text: "Не удалось получить кандидатов. Попробуй позже."}, nil
+	target := *s.CurrentTarget
+	s.LastSwipe = nil
+	liked := true
+	if err := c.match.Like(ctx, me.GetId(), target.UserID, isLike, message); err != nil {
+		if out, ok := likeQuotaOutput(err, target.UserID); ok {
+			return out, nil
+		}
+		log.Printf("core: Like(%v): %v", isLike, err)
+		liked = false
 	}
-	if len(cands) == 0 {
-		return Output{Text: "Пока нет подходящих анкет.\nЧто дальше?\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏", Kind: ReplyMenu}, nil
+
+	if isLike && liked && !me.GetActivated() {
+		if err := c.users.MarkActivated(ctx, me.GetId()); err != nil {
+			log.Printf("core: MarkActivated(%d): %v", me.GetId(), err)
+		}
+	}
+
+	if isLike && liked {
+		if ok, err := c.match.Match(ctx, me.GetId(), target.UserID); err != nil {
+			log.Printf("core: Match: %v", err)
+		} else if ok {
+			return c.announceMatch(ctx, chatID, s, me, target)
+		}
 	}
 
-	s := c.get(chatID)
-	s.Candidates = s.Candidates[:0]
-	for _, cand := range cands {
-		s.Candidates = append(s.Candidates, candidate{
-			UserID:     cand.GetId(),
-			TelegramID: cand.GetTelegramId(),
+	// совпадение уже разослано обоим, его не отменить; остальные оценки — можно
+	if liked {
+		s.LastSwipe = &target
+	}
+	out, err := c.nextCandidate(ctx, chatID, s)
+	if isLike && liked {
+		text := i18n.M("like.received")
+		if message != "" {
+			text = i18n.M("like.received.message", message)
+		}
+		out.Notify = append(out.Notify, Notification{
+			ChatID: target.TelegramID,
+			Output: Output{
+				Text:     text,
+				Kind:     ReplyLiked,
+				TargetID: me.GetId(),
+			},
 		})
 	}
-	s.State = stBrowsing
-	s.UpdatedAt = time.Now()
+	return out, err
+}
 
-	return c.nextCandidate(ctx, chatID)
+// OnLanguage переключает язык, если он передан в команде, иначе предлагает выбрать.
+func (c *Core) OnLanguage(ctx context.Context, chatID int64, lang string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if lang = strings.ToLower(strings.TrimSpace(lang)); lang != "" {
+		return c.setLanguage(s, lang), nil
+	}
+	return Output{Text: i18n.M("lang.choose"), Kind: ReplyLanguage}, nil
+}
+
+func (c *Core) setLanguage(s *session, lang string) Output {
+	if !i18n.IsSupported(lang) {
+		return Output{Text: i18n.M("lang.choose"), Kind: ReplyLanguage}
+	}
+	s.Lang = lang
+	return Output{Text: i18n.M("lang.changed")}
 }
 
-func (c *Core) nextCandidate(ctx context.Context, chatID int64) (Output, error) {
-	s := c.get(chatID)
+func (c *Core) startBrowsing(ctx context.Context, chatID int64, s *session) (Output, error) {
+	u, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			s.State = stAskName
+			return Output{Text: i18n.M("profile.missing")}, nil
+		}
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	// скрытая анкета не участвует в поиске — предлагаем сначала её показать
+	if !u.GetIsVisible() {
+		return Output{Text: i18n.M("browse.hidden"), Kind: ReplyResume}, nil
+	}
+
+	// очередь прошлого просмотра продолжается: показанные анкеты уже не вернутся
+	s.Inbox = false
+	s.InboxQueue = nil
 	if len(s.Candidates) == 0 {
+		s.CandidatesEnd = false
+		if err := c.fetchCandidates(ctx, chatID, s); err != nil {
+			log.Printf("core: GetCandidates: %v", err)
+			return Output{Text: i18n.M("browse.fetch_failed")}, nil
+		}
+		if len(s.Candidates) == 0 {
+			return Output{Text: withMenu("browse.empty"), Kind: ReplyMenu}, nil
+		}
+	}
+
+	s.LastSwipe = nil
+	s.State = stBrowsing
+
+	return c.nextCandidate(ctx, chatID, s)
+}
+
+// refillAt — когда в очереди остаётся меньше анкет, подгружается следующая страница выдачи.
+const refillAt = 5
+
+func (c *Core) nextCandidate(ctx context.Context, chatID int64, s *session) (Output, error) {
+	if !s.Inbox && !s.CandidatesEnd && len(s.Candidates) < refillAt {
+		if err := c.fetchCandidates(ctx, chatID, s); err != nil {
+			log.Printf("core: GetCandidates: %v", err)
+			if len(s.Candidates) == 0 {
+				s.State = stMenu
+				return Output{Text: i18n.M("browse.fetch_failed")}, nil
+			}
+		}
+	}
+	q := s.queue()
+	if len(*q) == 0 {
 		s.State = stMenu
-		return Output{Text: "Анкеты закончились. Возвращаемся в меню.\nЧто дальше?\n1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏", Kind: ReplyMenu}, nil
+		if s.Inbox {
+			return Output{Text: withMenu("inbox.finished"), Kind: ReplyMenu}, nil
+		}
+		return Output{Text: withMenu("browse.finished"), Kind: ReplyMenu}, nil
 	}
 
-	last := s.Candidates[len(s.Candidates)-1]
-	s.Candidates = s.Candidates[:len(s.Candidates)-1]
+	last := (*q)[len(*q)-1]
+	*q = (*q)[:len(*q)-1]
 	s.CurrentTarget = &last
-	s.UpdatedAt = time.Now()
 
 	target, err := c.users.GetByID(ctx, last.UserID)
-	if err != nil || target == nil {
+	if (err != nil || target == nil) && last.TelegramID != 0 {
 		target, _ = c.users.GetByTelegramID(ctx, last.TelegramID)
 	}
 	if target == nil {
-		return Output{Text: "Не удалось получить профиль кандидата. Пробуем следующего…"}, nil
+		return Output{Text: i18n.M("candidate.unavailable")}, nil
+	}
+	// у лайков из входящих известен только id пользователя
+	if last.TelegramID == 0 {
+		s.CurrentTarget.TelegramID = target.GetTelegramId()
 	}
 
-	caption := fmt.Sprintf("%s, %d, %s\n%s",
-		target.GetUsername(), target.GetAge(), target.GetLocation(), target.GetDescription())
+	text := i18n.M("card", profileCaption(target))
+	if last.Message != "" {
+		text = i18n.M("card.message", profileCaption(target), last.Message)
+	}
+	return Output{
+		Text:     text,
+		Kind:     ReplyBrowse,
+		Photos:   photoURLs(target),
+		TargetID: last.UserID,
+		UndoID:   s.LastSwipe.id(),
+		Intro:    introOf(target),
+	}, nil
+}
+
+// fetchCandidates добавляет следующую страницу выдачи в очередь. nextCandidate берёт анкеты
+// с конца, поэтому страница встаёт в начало и в обратном порядке; анкеты, которые уже
+// в очереди или на экране, пропускаются.
+func (c *Core) fetchCandidates(ctx context.Context, chatID int64, s *session) error {
+	page, more, err := c.match.GetCandidates(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	s.CandidatesEnd = !more
 
+	queued := make(map[int64]bool, len(s.Candidates)+1)
+	for _, cand := range s.Candidates {
+		queued[cand.UserID] = true
+	}
+	if s.CurrentTarget != nil {
+		queued[s.CurrentTarget.UserID] = true
+	}
+
+	fresh := make([]candidate, 0, len(page)+len(s.Candidates))
+	for i := len(page) - 1; i >= 0; i-- {
+		if queued[page[i].GetId()] {
+			continue
+		}
+		fresh = append(fresh, candidate{
+			UserID:     page[i].GetId(),
+			TelegramID: page[i].GetTelegramId(),
+		})
+	}
+	s.Candidates = append(fresh, s.Candidates...)
+	return nil
+}
+
+// announceMatch рассылает карточки совпадения обоим пользователям и показывает следующую анкету.
+func (c *Core) announceMatch(ctx context.Context, chatID int64, s *session, me *userpb.User, target candidate) (Output, error) {
+	other, err := c.users.GetByID(ctx, target.UserID)
+	if err != nil || other == nil {
+		other, _ = c.users.GetByTelegramID(ctx, target.TelegramID)
+	}
+
+	var notify []Notification
+	if other != nil {
+		notify = append(notify,
+			Notification{ChatID: me.GetTelegramId(), Output: matchCard(other)},
+			Notification{ChatID: other.GetTelegramId(), Output: matchCard(me)},
+		)
+	} else {
+		log.Printf("core: match with %d, but profile is unavailable", target.UserID)
+	}
+
+	out, err := c.nextCandidate(ctx, chatID, s)
+	out.Notify = append(notify, out.Notify...)
+	return out, err
+}
+
+// showLiker показывает анкету из уведомления о лайке. id берётся из кнопки, поэтому анкету
+// показываем, только если лайк всё ещё стоит, а сама анкета не скрыта и не заблокирована.
+func (c *Core) showLiker(ctx context.Context, chatID int64, arg string) (Output, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID(%d): %v", chatID, err)
+		return Output{Text: i18n.M("liker.failed")}, nil
+	}
+	liked, err := c.match.HasLike(ctx, id, me.GetId())
+	if err != nil || !liked {
+		if err != nil {
+			log.Printf("core: HasLike(%d, %d): %v", id, me.GetId(), err)
+		}
+		return Output{Text: i18n.M("liker.failed")}, nil
+	}
+	u, err := c.users.GetByID(ctx, id)
+	if err != nil || u == nil || !u.GetIsVisible() || u.GetIsBanned() {
+		if err != nil {
+			log.Printf("core: GetByID(%d): %v", id, err)
+		}
+		return Output{Text: i18n.M("liker.failed")}, nil
+	}
 	return Output{
-		Text:        caption,
-		Kind:        ReplyBrowse,
-		PhotoString: target.GetPhotoUrl(),
+		Text:   i18n.M("liker.card", profileCaption(u)),
+		Photos: photoURLs(u),
 	}, nil
 }
 
@@ -375,16 +737,54 @@ func (c *Core) showProfile(ctx context.Context, chatID int64) (Output, error) {
 	u, err := c.users.GetByTelegramID(ctx, chatID)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
-			return Output{Text: "Анкета не найдена. Давай создадим! Как тебя зовут?"}, nil
+			return Output{Text: i18n.M("profile.not_found")}, nil
 		}
-		return Output{Text: "Сервис недоступен. Попробуй позже."}, nil
+		return Output{Text: i18n.M("error.unavailable")}, nil
 	}
-	caption := fmt.Sprintf("Твоя анкета:\n%s, %d, %s\n%s",
+	return Output{
+		Text:   i18n.M("profile.mine", profileCaption(u), visibilityStatus(u.GetIsVisible())),
+		Kind:   ReplyMenu,
+		Photos: photoURLs(u),
+		Intro:  introOf(u),
+	}, nil
+}
+
+// withMenu дополняет сообщение списком пунктов меню.
+func withMenu(key string) i18n.Msg {
+	return i18n.M(key, i18n.M("menu.items"))
+}
+
+func profileCaption(u *userpb.User) string {
+	return fmt.Sprintf("%s, %d, %s\n%s",
 		u.GetUsername(), u.GetAge(), u.GetLocation(), u.GetDescription())
+}
 
+func matchCard(u *userpb.User) Output {
 	return Output{
-		Text:        caption,
-		Kind:        ReplyMenu,
-		PhotoString: u.GetPhotoUrl(),
-	}, nil
+		Text:   i18n.M("match.card", profileCaption(u)),
+		Photos: photoURLs(u),
+		Kind:   ReplyMatch,
+		Link:   contactLink(u),
+	}
+}
+
+// contactLink ведёт в чат с пользователем. Ссылку по имени Telegram открывает всегда,
+// а tg://user?id= отклоняет, если пользователь скрыл себя в настройках приватности.
+func contactLink(u *userpb.User) string {
+	if name := u.GetTgUsername(); name != "" {
+		return "https://t.me/" + name
+	}
+	return fmt.Sprintf("tg://user?id=%d", u.GetTelegramId())
+}
+
+type tgUsernameKey struct{}
+
+// WithTgUsername кладёт в контекст имя пользователя Telegram отправителя апдейта.
+func WithTgUsername(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, tgUsernameKey{}, name)
+}
+
+func tgUsername(ctx context.Context) string {
+	name, _ := ctx.Value(tgUsernameKey{}).(string)
+	return name
 }
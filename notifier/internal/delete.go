@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"app/notifier/internal/i18n"
+)
+
+// OnDelete спрашивает подтверждение перед удалением аккаунта.
+func (c *Core) OnDelete(ctx context.Context, chatID int64) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if _, err := c.users.GetByTelegramID(ctx, chatID); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("delete.nothing")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	s.State = stConfirmDelete
+	return Output{Text: i18n.M("delete.confirm"), Kind: ReplyDeleteConfirm}, nil
+}
+
+// onDeleteAction обрабатывает кнопки "delete:yes" и "delete:no".
+func (c *Core) onDeleteAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if s.State != stConfirmDelete {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+
+	switch arg {
+	case "no":
+		return c.cancelDelete(s), nil
+	case "yes":
+	default:
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			s.State = stDeleted
+			return Output{Text: i18n.M("delete.done"), Kind: ReplyRemoveKeyboard}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	// сначала лайки и совпадения: если упадёт удаление анкеты, повторный /delete всё доделает
+	if err := c.match.DeleteUser(ctx, me.GetId()); err != nil {
+		log.Printf("core: match DeleteUser(%d): %v", me.GetId(), err)
+		return Output{Text: i18n.M("delete.failed"), Kind: ReplyDeleteConfirm}, nil
+	}
+	if err := c.users.Delete(ctx, me.GetId()); err != nil {
+		log.Printf("core: Delete user %d: %v", me.GetId(), err)
+		return Output{Text: i18n.M("delete.failed"), Kind: ReplyDeleteConfirm}, nil
+	}
+
+	s.State = stDeleted
+	return Output{Text: i18n.M("delete.done"), Kind: ReplyRemoveKeyboard}, nil
+}
+
+func (c *Core) cancelDelete(s *session) Output {
+	s.State = stMenu
+	return Output{Text: withMenu("delete.cancelled"), Kind: ReplyMenu}
+}
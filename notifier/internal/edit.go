@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+)
+
+// Поля анкеты, которые можно изменить по отдельности.
+const (
+	fieldName   = "name"
+	fieldAge    = "age"
+	fieldCity   = "city"
+	fieldGender = "gender"
+	fieldDesc   = "desc"
+	fieldPhoto  = "photo"
+	fieldGeo    = "geo"
+)
+
+// editMenu показывает текущие значения анкеты и кнопки выбора поля.
+func (c *Core) editMenu(ctx context.Context, chatID int64, s *session, key string) (Output, error) {
+	u, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			s.State = stAskName
+			s.Draft = draftProfile{}
+			return Output{Text: i18n.M("profile.missing")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	s.State = stMenu
+	s.EditField = ""
+	return Output{
+		Text: i18n.M(key, i18n.M("edit.current",
+			u.GetUsername(), u.GetAge(), u.GetLocation(), geoLabel(u.GetGeo()), genderLabel(u.GetGender()), u.GetDescription())),
+		Kind: ReplyEdit,
+	}, nil
+}
+
+// onEditAction обрабатывает кнопки "edit:<field>", "edit:keep" и "edit:done".
+func (c *Core) onEditAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if s.State != stMenu && s.State != stEditField {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+
+	switch arg {
+	case "done":
+		s.State = stMenu
+		s.EditField = ""
+		return Output{Text: withMenu("menu.choose"), Kind: ReplyMenu}, nil
+	case "keep":
+		return c.editMenu(ctx, chatID, s, "edit.choose")
+	case fieldName, fieldAge, fieldCity, fieldGender, fieldDesc, fieldPhoto, fieldGeo:
+	default:
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	u, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	s.State = stEditField
+	s.EditField = arg
+
+	switch arg {
+	case fieldName:
+		return Output{Text: i18n.M("edit.ask.name", u.GetUsername()), Kind: ReplyEditField}, nil
+	case fieldAge:
+		return Output{Text: i18n.M("edit.ask.age", u.GetAge()), Kind: ReplyEditField}, nil
+	case fieldCity:
+		return Output{Text: i18n.M("edit.ask.city", u.GetLocation()), Kind: ReplyEditField}, nil
+	case fieldGeo:
+		return Output{Text: i18n.M("edit.ask.geo", geoLabel(u.GetGeo())), Kind: ReplyEditGeo}, nil
+	case fieldGender:
+		return Output{Text: i18n.M("edit.ask.gender", genderLabel(u.GetGender())), Kind: ReplyEditGender}, nil
+	case fieldDesc:
+		return Output{Text: i18n.M("edit.ask.desc", u.GetDescription()), Kind: ReplyEditField}, nil
+	default:
+		return c.photoManager(ctx, chatID, s, i18n.M("edit.ask.photo"))
+	}
+}
+
+// onEditText принимает новое значение выбранного поля.
+func (c *Core) onEditText(ctx context.Context, chatID int64, s *session, text string) (Output, error) {
+	text = strings.TrimSpace(text)
+	patch := &userpb.User{}
+
+	switch s.EditField {
+	case fieldName:
+		patch.Username = text
+	case fieldAge:
+		var age int32
+		if _, err := fmt.Sscanf(text, "%d", &age); err != nil || age <= 0 {
+			return Output{Text: i18n.M("ask.age.invalid"), Kind: ReplyEditField}, nil
+		}
+		patch.Age = age
+	case fieldCity:
+		patch.Location = text
+	case fieldGender:
+		switch {
+		case i18n.Matches(text, "gender.male"):
+			patch.Gender = genderMale
+		case i18n.Matches(text, "gender.female"):
+			patch.Gender = genderFemale
+		default:
+			return Output{Text: i18n.M("ask.gender.invalid"), Kind: ReplyEditGender}, nil
+		}
+	case fieldDesc:
+		patch.Description = text
+	case fieldPhoto:
+		return c.photoManager(ctx, chatID, s, i18n.M("edit.ask.photo"))
+	default:
+		// в том числе fieldGeo: геопозицию присылают кнопкой, любой текст — "оставить как есть"
+		return c.editMenu(ctx, chatID, s, "edit.choose")
+	}
+
+	if text == "" {
+		return Output{Text: i18n.M("edit.empty"), Kind: ReplyEditField}, nil
+	}
+	return c.applyEdit(ctx, chatID, s, patch)
+}
+
+// applyEdit отправляет в user service только изменённое поле.
+func (c *Core) applyEdit(ctx context.Context, chatID int64, s *session, patch *userpb.User) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	patch.Id = me.GetId()
+	if _, err := c.users.Update(ctx, patch); err != nil {
+		log.Printf("core: Update user: %v", err)
+		return Output{Text: i18n.M("profile.update_failed"), Kind: ReplyEditField}, nil
+	}
+	return c.editMenu(ctx, chatID, s, "edit.saved")
+}
+
+func genderLabel(g string) i18n.Msg {
+	switch g {
+	case genderMale:
+		return i18n.M("gender.male")
+	case genderFemale:
+		return i18n.M("gender.female")
+	default:
+		return i18n.M("gender.unknown")
+	}
+}
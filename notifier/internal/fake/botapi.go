@@ -0,0 +1,596 @@
+// Package fake — подделки внешних зависимостей notifier для сквозных тестов:
+// Telegram Bot API на httptest-сервере и in-memory user/match сервисы.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+// BotToken — токен, под которым бот ходит в BotAPI.
+const BotToken = "42:test-token"
+
+// Button — inline-кнопка отправленного сообщения.
+type Button struct {
+	Text string
+	Data string
+	URL  string
+}
+
+// Sent — сообщение, которое бот отправил в чат. Альбом — одно Sent с несколькими Photos.
+type Sent struct {
+	Method    string
+	ChatID    int64
+	MessageID int
+	// Text — текст сообщения или подпись к фото.
+	Text   string
+	Photos []string
+	// Media — голосовое или кружок (sendVoice, sendVideoNote): file_id или ссылка.
+	Media string
+	// Buttons — inline-клавиатура, Keyboard — обычная (reply) клавиатура.
+	Buttons        [][]Button
+	Keyboard       [][]string
+	RemoveKeyboard bool
+}
+
+// HasButton сообщает, есть ли у сообщения inline-кнопка с такими данными.
+func (s Sent) HasButton(data string) bool {
+	for _, row := range s.Buttons {
+		for _, b := range row {
+			if b.Data == data {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// User — пользователь Telegram, от имени которого приходят апдейты. Имя пользователя —
+// FirstName строчными буквами, если не задан NoUsername.
+type User struct {
+	ID         int64
+	FirstName  string
+	Lang       string
+	NoUsername bool
+}
+
+// BotAPI — поддельный Telegram Bot API. Записывает всё, что отправил бот,
+// и собирает апдейты так, как их прислал бы Telegram.
+type BotAPI struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	nextMsg   int
+	nextUpd   int
+	nextFile  int
+	sent      []Sent
+	unread    map[int64][]Sent
+	files     map[string][]byte
+	answered  []string
+	edited    []int
+	unhandled []string
+	blocked   map[int64]bool
+	private   map[int64]bool
+}
+
+func NewBotAPI() *BotAPI {
+	api := &BotAPI{
+		unread:  make(map[int64][]Sent),
+		files:   make(map[string][]byte),
+		blocked: make(map[int64]bool),
+		private: make(map[int64]bool),
+	}
+	api.srv = httptest.NewServer(http.HandlerFunc(api.serve))
+	return api
+}
+
+// URL — адрес для tb.Settings.URL.
+func (a *BotAPI) URL() string { return a.srv.URL }
+
+func (a *BotAPI) Close() { a.srv.Close() }
+
+// Take возвращает сообщения, отправленные в чат после предыдущего вызова Take.
+func (a *BotAPI) Take(chatID int64) []Sent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := a.unread[chatID]
+	delete(a.unread, chatID)
+	return out
+}
+
+// Answered — id callback-запросов, на которые бот ответил.
+func (a *BotAPI) Answered() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.answered...)
+}
+
+// Edited — id сообщений, у которых бот убрал или заменил клавиатуру.
+func (a *BotAPI) Edited() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]int(nil), a.edited...)
+}
+
+// SetBlocked имитирует пользователя, заблокировавшего бота: отправка в чат вернёт 403.
+func (a *BotAPI) SetBlocked(chatID int64, blocked bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.blocked[chatID] = blocked
+}
+
+// SetPrivate имитирует пользователя, запретившего ссылки на себя: сообщение с кнопкой
+// tg://user?id= на него Telegram отклоняет.
+func (a *BotAPI) SetPrivate(userID int64, private bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.private[userID] = private
+}
+
+// Unhandled — вызванные ботом методы, которые подделка не поддерживает.
+func (a *BotAPI) Unhandled() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.unhandled...)
+}
+
+// TextUpdate — пользователь отправил текст (или команду).
+func (a *BotAPI) TextUpdate(from User, text string) tb.Update {
+	a.mu.Lock()
+	msg := a.message(from)
+	a.mu.Unlock()
+
+	msg["text"] = text
+	if strings.HasPrefix(text, "/") {
+		cmd, _, _ := strings.Cut(text, " ")
+		msg["entities"] = []any{map[string]any{"type": "bot_command", "offset": 0, "length": len(cmd)}}
+	}
+	return a.update(map[string]any{"message": msg})
+}
+
+// PhotoUpdate — пользователь отправил фото; содержимое отдаётся через getFile.
+func (a *BotAPI) PhotoUpdate(from User, data []byte) tb.Update {
+	a.mu.Lock()
+	a.nextFile++
+	fileID := fmt.Sprintf("photo-%d", a.nextFile)
+	a.files[fileID] = data
+	msg := a.message(from)
+	a.mu.Unlock()
+
+	msg["photo"] = []any{map[string]any{
+		"file_id":        fileID,
+		"file_unique_id": "u" + fileID,
+		"width":          800,
+		"height":         600,
+		"file_size":      len(data),
+	}}
+	return a.update(map[string]any{"message": msg})
+}
+
+// VoiceUpdate — пользователь отправил голосовое длиной duration секунд.
+func (a *BotAPI) VoiceUpdate(from User, data []byte, duration int) tb.Update {
+	return a.mediaUpdate(from, "voice", data, map[string]any{"duration": duration, "mime_type": "audio/ogg"})
+}
+
+// VideoNoteUpdate — пользователь отправил видео-кружок длиной duration секунд.
+func (a *BotAPI) VideoNoteUpdate(from User, data []byte, duration int) tb.Update {
+	return a.mediaUpdate(from, "video_note", data, map[string]any{"duration": duration, "length": 240})
+}
+
+// mediaUpdate — сообщение с файлом вида kind; содержимое отдаётся через getFile.
+func (a *BotAPI) mediaUpdate(from User, kind string, data []byte, fields map[string]any) tb.Update {
+	a.mu.Lock()
+	a.nextFile++
+	fileID := fmt.Sprintf("%s-%d", kind, a.nextFile)
+	a.files[fileID] = data
+	msg := a.message(from)
+	a.mu.Unlock()
+
+	fields["file_id"] = fileID
+	fields["file_unique_id"] = "u" + fileID
+	fields["file_size"] = len(data)
+	msg[kind] = fields
+	return a.update(map[string]any{"message": msg})
+}
+
+// LocationUpdate — пользователь поделился геопозицией.
+func (a *BotAPI) LocationUpdate(from User, lat, lon float64) tb.Update {
+	a.mu.Lock()
+	msg := a.message(from)
+	a.mu.Unlock()
+
+	msg["location"] = map[string]any{"latitude": lat, "longitude": lon}
+	return a.update(map[string]any{"message": msg})
+}
+
+// CallbackUpdate — пользователь нажал inline-кнопку с данными data. Кнопка ищется
+// в последнем сообщении чата, где она есть; если её нет, это ошибка сценария.
+func (a *BotAPI) CallbackUpdate(from User, data string) (tb.Update, error) {
+	a.mu.Lock()
+	var src *Sent
+	for i := len(a.sent) - 1; i >= 0; i-- {
+		if a.sent[i].ChatID == from.ID && a.sent[i].HasButton(data) {
+			src = &a.sent[i]
+			break
+		}
+	}
+	if src == nil {
+		a.mu.Unlock()
+		return tb.Update{}, fmt.Errorf("fake: chat %d has no button %q", from.ID, data)
+	}
+	a.nextUpd++
+	cbID := "cb-" + strconv.Itoa(a.nextUpd)
+	msg := map[string]any{
+		"message_id":   src.MessageID,
+		"date":         time.Now().Unix(),
+		"chat":         map[string]any{"id": from.ID, "type": "private"},
+		"from":         map[string]any{"id": 42, "is_bot": true, "first_name": "DatingBot"},
+		"text":         src.Text,
+		"reply_markup": map[string]any{"inline_keyboard": inlineJSON(src.Buttons)},
+	}
+	a.mu.Unlock()
+
+	return a.update(map[string]any{"callback_query": map[string]any{
+		"id":            cbID,
+		"from":          userJSON(from),
+		"message":       msg,
+		"chat_instance": "ci",
+		"data":          data,
+	}}), nil
+}
+
+// message — заготовка входящего сообщения; вызывается под a.mu.
+func (a *BotAPI) message(from User) map[string]any {
+	a.nextMsg++
+	return map[string]any{
+		"message_id": a.nextMsg,
+		"date":       time.Now().Unix(),
+		"chat":       map[string]any{"id": from.ID, "type": "private", "first_name": from.FirstName},
+		"from":       userJSON(from),
+	}
+}
+
+// update прогоняет апдейт через JSON, как при доставке от Telegram.
+func (a *BotAPI) update(body map[string]any) tb.Update {
+	a.mu.Lock()
+	a.nextUpd++
+	body["update_id"] = a.nextUpd
+	a.mu.Unlock()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	var upd tb.Update
+	if err := json.Unmarshal(data, &upd); err != nil {
+		panic(err)
+	}
+	return upd
+}
+
+func userJSON(u User) map[string]any {
+	m := map[string]any{
+		"id":            u.ID,
+		"is_bot":        false,
+		"first_name":    u.FirstName,
+		"language_code": u.Lang,
+	}
+	if !u.NoUsername {
+		m["username"] = strings.ToLower(u.FirstName)
+	}
+	return m
+}
+
+func inlineJSON(rows [][]Button) [][]map[string]any {
+	out := make([][]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		r := make([]map[string]any, 0, len(row))
+		for _, b := range row {
+			btn := map[string]any{"text": b.Text}
+			if b.URL != "" {
+				btn["url"] = b.URL
+			} else {
+				btn["callback_data"] = b.Data
+			}
+			r = append(r, btn)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func (a *BotAPI) serve(w http.ResponseWriter, r *http.Request) {
+	if path, ok := strings.CutPrefix(r.URL.Path, "/file/bot"+BotToken+"/"); ok {
+		a.serveFile(w, path)
+		return
+	}
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+BotToken+"/")
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	params, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if strings.HasPrefix(method, "send") {
+		chatID, _ := strconv.ParseInt(params["chat_id"], 10, 64)
+		a.mu.Lock()
+		blocked := a.blocked[chatID]
+		a.mu.Unlock()
+		if blocked {
+			writeError(w, http.StatusForbidden, "Forbidden: bot was blocked by the user")
+			return
+		}
+	}
+
+	switch method {
+	case "getMe":
+		writeResult(w, map[string]any{"id": 42, "is_bot": true, "first_name": "DatingBot", "username": "dating_test_bot"})
+	case "deleteWebhook", "setWebhook", "setMyCommands":
+		writeResult(w, true)
+	case "answerCallbackQuery":
+		a.mu.Lock()
+		a.answered = append(a.answered, params["callback_query_id"])
+		a.mu.Unlock()
+		writeResult(w, true)
+	case "editMessageReplyMarkup":
+		id, _ := strconv.Atoi(params["message_id"])
+		chatID, _ := strconv.ParseInt(params["chat_id"], 10, 64)
+		buttons, _, _, err := parseMarkup(params["reply_markup"])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		a.mu.Lock()
+		a.edited = append(a.edited, id)
+		var msg *Sent
+		for i := range a.sent {
+			if a.sent[i].ChatID == chatID && a.sent[i].MessageID == id {
+				// старые кнопки исчезают, нажать их больше нельзя
+				a.sent[i].Buttons = buttons
+				msg = &a.sent[i]
+			}
+		}
+		var result map[string]any
+		if msg != nil {
+			result = messageJSON(*msg, 0)
+		}
+		a.mu.Unlock()
+		if result == nil {
+			writeError(w, http.StatusBadRequest, "Bad Request: message to edit not found")
+			return
+		}
+		writeResult(w, result)
+	case "getFile":
+		a.mu.Lock()
+		_, ok := a.files[params["file_id"]]
+		a.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Bad Request: invalid file_id")
+			return
+		}
+		writeResult(w, map[string]any{"file_id": params["file_id"], "file_path": "photos/" + params["file_id"] + ".jpg"})
+	case "sendMessage", "sendPhoto", "sendVoice", "sendVideoNote":
+		s, err := a.record(method, params)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeResult(w, messageJSON(s, 0))
+	case "sendMediaGroup":
+		s, err := a.record(method, params)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// Telegram возвращает по сообщению на каждый элемент альбома
+		msgs := make([]map[string]any, len(s.Photos))
+		for i := range s.Photos {
+			msgs[i] = messageJSON(s, i)
+		}
+		writeResult(w, msgs)
+	default:
+		a.mu.Lock()
+		a.unhandled = append(a.unhandled, method)
+		a.mu.Unlock()
+		writeError(w, http.StatusNotFound, "Not Found: method "+method+" is not supported by the fake")
+	}
+}
+
+func (a *BotAPI) serveFile(w http.ResponseWriter, path string) {
+	id := strings.TrimSuffix(strings.TrimPrefix(path, "photos/"), ".jpg")
+	a.mu.Lock()
+	data, ok := a.files[id]
+	a.mu.Unlock()
+	if !ok {
+		http.NotFound(w, nil)
+		return
+	}
+	w.Write(data)
+}
+
+// record сохраняет исходящее сообщение бота.
+func (a *BotAPI) record(method string, p map[string]string) (Sent, error) {
+	chatID, err := strconv.ParseInt(p["chat_id"], 10, 64)
+	if err != nil {
+		return Sent{}, fmt.Errorf("Bad Request: chat_id is empty")
+	}
+
+	s := Sent{Method: method, ChatID: chatID, Text: p["text"]}
+	switch method {
+	case "sendPhoto":
+		s.Text = p["caption"]
+		s.Photos = []string{p["photo"]}
+	case "sendVoice":
+		s.Text = p["caption"]
+		s.Media = p["voice"]
+	case "sendVideoNote":
+		s.Media = p["video_note"]
+	case "sendMediaGroup":
+		var media []struct {
+			Media   string `json:"media"`
+			Caption string `json:"caption"`
+		}
+		if err := json.Unmarshal([]byte(p["media"]), &media); err != nil {
+			return Sent{}, fmt.Errorf("Bad Request: can't parse media: %v", err)
+		}
+		for _, m := range media {
+			s.Photos = append(s.Photos, m.Media)
+			if m.Caption != "" {
+				s.Text = m.Caption
+			}
+		}
+	}
+	if s.Text == "" && len(s.Photos) == 0 && s.Media == "" {
+		return Sent{}, fmt.Errorf("Bad Request: message text is empty")
+	}
+
+	s.Buttons, s.Keyboard, s.RemoveKeyboard, err = parseMarkup(p["reply_markup"])
+	if err != nil {
+		return Sent{}, err
+	}
+
+	a.mu.Lock()
+	for _, row := range s.Buttons {
+		for _, b := range row {
+			id, ok := strings.CutPrefix(b.URL, "tg://user?id=")
+			if uid, _ := strconv.ParseInt(id, 10, 64); ok && a.private[uid] {
+				a.mu.Unlock()
+				return Sent{}, fmt.Errorf("Bad Request: BUTTON_USER_PRIVACY_RESTRICTED")
+			}
+		}
+	}
+	s.MessageID = a.nextMsg + 1
+	a.nextMsg += max(1, len(s.Photos))
+	a.sent = append(a.sent, s)
+	a.unread[chatID] = append(a.unread[chatID], s)
+	a.mu.Unlock()
+	return s, nil
+}
+
+// parseMarkup разбирает reply_markup в том виде, в каком его отправляет telebot.
+func parseMarkup(raw string) (buttons [][]Button, keyboard [][]string, remove bool, err error) {
+	if raw == "" {
+		return nil, nil, false, nil
+	}
+	var markup struct {
+		Inline [][]struct {
+			Text string `json:"text"`
+			Data string `json:"callback_data"`
+			URL  string `json:"url"`
+		} `json:"inline_keyboard"`
+		Keyboard [][]struct {
+			Text string `json:"text"`
+		} `json:"keyboard"`
+		Remove bool `json:"remove_keyboard"`
+	}
+	if err := json.Unmarshal([]byte(raw), &markup); err != nil {
+		return nil, nil, false, fmt.Errorf("Bad Request: can't parse reply markup: %v", err)
+	}
+	for _, row := range markup.Inline {
+		var r []Button
+		for _, b := range row {
+			r = append(r, Button{Text: b.Text, Data: b.Data, URL: b.URL})
+		}
+		buttons = append(buttons, r)
+	}
+	for _, row := range markup.Keyboard {
+		var r []string
+		for _, b := range row {
+			r = append(r, b.Text)
+		}
+		keyboard = append(keyboard, r)
+	}
+	return buttons, keyboard, markup.Remove, nil
+}
+
+func messageJSON(s Sent, photo int) map[string]any {
+	m := map[string]any{
+		"message_id": s.MessageID + photo,
+		"date":       time.Now().Unix(),
+		"chat":       map[string]any{"id": s.ChatID, "type": "private"},
+		"from":       map[string]any{"id": 42, "is_bot": true, "first_name": "DatingBot"},
+	}
+	if s.Media != "" {
+		kind := map[string]string{"sendVoice": "voice", "sendVideoNote": "video_note"}[s.Method]
+		m[kind] = map[string]any{
+			"file_id":        fmt.Sprintf("sent-%d", s.MessageID),
+			"file_unique_id": fmt.Sprintf("sent-u-%d", s.MessageID),
+			"duration":       1,
+		}
+		return m
+	}
+	if len(s.Photos) == 0 {
+		m["text"] = s.Text
+		return m
+	}
+	m["caption"] = s.Text
+	m["photo"] = []any{map[string]any{
+		"file_id":        fmt.Sprintf("sent-%d-%d", s.MessageID, photo),
+		"file_unique_id": fmt.Sprintf("sent-u-%d-%d", s.MessageID, photo),
+		"width":          800,
+		"height":         600,
+	}}
+	return m
+}
+
+// readParams читает параметры метода: telebot шлёт JSON, а при загрузке файлов — multipart.
+func readParams(r *http.Request) (map[string]string, error) {
+	params := make(map[string]string)
+
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct == "multipart/form-data" {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		for k, v := range r.MultipartForm.Value {
+			params[k] = v[0]
+		}
+		for k := range r.MultipartForm.File {
+			params[k] = "attach://" + k
+		}
+		return params, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		return params, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	for k, v := range raw {
+		var s string
+		if json.Unmarshal(v, &s) == nil {
+			params[k] = s
+		} else {
+			params[k] = string(v)
+		}
+	}
+	return params, nil
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"ok": false, "error_code": code, "description": description})
+}
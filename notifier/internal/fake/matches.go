@@ -0,0 +1,367 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	matchpb "app/match/proto"
+	userpb "app/user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Report — жалоба, отправленная через Matches.Report.
+type Report struct {
+	Reporter, Reported int64
+	Reason, Comment    string
+}
+
+type pair struct{ from, to int64 }
+
+type like struct {
+	isLike  bool
+	message string
+	at      time.Time
+}
+
+// cursor — докуда пользователь долистал выдачу при фильтре filter.
+type cursor struct {
+	filter string
+	after  int64
+}
+
+// swipe — последняя оценка пользователя и оценка, которую она перезаписала.
+type swipe struct {
+	to      int64
+	prev    like
+	hadPrev bool
+}
+
+// Matches — in-memory match service. Кандидатов берёт из Users: видимые анкеты
+// другого пола из того же города, которые пользователь ещё не оценивал.
+type Matches struct {
+	users *Users
+
+	// PageSize — размер страницы GetCandidates.
+	PageSize int
+
+	mu      sync.Mutex
+	likes   map[pair]like
+	last    map[int64]swipe
+	cursors map[int64]cursor
+	blocks  map[pair]bool
+	reports []Report
+}
+
+func NewMatches(users *Users) *Matches {
+	return &Matches{
+		users:    users,
+		PageSize: 20,
+		likes:    make(map[pair]like),
+		last:     make(map[int64]swipe),
+		cursors:  make(map[int64]cursor),
+		blocks:   make(map[pair]bool),
+	}
+}
+
+// Reports возвращает отправленные жалобы.
+func (f *Matches) Reports() []Report {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Report(nil), f.reports...)
+}
+
+// Rated сообщает, есть ли оценка fromUserID анкете toUserID.
+func (f *Matches) Rated(fromUserID, toUserID int64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.likes[pair{fromUserID, toUserID}]
+	return ok
+}
+
+// GetCandidates, как и match service, отдаёт выдачу страницами по PageSize и сам помнит,
+// докуда пользователь её долистал; выдача идёт по возрастанию id.
+func (f *Matches) GetCandidates(ctx context.Context, telegramID int64) ([]*matchpb.User, bool, error) {
+	me, err := f.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := filterKey(me)
+	cur := f.cursors[me.Id]
+	if cur.filter != key {
+		cur = cursor{filter: key}
+	}
+
+	var out []*matchpb.User
+	more := false
+	for _, u := range f.users.All() {
+		if u.Id <= cur.after || u.Id == me.Id || !u.IsVisible || !wanted(me, u) {
+			continue
+		}
+		if _, rated := f.likes[pair{me.Id, u.Id}]; rated || f.blocked(me.Id, u.Id) {
+			continue
+		}
+		if len(out) == f.PageSize {
+			more = true
+			break
+		}
+		out = append(out, &matchpb.User{
+			Id:          u.Id,
+			TelegramId:  u.TelegramId,
+			Username:    u.Username,
+			Age:         u.Age,
+			Gender:      u.Gender,
+			Location:    u.Location,
+			Description: u.Description,
+			PhotoUrl:    u.PhotoUrl,
+			IsVisible:   u.IsVisible,
+		})
+	}
+
+	// долистанная выдача не начинается заново: курсор остаётся на последней странице
+	if more {
+		cur.after = out[len(out)-1].Id
+	}
+	f.cursors[me.Id] = cur
+	return out, more, nil
+}
+
+func (f *Matches) Like(_ context.Context, fromUserID, toUserID int64, isLike bool, message string) error {
+	if !isLike {
+		message = ""
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := pair{fromUserID, toUserID}
+	prev, had := f.likes[p]
+	f.last[fromUserID] = swipe{to: toUserID, prev: prev, hadPrev: had}
+	f.likes[p] = like{isLike: isLike, message: message, at: time.Now()}
+	return nil
+}
+
+// UndoLike, как и match service, отменяет только последнюю оценку пользователя.
+func (f *Matches) UndoLike(_ context.Context, fromUserID, toUserID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.last[fromUserID]
+	if !ok || s.to != toUserID {
+		return status.Error(codes.FailedPrecondition, "nothing to undo")
+	}
+	delete(f.last, fromUserID)
+	if s.hadPrev {
+		f.likes[pair{fromUserID, toUserID}] = s.prev
+	} else {
+		delete(f.likes, pair{fromUserID, toUserID})
+	}
+	return nil
+}
+
+func (f *Matches) Match(_ context.Context, fromUserID, toUserID int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.mutual(fromUserID, toUserID), nil
+}
+
+func (f *Matches) HasLike(_ context.Context, fromUserID, toUserID int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.likes[pair{fromUserID, toUserID}].isLike && !f.blocked(fromUserID, toUserID), nil
+}
+
+func (f *Matches) DeleteUser(_ context.Context, userID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for p := range f.likes {
+		if p.from == userID || p.to == userID {
+			delete(f.likes, p)
+		}
+	}
+	delete(f.last, userID)
+	return nil
+}
+
+func (f *Matches) ListIncomingLikes(_ context.Context, userID int64, limit int32) ([]*matchpb.IncomingLike, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []*matchpb.IncomingLike
+	for p, l := range f.likes {
+		if p.to != userID || !l.isLike || f.blocked(p.from, p.to) {
+			continue
+		}
+		if _, answered := f.likes[pair{userID, p.from}]; answered {
+			continue
+		}
+		out = append(out, &matchpb.IncomingLike{FromUser: p.from, CreatedAt: l.at.Format(time.RFC3339), Message: l.message})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].FromUser < out[j].FromUser })
+	if limit > 0 && len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// ListMatches отдаёт совпадения по возрастанию id; курсор — смещение следующей страницы.
+func (f *Matches) ListMatches(_ context.Context, userID int64, cursor string, limit int32) ([]*matchpb.MatchedUser, string, error) {
+	offset := 0
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 {
+			return nil, "", errors.New("invalid cursor")
+		}
+		offset = n
+	}
+
+	f.mu.Lock()
+	var all []*matchpb.MatchedUser
+	for p, l := range f.likes {
+		if p.from == userID && f.mutual(p.from, p.to) {
+			all = append(all, &matchpb.MatchedUser{UserId: p.to, MatchedAt: l.at.Format(time.RFC3339)})
+		}
+	}
+	f.mu.Unlock()
+	sort.Slice(all, func(i, j int) bool { return all[i].UserId < all[j].UserId })
+
+	if offset >= len(all) {
+		return nil, "", nil
+	}
+	end := len(all)
+	if limit > 0 && offset+int(limit) < end {
+		end = offset + int(limit)
+	}
+	next := ""
+	if end < len(all) {
+		next = strconv.Itoa(end)
+	}
+	return all[offset:end], next, nil
+}
+
+// Unmatch, как и match service, меняет только эту оценку: последнюю оценку пользователя
+// по-прежнему можно отменить, а сам разрыв — нет.
+func (f *Matches) Unmatch(_ context.Context, userID, otherUserID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := pair{userID, otherUserID}
+	if _, ok := f.likes[p]; !ok {
+		return nil
+	}
+	f.likes[p] = like{isLike: false, at: time.Now()}
+	if f.last[userID].to == otherUserID {
+		delete(f.last, userID)
+	}
+	return nil
+}
+
+func (f *Matches) Block(_ context.Context, userID, blockedUserID int64) error {
+	if userID == blockedUserID {
+		return errors.New("cannot block or report yourself")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocks[pair{userID, blockedUserID}] = true
+	return nil
+}
+
+func (f *Matches) Report(ctx context.Context, reporterID, reportedUserID int64, reason, comment string) error {
+	if err := f.Block(ctx, reporterID, reportedUserID); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reports = append(f.reports, Report{Reporter: reporterID, Reported: reportedUserID, Reason: reason, Comment: comment})
+	return nil
+}
+
+// GetStats считает совпадение по второму лайку пары; все жалобы считаются открытыми.
+func (f *Matches) GetStats(_ context.Context, since time.Time) (*matchpb.GetStatsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	st := &matchpb.GetStatsResponse{OpenReports: int64(len(f.reports))}
+	for p, l := range f.likes {
+		if l.isLike && !l.at.Before(since) {
+			st.Likes++
+		}
+		if p.from < p.to && f.mutual(p.from, p.to) {
+			back := f.likes[pair{p.to, p.from}]
+			if !l.at.Before(since) || !back.at.Before(since) {
+				st.Matches++
+			}
+		}
+	}
+	return st, nil
+}
+
+func (f *Matches) GetUserStats(_ context.Context, userID int64) (*matchpb.GetUserStatsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	st := &matchpb.GetUserStatsResponse{}
+	for p, l := range f.likes {
+		if !l.isLike {
+			continue
+		}
+		switch userID {
+		case p.from:
+			st.LikesGiven++
+			if f.mutual(p.from, p.to) {
+				st.Matches++
+			}
+		case p.to:
+			st.LikesReceived++
+		}
+	}
+	for _, r := range f.reports {
+		if r.Reported == userID {
+			st.ReportsReceived++
+		}
+	}
+	return st, nil
+}
+
+// mutual и blocked вызываются под f.mu.
+func (f *Matches) mutual(a, b int64) bool {
+	return f.likes[pair{a, b}].isLike && f.likes[pair{b, a}].isLike && !f.blocked(a, b)
+}
+
+func (f *Matches) blocked(a, b int64) bool {
+	return f.blocks[pair{a, b}] || f.blocks[pair{b, a}]
+}
+
+// filterKey — отпечаток настроек поиска: при их смене выдача начинается заново.
+func filterKey(me *userpb.User) string {
+	p := me.GetSearchPrefs()
+	return fmt.Sprintf("%s|%d-%d|%t|%s", p.GetGender(), p.GetMinAge(), p.GetMaxAge(), p.GetAnyCity(), me.Location)
+}
+
+// wanted упрощённо повторяет фильтр match service: пол и возраст из настроек поиска
+// (по умолчанию — противоположный пол и любой возраст) и тот же город, если не выбран "любой город".
+func wanted(me, u *userpb.User) bool {
+	p := me.GetSearchPrefs()
+	switch p.GetGender() {
+	case "":
+		if u.Gender == me.Gender {
+			return false
+		}
+	case "any":
+	default:
+		if u.Gender != p.GetGender() {
+			return false
+		}
+	}
+	if p.GetMinAge() > 0 && u.Age < p.GetMinAge() || p.GetMaxAge() > 0 && u.Age > p.GetMaxAge() {
+		return false
+	}
+	return p.GetAnyCity() || u.Location == me.Location
+}
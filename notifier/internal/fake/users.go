@@ -0,0 +1,421 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	userpb "app/user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrUserNotFound повторяет текст ошибки user service, на который опирается Core.
+var ErrUserNotFound = errors.New("user not found")
+
+const maxPhotos = 5
+
+// Users — in-memory user service. Возвращает копии, чтобы тест не менял хранилище в обход методов.
+type Users struct {
+	mu        sync.Mutex
+	nextID    int64
+	nextPhoto int64
+	byID      map[int64]*userpb.User
+	created   map[int64]time.Time
+}
+
+func NewUsers() *Users {
+	return &Users{byID: make(map[int64]*userpb.User), created: make(map[int64]time.Time)}
+}
+
+// Put сохраняет готовую анкету (например, второго участника сценария) и возвращает её с id.
+// Каждая строка photos становится фото анкеты, первая — основным.
+func (f *Users) Put(u *userpb.User, photos ...string) *userpb.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u = proto.Clone(u).(*userpb.User)
+	f.nextID++
+	u.Id = f.nextID
+	u.Photos = nil
+	for _, url := range photos {
+		f.nextPhoto++
+		u.Photos = append(u.Photos, &userpb.Photo{Id: f.nextPhoto, Url: url})
+	}
+	normalize(u)
+	f.byID[u.Id] = u
+	f.created[u.Id] = time.Now()
+	return proto.Clone(u).(*userpb.User)
+}
+
+// All возвращает анкеты, отсортированные по id.
+func (f *Users) All() []*userpb.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]*userpb.User, 0, len(f.byID))
+	for _, u := range f.byID {
+		out = append(out, proto.Clone(u).(*userpb.User))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out
+}
+
+func (f *Users) GetByID(_ context.Context, id int64) (*userpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.byID[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return proto.Clone(u).(*userpb.User), nil
+}
+
+func (f *Users) GetByTelegramID(_ context.Context, telegramID int64) (*userpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.byID {
+		if u.TelegramId == telegramID {
+			return proto.Clone(u).(*userpb.User), nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (f *Users) Create(_ context.Context, u *userpb.User) (*userpb.User, error) {
+	f.mu.Lock()
+	for _, existing := range f.byID {
+		if existing.TelegramId == u.GetTelegramId() {
+			f.mu.Unlock()
+			return nil, fmt.Errorf("telegram id %d is already registered", u.GetTelegramId())
+		}
+	}
+	f.mu.Unlock()
+	return f.Put(u), nil
+}
+
+// Update, как и user service, меняет только непустые поля.
+func (f *Users) Update(_ context.Context, patch *userpb.User) (*userpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[patch.GetId()]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	if patch.Username != "" {
+		u.Username = patch.Username
+	}
+	if patch.Age != 0 {
+		u.Age = patch.Age
+	}
+	if patch.Gender != "" {
+		u.Gender = patch.Gender
+	}
+	if patch.Location != "" {
+		u.Location = patch.Location
+	}
+	if patch.Description != "" {
+		u.Description = patch.Description
+	}
+	if patch.Timezone != "" {
+		u.Timezone = patch.Timezone
+	}
+	if patch.Geo != nil {
+		u.Geo = proto.Clone(patch.Geo).(*userpb.GeoPoint)
+	}
+	if patch.TgUsername != "" {
+		u.TgUsername = patch.TgUsername
+	}
+	return proto.Clone(u).(*userpb.User), nil
+}
+
+// UpdatePhoto делает фото основным; лишние фото сверх лимита отбрасываются.
+func (f *Users) UpdatePhoto(ctx context.Context, userID int64, photo io.Reader) (*userpb.User, error) {
+	if _, err := f.addPhoto(userID, photo, true); err != nil {
+		return nil, err
+	}
+	return f.GetByID(ctx, userID)
+}
+
+func (f *Users) AddPhoto(_ context.Context, userID int64, photo io.Reader) ([]*userpb.Photo, error) {
+	return f.addPhoto(userID, photo, false)
+}
+
+func (f *Users) addPhoto(userID int64, photo io.Reader, primary bool) ([]*userpb.Photo, error) {
+	data, err := io.ReadAll(photo)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	if !primary && len(u.Photos) >= maxPhotos {
+		return nil, errors.New("too many photos")
+	}
+
+	f.nextPhoto++
+	p := &userpb.Photo{Id: f.nextPhoto, Url: fmt.Sprintf("https://photos.test/users/%d/%d.jpg", userID, f.nextPhoto)}
+	if primary {
+		u.Photos = append([]*userpb.Photo{p}, u.Photos...)
+		if len(u.Photos) > maxPhotos {
+			u.Photos = u.Photos[:maxPhotos]
+		}
+	} else {
+		u.Photos = append(u.Photos, p)
+	}
+	normalize(u)
+	return clonePhotos(u.Photos), nil
+}
+
+func (f *Users) RemovePhoto(_ context.Context, userID, photoID int64) ([]*userpb.Photo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	for i, p := range u.Photos {
+		if p.Id == photoID {
+			u.Photos = append(u.Photos[:i], u.Photos[i+1:]...)
+			normalize(u)
+			return clonePhotos(u.Photos), nil
+		}
+	}
+	return nil, errors.New("photo not found")
+}
+
+func (f *Users) ReorderPhotos(_ context.Context, userID int64, photoIDs []int64) ([]*userpb.Photo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	if len(photoIDs) != len(u.Photos) {
+		return nil, errors.New("photo order must list every photo exactly once")
+	}
+	byID := make(map[int64]*userpb.Photo, len(u.Photos))
+	for _, p := range u.Photos {
+		byID[p.Id] = p
+	}
+	ordered := make([]*userpb.Photo, 0, len(photoIDs))
+	for _, id := range photoIDs {
+		p, ok := byID[id]
+		if !ok {
+			return nil, errors.New("photo order must list every photo exactly once")
+		}
+		delete(byID, id)
+		ordered = append(ordered, p)
+	}
+	u.Photos = ordered
+	normalize(u)
+	return clonePhotos(u.Photos), nil
+}
+
+func (f *Users) ToggleVisibility(_ context.Context, userID int64, isVisible bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	u.IsVisible = isVisible && !u.IsBanned
+	return nil
+}
+
+func (f *Users) Delete(_ context.Context, userID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.byID, userID)
+	delete(f.created, userID)
+	return nil
+}
+
+// SetBanned, как и user service, при блокировке скрывает анкету.
+func (f *Users) SetBanned(_ context.Context, userID int64, banned bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	u.IsBanned = banned
+	if banned {
+		u.IsVisible = false
+	}
+	return nil
+}
+
+func (f *Users) SetBotBlocked(_ context.Context, userID int64, blocked bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	u.BotBlocked = blocked
+	return nil
+}
+
+func (f *Users) UpdateSearchPrefs(_ context.Context, userID int64, prefs *userpb.SearchPrefs) (*userpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	u.SearchPrefs = proto.Clone(prefs).(*userpb.SearchPrefs)
+	return proto.Clone(u).(*userpb.User), nil
+}
+
+func (f *Users) MarkActivated(_ context.Context, userID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	u.Activated = true
+	return nil
+}
+
+// GetCampaignStats, как и user service, сортирует кампании по числу регистраций.
+func (f *Users) GetCampaignStats(_ context.Context, since time.Time) ([]*userpb.CampaignStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	byName := make(map[string]*userpb.CampaignStats)
+	for id, u := range f.byID {
+		if f.created[id].Before(since) {
+			continue
+		}
+		st, ok := byName[u.Campaign]
+		if !ok {
+			st = &userpb.CampaignStats{Campaign: u.Campaign}
+			byName[u.Campaign] = st
+		}
+		st.Registered++
+		if u.Activated {
+			st.Activated++
+		}
+	}
+
+	out := make([]*userpb.CampaignStats, 0, len(byName))
+	for _, st := range byName {
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Registered != out[j].Registered {
+			return out[i].Registered > out[j].Registered
+		}
+		return out[i].Campaign < out[j].Campaign
+	})
+	return out, nil
+}
+
+// SetIntro проверяет интро так же, как user service: вид, длительность до минуты, размер до 3 МБ.
+func (f *Users) SetIntro(_ context.Context, userID int64, intro *userpb.Intro, file io.Reader) (*userpb.User, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	kind := intro.GetKind()
+	if (kind != "voice" && kind != "video_note") || len(data) == 0 ||
+		intro.GetDuration() < 0 || intro.GetDuration() > 60 || len(data) > 3<<20 {
+		return nil, status.Error(codes.InvalidArgument, "invalid intro")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.byID[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	u.Intro = &userpb.Intro{
+		Kind:     kind,
+		Url:      fmt.Sprintf("https://media.test/users/%d/intro-%s", userID, kind),
+		Duration: intro.GetDuration(),
+		FileId:   intro.GetFileId(),
+	}
+	return proto.Clone(u).(*userpb.User), nil
+}
+
+// ListUsers, как и user service, пропускает заблокированных и заблокировавших бота.
+func (f *Users) ListUsers(_ context.Context, afterID int64, limit int32) ([]*userpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []*userpb.User
+	for _, u := range f.byID {
+		if u.Id > afterID && !u.IsBanned && !u.BotBlocked {
+			out = append(out, proto.Clone(u).(*userpb.User))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	if limit > 0 && len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *Users) GetStats(_ context.Context, since time.Time) (*userpb.GetStatsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	st := &userpb.GetStatsResponse{Total: int64(len(f.byID))}
+	for id, u := range f.byID {
+		if !f.created[id].Before(since) {
+			st.Registered++
+		}
+		if u.IsVisible && !u.IsBanned {
+			st.Visible++
+		}
+		if u.IsBanned {
+			st.Banned++
+		}
+	}
+	return st, nil
+}
+
+// normalize проставляет позиции, основное фото и photo_url, как это делает user service.
+func normalize(u *userpb.User) {
+	u.PhotoUrl = ""
+	for i, p := range u.Photos {
+		p.Position = int32(i)
+		p.IsPrimary = i == 0
+	}
+	if len(u.Photos) > 0 {
+		u.PhotoUrl = u.Photos[0].Url
+	}
+}
+
+func clonePhotos(photos []*userpb.Photo) []*userpb.Photo {
+	out := make([]*userpb.Photo, len(photos))
+	for i, p := range photos {
+		out[i] = proto.Clone(p).(*userpb.Photo)
+	}
+	return out
+}
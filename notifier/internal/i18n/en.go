@@ -0,0 +1,234 @@
+package i18n
+
+var en = map[string]string{
+	"lang.name":    "🇬🇧 English",
+	"lang.choose":  "Choose your language:",
+	"lang.changed": "Done, I speak English now 🇬🇧",
+
+	"error.unavailable": "The service is unavailable. Please try again later.",
+	"error.generic":     "Something went wrong. Please try again.",
+	"error.text":        "I didn't get that. Please try again.",
+	"error.action":      "The action failed. Please try again.",
+
+	"ratelimit.slow": "Too fast 🙂 Wait a couple of seconds and try again.",
+
+	"start.new":  "Hi! Let's create your profile.\nWhat's your name?",
+	"start.over": "Let's start over. What's your name?",
+
+	"menu.items":  "1. Browse profiles 🚀\n2. My profile 📱\n3. Edit profile ✏️\n4. Hide / show profile ⏸\n5. Who liked me 💌\n6. My matches 💞\n7. Search settings 🔎",
+	"menu.choose": "Choose an action:\n%s",
+	"menu.hint":   "Choose a menu item: 1 (browse), 2 (my profile), 3 (edit), 4 (hide / show), 5 (who liked me), 6 (matches), 7 (search settings).",
+
+	"pause.done":        "Your profile is hidden ⏸ Nobody will see you in search. To bring it back: /resume\n%s",
+	"pause.already":     "Your profile is already hidden. To bring it back: /resume\n%s",
+	"resume.done":       "Your profile is visible in search again ▶️\n%s",
+	"resume.already":    "Your profile is already visible in search.\n%s",
+	"delete.confirm":    "Delete your account? Your profile, photos, likes and matches will be removed for good.",
+	"delete.done":       "Your account has been deleted. If you want to come back, send /start",
+	"delete.failed":     "Couldn't delete your account. Please try again.",
+	"delete.cancelled":  "Deletion cancelled.\n%s",
+	"delete.nothing":    "You don't have a profile, so there's nothing to delete.",
+	"visibility.failed": "Couldn't change your profile visibility. Please try again later.\n%s",
+
+	"ask.age":            "How old are you?",
+	"ask.age.invalid":    "Age must be a number. Please enter a valid age.",
+	"ask.city":           "Where do you live? Enter your city. You can also share your location so we show people nearby.",
+	"ask.gender":         "Choose your gender:",
+	"ask.gender.invalid": "Please choose your gender with a button.",
+	"ask.desc":           "Describe yourself briefly (interests, who you are looking for).",
+	"ask.photo":          "Send photos for your profile — up to %d. The first one becomes the main photo.",
+
+	"location.saved":      "📍 Location saved. Now type your city name — it is shown on your profile.",
+	"location.invalid":    "Couldn't read that location. Please try again.",
+	"location.unexpected": "No location is needed right now. Use the menu.",
+	"geo.set":             "shared",
+	"geo.unset":           "not shared",
+	"btn.share_location":  "📍 Share location",
+
+	"gender.male":    "Guy",
+	"gender.female":  "Girl",
+	"gender.unknown": "not set",
+
+	"photo.unexpected":  "No photo is needed right now. Use the menu.",
+	"photo.not_image":   "Please send the photo as an image, not as a file.",
+	"photo.get_failed":  "Couldn't get the photo, please try again.",
+	"photo.read_failed": "Couldn't read the photo, please try again.",
+	"photo.too_big":     "The photo is too large. Send a smaller file (up to %dMB).",
+	"photo.failed":      "Couldn't process the photo, please try again.",
+	"photo.more":        "Photo added (%d of %d). Send another one or tap “Done”.",
+	"photo.limit":       "Your profile already has %d photos — delete one to add a new one.",
+	"photo.added":       "Photo added ✅",
+	"photo.removed":     "Photo deleted.",
+	"photo.main_set":    "Main photo updated ✅",
+	"photo.last":        "This is your only photo, so it can't be deleted. Add another one first.",
+
+	"profile.saved":          "Profile saved! What's next?\n%s",
+	"profile.save_failed":    "Couldn't save the profile. Please try again.",
+	"profile.update_failed":  "Couldn't update the profile. Please try again.",
+	"profile.missing":        "Looks like you don't have a profile yet. Let's create one! What's your name?",
+	"profile.not_found":      "Profile not found. Let's create one! What's your name?",
+	"profile.mine":           "Your profile:\n%s\n\n%s",
+	"profile.status.visible": "👁 Your profile is visible in search. Hide it: /pause",
+	"profile.status.hidden":  "⏸ Your profile is hidden from search. Show it: /resume",
+	"register.first":         "Create your profile first: /start",
+
+	"edit.choose":     "What do you want to change?\n\n%s",
+	"edit.saved":      "Saved ✅\n\n%s",
+	"edit.current":    "Name: %s\nAge: %d\nCity: %s\nLocation: %s\nGender: %s\nAbout: %s",
+	"edit.ask.name":   "Now: %s\nEnter a new name.",
+	"edit.ask.age":    "Now: %d\nEnter a new age.",
+	"edit.ask.city":   "Now: %s\nEnter a new city.",
+	"edit.ask.geo":    "Location now: %s\nShare a new one to get matches sorted by distance.",
+	"edit.ask.gender": "Now: %s\nChoose your gender.",
+	"edit.ask.desc":   "Now: %s\nWrite a new description.",
+	"edit.ask.photo":  "Send a photo to add it to your profile.",
+	"edit.photos":     "%s\n\nPhotos: %d of %d.\n⭐ — make main, 🗑 — delete.",
+	"edit.empty":      "The value can't be empty.",
+
+	"card":                   "%s",
+	"card.message":           "%s\n\n💌 “%s”",
+	"candidate.unavailable":  "Couldn't load this profile. Trying the next one…",
+	"browse.hint":            "Use the buttons under the profile: ❤️ / 💌 / 👎 / 💤 / 🚩",
+	"browse.fetch_failed":    "Couldn't load profiles. Please try again later.",
+	"browse.empty":           "No matching profiles yet.\nWhat's next?\n%s",
+	"browse.finished":        "You've seen all profiles. Back to the menu.\nWhat's next?\n%s",
+	"browse.no_more":         "No more profiles.\nWhat's next?\n%s",
+	"browse.sleep":           "Ok, back to the menu.\n%s",
+	"matches.item":           "💞 %s",
+	"matches.more":           "Show more matches?",
+	"matches.end":            "That's all your matches.\n%s",
+	"matches.empty":          "No matches yet — like some profiles and they'll show up!\n%s",
+	"matches.fetch_failed":   "Couldn't load your matches. Please try again later.",
+	"matches.unmatched":      "Match removed.",
+	"matches.unmatch_failed": "Couldn't remove the match. Please try again.",
+	"report.choose":          "What's wrong with this profile?",
+	"report.reason.spam":     "Spam or advertising",
+	"report.reason.fake":     "Fake profile",
+	"report.reason.abuse":    "Abusive behaviour",
+	"report.reason.underage": "Underage",
+	"report.reason.other":    "Something else",
+	"report.comment":         "Describe the problem in one message or tap “Skip”.",
+	"report.sent":            "Thanks, your report has been sent to the moderators. You won't see this profile again.",
+	"report.blocked":         "User blocked 🚫",
+	"report.cancelled":       "Report cancelled.",
+	"report.failed":          "Couldn't send it. Please try again.",
+	"inbox.empty":            "No new likes yet.\n%s",
+	"inbox.finished":         "That's everyone who liked you.\n%s",
+	"inbox.fetch_failed":     "Couldn't load your likes. Please try again later.",
+	"browse.hidden":          "Your profile is hidden right now. Show it again and start browsing?",
+	"browse.stale":           "This profile is no longer current. Use the buttons under the latest profile.",
+
+	"action.unavailable": "This action is not available now. Use the menu.",
+	"action.unknown":     "Unknown action.",
+
+	"like.received":    "Someone likes you 😉\nSee who it is!",
+	"like.quota":       "You've used up today's likes 💔 New ones in %d h %d min. You can still skip profiles.",
+	"like.quota.later": "You've used up today's likes 💔 They refresh at midnight. You can still skip profiles.",
+
+	"like.received.message":  "Someone likes you 😉 They wrote:\n💌 “%s”\nSee who it is!",
+	"like.message.ask":       "Write a message to go with your like (up to %d characters).",
+	"like.message.too_long":  "That's too long — keep it under %d characters.",
+	"like.message.cancelled": "OK, no message. Rate the profile with the buttons.",
+
+	"timezone.current": "Your timezone: %s. Likes refresh at midnight in it. To change: /timezone Europe/Berlin",
+	"timezone.unset":   "Your timezone isn't set, so likes refresh at midnight server time. To set it: /timezone Europe/Berlin",
+	"timezone.saved":   "Timezone saved: %s ✅",
+	"timezone.invalid": "Unknown timezone “%s”. Use a name like Europe/Berlin or Asia/Almaty.",
+	"liker.card":       "You were liked by:\n%s",
+	"liker.failed":     "Couldn't load the profile. Please try again later.",
+	"match.card":       "🎉 It's a match!\n\n%s",
+	"card.actions":     "Choose an action:",
+
+	"account.banned": "Your access to the bot has been restricted by an administrator.",
+
+	"admin.usage":          "Specify a Telegram ID: %s 123456789",
+	"admin.not_found":      "No user with Telegram ID %d.",
+	"admin.stats":          "📊 Stats for %s\nRegistrations: %d\nLikes: %d\nMatches: %d\n\nProfiles: %d, visible in search: %d, banned: %d\nOpen reports: %d",
+	"admin.user":           "Profile #%d, Telegram ID %d:\n%s\n\n%s\nLikes given: %d, received: %d\nMatches: %d\nReports against: %d",
+	"admin.status.visible": "👁 Visible in search",
+	"admin.status.hidden":  "⏸ Hidden",
+	"admin.status.banned":  "🚫 Banned",
+	"admin.banned":         "🚫 %s (%d) is banned: the profile is hidden and the bot no longer responds to them.",
+	"admin.unbanned":       "✅ %s (%d) is unbanned. The profile stays hidden until they show it again.",
+	"admin.ban.admin":      "Administrators can't be banned.",
+
+	"broadcast.usage":         "Broadcast text: /broadcast Hello everyone!",
+	"broadcast.disabled":      "Broadcasts are disabled.",
+	"broadcast.started":       "Broadcast %s started. Progress: /broadcast_status",
+	"broadcast.active":        "Broadcast %s isn't finished yet. Progress: /broadcast_status",
+	"broadcast.none":          "There have been no broadcasts yet.",
+	"broadcast.not_running":   "Nothing is being broadcast right now.",
+	"broadcast.not_paused":    "The broadcast isn't paused.",
+	"broadcast.status":        "Broadcast %s: %s\nDelivered: %d, blocked the bot: %d, failed: %d",
+	"broadcast.paused":        "⏸ Broadcast %s: %s\nDelivered: %d, blocked the bot: %d, failed: %d\nContinue: /broadcast_resume",
+	"broadcast.resumed":       "▶️ Broadcast %s: %s\nDelivered: %d, blocked the bot: %d, failed: %d",
+	"broadcast.state.running": "running",
+	"broadcast.state.paused":  "paused",
+	"broadcast.state.done":    "finished",
+
+	"search.choose":         "🔎 Search settings\n\n%s",
+	"search.saved":          "Saved ✅\n\n%s",
+	"search.current":        "Showing: %s\nAge: %s\nWhere: %s",
+	"search.failed":         "Couldn't save the settings. Please try again later.",
+	"search.ask.gender":     "Whose profiles should I show?",
+	"search.ask.age":        "Send an age range, e.g. 25-35 (from %d to %d).",
+	"search.age.invalid":    "I didn't get that range. Send two numbers from %d to %d, e.g. 25-35.",
+	"search.ask.radius":     "Where to search? The radius works once you share your location (3 → 📍 Location); otherwise I search in your city.",
+	"search.gender.default": "the opposite gender",
+	"search.gender.male":    "men",
+	"search.gender.female":  "women",
+	"search.gender.any":     "everyone",
+	"search.age.default":    "±3 years from yours",
+	"search.age.range":      "%d–%d",
+	"search.where.city":     "%s",
+	"search.where.near":     "near me",
+	"search.where.km":       "within %d km",
+	"search.where.any":      "any city",
+	"btn.search.gender":     "Who",
+	"btn.search.age":        "Age",
+	"btn.search.radius":     "Where",
+	"btn.seek.male":         "Men",
+	"btn.seek.female":       "Women",
+	"btn.seek.any":          "Everyone",
+	"btn.seek.default":      "Default",
+	"btn.km":                "%d km",
+	"btn.any_city":          "🌍 Any city",
+
+	"admin.campaigns":        "📈 Registration sources for the last %d days:\n%s",
+	"admin.campaigns.empty":  "No registrations in the last %d days.",
+	"admin.campaigns.usage":  "Specify the number of days from 1 to %d: /campaigns 7",
+	"admin.campaign.line":    "%s: %d registered, %d active (%d%%)",
+	"admin.campaign.organic": "no tag",
+
+	"undo.unavailable": "Only the latest rating can be undone.",
+	"undo.failed":      "Couldn't undo the rating, please try again.",
+
+	"ask.intro":        "Last step: record a voice message or a video note about yourself — up to %d seconds. It will be shown with your profile. You can skip this.",
+	"intro.unexpected": "No voice message or video note is needed right now. Use the menu.",
+	"intro.invalid":    "The intro must be at most %d seconds long and %d MB in size. Record a shorter one or skip.",
+	"intro.get_failed": "Couldn't get the file from Telegram, please try again.",
+	"intro.failed":     "Couldn't save the intro, try again or skip.",
+
+	"btn.view_profile": "👀 View profile",
+	"btn.write":        "💬 Message",
+	"btn.edit.name":    "Name",
+	"btn.edit.age":     "Age",
+	"btn.edit.city":    "City",
+	"btn.edit.gender":  "Gender",
+	"btn.edit.desc":    "About",
+	"btn.edit.photo":   "📷 Photo",
+	"btn.edit.geo":     "📍 Location",
+	"btn.keep":         "Keep as is",
+	"btn.done":         "✅ Done",
+	"btn.resume":       "▶️ Show and browse",
+	"btn.not_now":      "Not now",
+	"btn.delete":       "🗑 Yes, delete forever",
+	"btn.cancel":       "Cancel",
+	"btn.unmatch":      "💔 Unmatch",
+	"btn.more":         "More ▶️",
+	"btn.block":        "🚫 Just block",
+	"btn.skip":         "Skip",
+	"btn.back":         "⬅️ Back",
+	"btn.photo.main":   "⭐ %d",
+	"btn.photo.del":    "🗑 %d",
+}
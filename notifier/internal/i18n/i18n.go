@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Default — язык, на котором говорит бот, если язык пользователя неизвестен.
+const Default = "ru"
+
+// Supported — языки, для которых есть каталог, в порядке показа пользователю.
+var Supported = []string{"ru", "en"}
+
+var catalogs = map[string]map[string]string{
+	"ru": ru,
+	"en": en,
+}
+
+// Msg — ключ сообщения с параметрами. Параметр-Msg рендерится на том же языке.
+type Msg struct {
+	Key  string
+	Args []any
+}
+
+func M(key string, args ...any) Msg {
+	return Msg{Key: key, Args: args}
+}
+
+func (m Msg) IsZero() bool {
+	return m.Key == ""
+}
+
+// T возвращает текст по ключу на языке lang, при отсутствии перевода — на языке по умолчанию.
+func T(lang, key string, args ...any) string {
+	tpl, ok := catalogs[lang][key]
+	if !ok {
+		if tpl, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return tpl
+	}
+	rendered := make([]any, len(args))
+	for i, a := range args {
+		if m, ok := a.(Msg); ok {
+			rendered[i] = Render(lang, m)
+		} else {
+			rendered[i] = a
+		}
+	}
+	return fmt.Sprintf(tpl, rendered...)
+}
+
+func Render(lang string, m Msg) string {
+	if m.IsZero() {
+		return ""
+	}
+	return T(lang, m.Key, m.Args...)
+}
+
+// Resolve приводит language_code из Telegram ("en-US", "ru") к поддерживаемому языку.
+func Resolve(code string) string {
+	if code == "" {
+		return Default
+	}
+	base, _, _ := strings.Cut(strings.ToLower(code), "-")
+	if _, ok := catalogs[base]; ok {
+		return base
+	}
+	return "en"
+}
+
+// IsSupported сообщает, есть ли каталог для языка.
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Matches сообщает, совпадает ли text с переводом key хотя бы на одном языке.
+func Matches(text, key string) bool {
+	for _, lang := range Supported {
+		if strings.EqualFold(strings.TrimSpace(text), T(lang, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+type ctxKey struct{}
+
+// WithLang кладёт в контекст language_code отправителя апдейта.
+func WithLang(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, code)
+}
+
+// FromContext возвращает язык из контекста, приведённый к поддерживаемому.
+func FromContext(ctx context.Context) string {
+	code, _ := ctx.Value(ctxKey{}).(string)
+	return Resolve(code)
+}
@@ -0,0 +1,234 @@
+package i18n
+
+var ru = map[string]string{
+	"lang.name":    "🇷🇺 Русский",
+	"lang.choose":  "Выбери язык:",
+	"lang.changed": "Готово, теперь я говорю по-русски 🇷🇺",
+
+	"error.unavailable": "Сервис недоступен. Попробуй позже.",
+	"error.generic":     "Что-то пошло не так. Попробуй ещё раз.",
+	"error.text":        "Не понял сообщение. Попробуй ещё раз.",
+	"error.action":      "Действие не удалось. Попробуй ещё раз.",
+
+	"ratelimit.slow": "Слишком быстро 🙂 Подожди пару секунд и попробуй снова.",
+
+	"start.new":  "Привет! Давай создадим анкету.\nКак тебя зовут?",
+	"start.over": "Давай начнём с начала. Как тебя зовут?",
+
+	"menu.items":  "1. Смотреть анкеты 🚀\n2. Моя анкета 📱\n3. Изменить анкету ✏️\n4. Скрыть / показать анкету ⏸\n5. Кто меня лайкнул 💌\n6. Мои совпадения 💞\n7. Настройки поиска 🔎",
+	"menu.choose": "Выбери действие:\n%s",
+	"menu.hint":   "Выбери пункт меню: 1 (смотреть), 2 (моя анкета), 3 (изменить), 4 (скрыть / показать), 5 (кто меня лайкнул), 6 (совпадения), 7 (настройки поиска).",
+
+	"pause.done":        "Анкета скрыта ⏸ Тебя не увидят в поиске. Вернуть её: /resume\n%s",
+	"pause.already":     "Анкета уже скрыта. Вернуть её: /resume\n%s",
+	"resume.done":       "Анкета снова видна в поиске ▶️\n%s",
+	"resume.already":    "Анкета и так видна в поиске.\n%s",
+	"delete.confirm":    "Удалить аккаунт? Анкета, фото, лайки и совпадения будут удалены безвозвратно.",
+	"delete.done":       "Аккаунт удалён. Если захочешь вернуться — /start",
+	"delete.failed":     "Не удалось удалить аккаунт. Попробуй ещё раз.",
+	"delete.cancelled":  "Удаление отменено.\n%s",
+	"delete.nothing":    "У тебя нет анкеты — удалять нечего.",
+	"visibility.failed": "Не удалось изменить видимость анкеты. Попробуй позже.\n%s",
+
+	"ask.age":            "Сколько тебе лет?",
+	"ask.age.invalid":    "Возраст должен быть числом. Введи корректный возраст.",
+	"ask.city":           "Где ты живёшь? Укажи город. Можно ещё поделиться геопозицией — тогда покажем тех, кто рядом.",
+	"ask.gender":         "Выбери пол:",
+	"ask.gender.invalid": "Пожалуйста, выбери пол кнопкой.",
+	"ask.desc":           "Кратко опиши себя (интересы, что ищешь).",
+	"ask.photo":          "Пришли фото для анкеты — можно до %d штук. Первое станет главным.",
+
+	"location.saved":      "📍 Геопозиция сохранена. Теперь напиши название города — его увидят в анкете.",
+	"location.invalid":    "Не получилось распознать геопозицию. Попробуй ещё раз.",
+	"location.unexpected": "Геопозиция сейчас не нужна. Используй меню.",
+	"geo.set":             "указана",
+	"geo.unset":           "не указана",
+	"btn.share_location":  "📍 Поделиться геопозицией",
+
+	"gender.male":    "Парень",
+	"gender.female":  "Девушка",
+	"gender.unknown": "не указан",
+
+	"photo.unexpected":  "Фото сейчас не требуется. Используй меню.",
+	"photo.not_image":   "Пришли, пожалуйста, фото изображением, не файлом.",
+	"photo.get_failed":  "Не удалось получить фото, попробуй ещё раз.",
+	"photo.read_failed": "Не удалось прочитать фото, попробуй ещё раз.",
+	"photo.too_big":     "Фото слишком большое. Отправь файл поменьше (до %dMB).",
+	"photo.failed":      "Не удалось обработать фото, попробуй ещё раз.",
+	"photo.more":        "Фото добавлено (%d из %d). Пришли ещё или нажми «Готово».",
+	"photo.limit":       "В анкете уже %d фото — удали одно, чтобы добавить новое.",
+	"photo.added":       "Фото добавлено ✅",
+	"photo.removed":     "Фото удалено.",
+	"photo.main_set":    "Главное фото обновлено ✅",
+	"photo.last":        "Это единственное фото — его нельзя удалить. Сначала добавь другое.",
+
+	"profile.saved":          "Анкета сохранена! Что дальше?\n%s",
+	"profile.save_failed":    "Не удалось сохранить анкету. Попробуй ещё раз.",
+	"profile.update_failed":  "Не удалось обновить анкету. Попробуй ещё раз.",
+	"profile.missing":        "Похоже, анкеты нет. Давай создадим! Как тебя зовут?",
+	"profile.not_found":      "Анкета не найдена. Давай создадим! Как тебя зовут?",
+	"profile.mine":           "Твоя анкета:\n%s\n\n%s",
+	"profile.status.visible": "👁 Анкета видна в поиске. Скрыть: /pause",
+	"profile.status.hidden":  "⏸ Анкета скрыта из поиска. Показать: /resume",
+	"register.first":         "Сначала зарегистрируй анкету: /start",
+
+	"edit.choose":     "Что изменить?\n\n%s",
+	"edit.saved":      "Сохранено ✅\n\n%s",
+	"edit.current":    "Имя: %s\nВозраст: %d\nГород: %s\nГеопозиция: %s\nПол: %s\nО себе: %s",
+	"edit.ask.name":   "Сейчас: %s\nВведи новое имя.",
+	"edit.ask.age":    "Сейчас: %d\nВведи новый возраст.",
+	"edit.ask.city":   "Сейчас: %s\nВведи новый город.",
+	"edit.ask.geo":    "Геопозиция сейчас: %s\nПоделись новой — анкеты будут подбираться по расстоянию.",
+	"edit.ask.gender": "Сейчас: %s\nВыбери пол.",
+	"edit.ask.desc":   "Сейчас: %s\nНапиши новое описание.",
+	"edit.ask.photo":  "Пришли фото, чтобы добавить его в анкету.",
+	"edit.photos":     "%s\n\nФото в анкете: %d из %d.\n⭐ — сделать главным, 🗑 — удалить.",
+	"edit.empty":      "Значение не может быть пустым.",
+
+	"card":                   "%s",
+	"card.message":           "%s\n\n💌 «%s»",
+	"candidate.unavailable":  "Не удалось получить профиль кандидата. Пробуем следующего…",
+	"browse.hint":            "Используй кнопки под анкетой: ❤️ / 💌 / 👎 / 💤 / 🚩",
+	"browse.fetch_failed":    "Не удалось получить кандидатов. Попробуй позже.",
+	"browse.empty":           "Пока нет подходящих анкет.\nЧто дальше?\n%s",
+	"browse.finished":        "Анкеты закончились. Возвращаемся в меню.\nЧто дальше?\n%s",
+	"browse.no_more":         "Кандидатов больше нет.\nЧто дальше?\n%s",
+	"browse.sleep":           "Ок, вернулись в меню.\n%s",
+	"matches.item":           "💞 %s",
+	"matches.more":           "Показать ещё совпадения?",
+	"matches.end":            "Это все твои совпадения.\n%s",
+	"matches.empty":          "Совпадений пока нет — лайкай анкеты, и они появятся!\n%s",
+	"matches.fetch_failed":   "Не удалось загрузить совпадения. Попробуй позже.",
+	"matches.unmatched":      "Совпадение удалено.",
+	"matches.unmatch_failed": "Не удалось удалить совпадение. Попробуй ещё раз.",
+	"report.choose":          "Что не так с анкетой?",
+	"report.reason.spam":     "Спам или реклама",
+	"report.reason.fake":     "Фейковая анкета",
+	"report.reason.abuse":    "Оскорбления",
+	"report.reason.underage": "Несовершеннолетний",
+	"report.reason.other":    "Другое",
+	"report.comment":         "Опиши проблему одним сообщением или нажми «Пропустить».",
+	"report.sent":            "Спасибо, жалоба отправлена модераторам. Эту анкету ты больше не увидишь.",
+	"report.blocked":         "Пользователь заблокирован 🚫",
+	"report.cancelled":       "Жалоба отменена.",
+	"report.failed":          "Не удалось отправить. Попробуй ещё раз.",
+	"inbox.empty":            "Новых лайков пока нет.\n%s",
+	"inbox.finished":         "Это были все, кто тебя лайкнул.\n%s",
+	"inbox.fetch_failed":     "Не удалось загрузить лайки. Попробуй позже.",
+	"browse.hidden":          "Твоя анкета сейчас скрыта. Показать её снова и начать просмотр?",
+	"browse.stale":           "Эта анкета уже неактуальна. Используй кнопки под последней анкетой.",
+
+	"action.unavailable": "Действие сейчас недоступно. Используй меню.",
+	"action.unknown":     "Неизвестное действие.",
+
+	"like.received":    "Ты кому-то понравился 😉\nПосмотри, кто это!",
+	"like.quota":       "Лайки на сегодня закончились 💔 Новые появятся через %d ч %d мин. Пропускать анкеты можно и сейчас.",
+	"like.quota.later": "Лайки на сегодня закончились 💔 Новые появятся в полночь. Пропускать анкеты можно и сейчас.",
+
+	"like.received.message":  "Ты кому-то понравился 😉 Тебе написали:\n💌 «%s»\nПосмотри, кто это!",
+	"like.message.ask":       "Напиши сообщение к лайку (до %d символов) — его покажут вместе с лайком.",
+	"like.message.too_long":  "Слишком длинно — уложись в %d символов.",
+	"like.message.cancelled": "Хорошо, без сообщения. Оцени анкету кнопками.",
+
+	"timezone.current": "Твой часовой пояс: %s. Лайки обновляются в полночь по нему. Изменить: /timezone Europe/Moscow",
+	"timezone.unset":   "Часовой пояс не указан, лайки обновляются в полночь по времени сервера. Указать: /timezone Europe/Moscow",
+	"timezone.saved":   "Часовой пояс сохранён: %s ✅",
+	"timezone.invalid": "Не знаю часовой пояс «%s». Укажи название вроде Europe/Moscow или Asia/Almaty.",
+	"liker.card":       "Тебя лайкнул(а):\n%s",
+	"liker.failed":     "Не удалось получить анкету. Попробуй позже.",
+	"match.card":       "🎉 У тебя совпадение!\n\n%s",
+	"card.actions":     "Выбери действие:",
+
+	"account.banned": "Доступ к боту ограничен администратором.",
+
+	"admin.usage":          "Укажи Telegram ID: %s 123456789",
+	"admin.not_found":      "Пользователь с Telegram ID %d не найден.",
+	"admin.stats":          "📊 Статистика за %s\nРегистраций: %d\nЛайков: %d\nСовпадений: %d\n\nВсего анкет: %d, видно в поиске: %d, заблокировано: %d\nЖалоб в очереди: %d",
+	"admin.user":           "Анкета #%d, Telegram ID %d:\n%s\n\n%s\nЛайков поставил: %d, получил: %d\nСовпадений: %d\nЖалоб на пользователя: %d",
+	"admin.status.visible": "👁 Видна в поиске",
+	"admin.status.hidden":  "⏸ Скрыта",
+	"admin.status.banned":  "🚫 Заблокирован",
+	"admin.banned":         "🚫 %s (%d) заблокирован: анкета скрыта, бот ему больше не отвечает.",
+	"admin.unbanned":       "✅ %s (%d) разблокирован. Анкета останется скрытой, пока он сам не включит её.",
+	"admin.ban.admin":      "Администратора заблокировать нельзя.",
+
+	"broadcast.usage":         "Текст рассылки: /broadcast Привет всем!",
+	"broadcast.disabled":      "Рассылки отключены.",
+	"broadcast.started":       "Рассылка %s запущена. Состояние: /broadcast_status",
+	"broadcast.active":        "Рассылка %s ещё не закончена. Состояние: /broadcast_status",
+	"broadcast.none":          "Рассылок ещё не было.",
+	"broadcast.not_running":   "Сейчас ничего не рассылается.",
+	"broadcast.not_paused":    "Рассылка не на паузе.",
+	"broadcast.status":        "Рассылка %s: %s\nДоставлено: %d, заблокировали бота: %d, ошибок: %d",
+	"broadcast.paused":        "⏸ Рассылка %s: %s\nДоставлено: %d, заблокировали бота: %d, ошибок: %d\nПродолжить: /broadcast_resume",
+	"broadcast.resumed":       "▶️ Рассылка %s: %s\nДоставлено: %d, заблокировали бота: %d, ошибок: %d",
+	"broadcast.state.running": "идёт",
+	"broadcast.state.paused":  "на паузе",
+	"broadcast.state.done":    "завершена",
+
+	"search.choose":         "🔎 Настройки поиска\n\n%s",
+	"search.saved":          "Сохранено ✅\n\n%s",
+	"search.current":        "Показываю: %s\nВозраст: %s\nГде: %s",
+	"search.failed":         "Не удалось сохранить настройки. Попробуй позже.",
+	"search.ask.gender":     "Чьи анкеты показывать?",
+	"search.ask.age":        "Напиши возрастной диапазон, например 25-35 (от %d до %d).",
+	"search.age.invalid":    "Не понял диапазон. Напиши два числа от %d до %d, например 25-35.",
+	"search.ask.radius":     "Где искать? Радиус работает, если ты поделился геопозицией (3 → 📍 Геопозиция), иначе ищем в твоём городе.",
+	"search.gender.default": "противоположный пол",
+	"search.gender.male":    "парней",
+	"search.gender.female":  "девушек",
+	"search.gender.any":     "всех",
+	"search.age.default":    "±3 года от твоего",
+	"search.age.range":      "%d–%d",
+	"search.where.city":     "город %s",
+	"search.where.near":     "рядом со мной",
+	"search.where.km":       "до %d км от меня",
+	"search.where.any":      "любой город",
+	"btn.search.gender":     "Кого",
+	"btn.search.age":        "Возраст",
+	"btn.search.radius":     "Где",
+	"btn.seek.male":         "Парней",
+	"btn.seek.female":       "Девушек",
+	"btn.seek.any":          "Всех",
+	"btn.seek.default":      "По умолчанию",
+	"btn.km":                "%d км",
+	"btn.any_city":          "🌍 Любой город",
+
+	"admin.campaigns":        "📈 Источники регистраций за %d дн.:\n%s",
+	"admin.campaigns.empty":  "За %d дн. регистраций не было.",
+	"admin.campaigns.usage":  "Укажи число дней от 1 до %d: /campaigns 7",
+	"admin.campaign.line":    "%s: регистраций %d, активных %d (%d%%)",
+	"admin.campaign.organic": "без метки",
+
+	"undo.unavailable": "Отменить можно только последнюю оценку.",
+	"undo.failed":      "Не получилось отменить оценку, попробуй ещё раз.",
+
+	"ask.intro":        "Последний шаг: запиши голосовое или видео-кружок о себе — до %d секунд. Его увидят вместе с анкетой. Можно пропустить.",
+	"intro.unexpected": "Голосовое или кружок сейчас не нужны. Используй меню.",
+	"intro.invalid":    "Интро должно быть не длиннее %d секунд и не больше %d МБ. Запиши покороче или пропусти.",
+	"intro.get_failed": "Не удалось получить файл из Telegram, попробуй ещё раз.",
+	"intro.failed":     "Не получилось сохранить интро, попробуй ещё раз или пропусти.",
+
+	"btn.view_profile": "👀 Посмотреть анкету",
+	"btn.write":        "💬 Написать",
+	"btn.edit.name":    "Имя",
+	"btn.edit.age":     "Возраст",
+	"btn.edit.city":    "Город",
+	"btn.edit.gender":  "Пол",
+	"btn.edit.desc":    "О себе",
+	"btn.edit.photo":   "📷 Фото",
+	"btn.edit.geo":     "📍 Геопозиция",
+	"btn.keep":         "Оставить как есть",
+	"btn.done":         "✅ Готово",
+	"btn.resume":       "▶️ Показать и смотреть",
+	"btn.not_now":      "Не сейчас",
+	"btn.delete":       "🗑 Да, удалить навсегда",
+	"btn.cancel":       "Отмена",
+	"btn.unmatch":      "💔 Удалить",
+	"btn.more":         "Ещё ▶️",
+	"btn.block":        "🚫 Просто заблокировать",
+	"btn.skip":         "Пропустить",
+	"btn.back":         "⬅️ Назад",
+	"btn.photo.main":   "⭐ %d",
+	"btn.photo.del":    "🗑 %d",
+}
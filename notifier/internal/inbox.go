@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"app/notifier/internal/i18n"
+)
+
+// inboxSize — сколько входящих лайков загружаем за раз.
+const inboxSize = 20
+
+// startInbox показывает по одному тех, кто лайкнул пользователя и кому он ещё не ответил.
+func (c *Core) startInbox(ctx context.Context, chatID int64, s *session) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			s.State = stAskName
+			return Output{Text: i18n.M("profile.missing")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	likes, err := c.match.ListIncomingLikes(ctx, me.GetId(), inboxSize)
+	if err != nil {
+		log.Printf("core: ListIncomingLikes: %v", err)
+		return Output{Text: i18n.M("inbox.fetch_failed")}, nil
+	}
+	if len(likes) == 0 {
+		return Output{Text: withMenu("inbox.empty"), Kind: ReplyMenu}, nil
+	}
+
+	// nextCandidate берёт с конца, а лайки приходят новыми первыми. Обычная выдача остаётся
+	// в s.Candidates: match service уже сдвинул курсор за неё
+	s.InboxQueue = s.InboxQueue[:0]
+	for i := len(likes) - 1; i >= 0; i-- {
+		s.InboxQueue = append(s.InboxQueue, candidate{UserID: likes[i].GetFromUser(), Message: likes[i].GetMessage()})
+	}
+	s.Inbox = true
+	s.LastSwipe = nil
+	s.State = stBrowsing
+
+	return c.nextCandidate(ctx, chatID, s)
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"log"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Виды интро, как их хранит user service.
+const (
+	IntroVoice     = "voice"
+	IntroVideoNote = "video_note"
+)
+
+// Ограничения интро, такие же, как в user service.
+const (
+	MaxIntroSeconds = 60
+	MaxIntroSize    = 3 << 20
+)
+
+// Intro — голосовое или видео-кружок анкеты, отправляется следом за сообщением.
+// Src — "file_id:<id>" или ссылка, как в Output.Photos.
+type Intro struct {
+	Kind string
+	Src  string
+}
+
+// IntroUpload — голосовое или кружок, которые пользователь прислал боту.
+type IntroUpload struct {
+	Kind     string
+	FileID   string
+	Duration int // секунды
+	Data     []byte
+}
+
+// askIntro предлагает после фото записать интро; шаг можно пропустить.
+func askIntro(s *session) Output {
+	s.State = stAskIntro
+	return Output{Text: i18n.M("ask.intro", MaxIntroSeconds), Kind: ReplyIntro}
+}
+
+// OnIntro сохраняет интро на последнем шаге регистрации.
+func (c *Core) OnIntro(ctx context.Context, chatID int64, in IntroUpload) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if s.State != stAskIntro {
+		return Output{Text: i18n.M("intro.unexpected")}, nil
+	}
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable"), Kind: ReplyIntro}, nil
+	}
+
+	intro := &userpb.Intro{Kind: in.Kind, Duration: int32(in.Duration), FileId: in.FileID}
+	if _, err := c.users.SetIntro(ctx, me.GetId(), intro, bytes.NewReader(in.Data)); err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return Output{Text: i18n.M("intro.invalid", MaxIntroSeconds, MaxIntroSize>>20), Kind: ReplyIntro}, nil
+		}
+		log.Printf("core: SetIntro: %v", err)
+		return Output{Text: i18n.M("intro.failed"), Kind: ReplyIntro}, nil
+	}
+	return finishProfile(s), nil
+}
+
+// introOf возвращает интро анкеты; по file_id Telegram отправляет быстрее и без скачивания.
+func introOf(u *userpb.User) *Intro {
+	in := u.GetIntro()
+	if in == nil {
+		return nil
+	}
+	if in.GetFileId() != "" {
+		return &Intro{Kind: in.GetKind(), Src: "file_id:" + in.GetFileId()}
+	}
+	return &Intro{Kind: in.GetKind(), Src: in.GetUrl()}
+}
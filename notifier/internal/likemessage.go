@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"app/notifier/internal/i18n"
+)
+
+// maxLikeMessage — ограничение длины сообщения к лайку, то же, что в match service.
+const maxLikeMessage = 200
+
+// onLikeMessageAction обрабатывает кнопку 💌 под анкетой ("likemsg:<id>") и отмену ("likemsg:cancel").
+func (c *Core) onLikeMessageAction(s *session, arg string) Output {
+	if arg == "cancel" {
+		if s.State != stLikeMessage || s.CurrentTarget == nil {
+			return Output{Text: i18n.M("action.unavailable")}
+		}
+		s.State = stBrowsing
+		return Output{Text: i18n.M("like.message.cancelled"), Kind: ReplyBrowse, TargetID: s.CurrentTarget.UserID}
+	}
+
+	if s.State != stBrowsing || s.CurrentTarget == nil {
+		return Output{Text: i18n.M("action.unavailable")}
+	}
+	targetID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || targetID != s.CurrentTarget.UserID {
+		return Output{Text: i18n.M("browse.stale")}
+	}
+	s.State = stLikeMessage
+	return Output{Text: i18n.M("like.message.ask", maxLikeMessage), Kind: ReplyLikeMessage}
+}
+
+// submitLikeMessage лайкает анкету на экране вместе с сообщением.
+func (c *Core) submitLikeMessage(ctx context.Context, chatID int64, s *session, text string) (Output, error) {
+	if s.CurrentTarget == nil {
+		s.State = stMenu
+		return Output{Text: withMenu("browse.no_more"), Kind: ReplyMenu}, nil
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Output{Text: i18n.M("like.message.ask", maxLikeMessage), Kind: ReplyLikeMessage}, nil
+	}
+	if utf8.RuneCountInString(text) > maxLikeMessage {
+		return Output{Text: i18n.M("like.message.too_long", maxLikeMessage), Kind: ReplyLikeMessage}, nil
+	}
+
+	s.State = stBrowsing
+	return c.rate(ctx, chatID, s, true, text)
+}
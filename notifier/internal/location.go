@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"log"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+)
+
+// OnLocation принимает геопозицию: при регистрации она сохраняется в черновик анкеты
+// (город всё равно спрашиваем — он виден в анкете и нужен для поиска тех, кто без геопозиции),
+// при редактировании — сразу уходит в user service.
+func (c *Core) OnLocation(ctx context.Context, chatID int64, lat, lon float64) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		log.Printf("core: location out of range from %d: %f, %f", chatID, lat, lon)
+		return Output{Text: i18n.M("location.invalid")}, nil
+	}
+
+	switch {
+	case s.State == stAskCity:
+		s.Draft.Geo = &geoPoint{Lat: lat, Lon: lon}
+		return Output{Text: i18n.M("location.saved"), Kind: ReplyRemoveKeyboard}, nil
+	case s.State == stEditField && s.EditField == fieldGeo:
+		return c.applyEdit(ctx, chatID, s, &userpb.User{Geo: &userpb.GeoPoint{Latitude: lat, Longitude: lon}})
+	default:
+		return Output{Text: i18n.M("location.unexpected")}, nil
+	}
+}
+
+func (g *geoPoint) pb() *userpb.GeoPoint {
+	if g == nil {
+		return nil
+	}
+	return &userpb.GeoPoint{Latitude: g.Lat, Longitude: g.Lon}
+}
+
+func geoLabel(g *userpb.GeoPoint) i18n.Msg {
+	if g == nil {
+		return i18n.M("geo.unset")
+	}
+	return i18n.M("geo.set")
+}
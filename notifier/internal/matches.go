@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"app/notifier/internal/i18n"
+)
+
+// matchesPage — сколько совпадений показываем за раз.
+const matchesPage = 5
+
+// showMatches отправляет страницу совпадений отдельными карточками; cursor "" — первая страница.
+func (c *Core) showMatches(ctx context.Context, chatID int64, s *session, cursor string) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	list, next, err := c.match.ListMatches(ctx, me.GetId(), cursor, matchesPage)
+	if err != nil {
+		log.Printf("core: ListMatches: %v", err)
+		return Output{Text: i18n.M("matches.fetch_failed")}, nil
+	}
+
+	s.State = stMenu
+	s.MatchesCursor = next
+
+	if len(list) == 0 && cursor == "" {
+		return Output{Text: withMenu("matches.empty"), Kind: ReplyMenu}, nil
+	}
+
+	var cards []Notification
+	for _, m := range list {
+		u, err := c.users.GetByID(ctx, m.GetUserId())
+		if err != nil || u == nil {
+			log.Printf("core: GetByID(%d): %v", m.GetUserId(), err)
+			continue
+		}
+		photos := photoURLs(u)
+		if len(photos) > 1 {
+			photos = photos[:1]
+		}
+		cards = append(cards, Notification{
+			ChatID: chatID,
+			Output: Output{
+				Text:     i18n.M("matches.item", profileCaption(u)),
+				Photos:   photos,
+				Kind:     ReplyMatchItem,
+				TargetID: u.GetId(),
+				Link:     contactLink(u),
+			},
+		})
+	}
+
+	if next == "" {
+		return Output{Text: withMenu("matches.end"), Kind: ReplyMenu, Notify: cards}, nil
+	}
+	return Output{Text: i18n.M("matches.more"), Kind: ReplyMatchesMore, Notify: cards}, nil
+}
+
+// onMatchesAction обрабатывает кнопку "matches:more".
+func (c *Core) onMatchesAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if arg != "more" {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+	if s.MatchesCursor == "" {
+		return Output{Text: withMenu("matches.end"), Kind: ReplyMenu}, nil
+	}
+	return c.showMatches(ctx, chatID, s, s.MatchesCursor)
+}
+
+// unmatch обрабатывает кнопку "unmatch:<id>" под карточкой совпадения.
+func (c *Core) unmatch(ctx context.Context, chatID int64, arg string) (Output, error) {
+	otherID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	if err := c.match.Unmatch(ctx, me.GetId(), otherID); err != nil {
+		log.Printf("core: Unmatch(%d): %v", otherID, err)
+		return Output{Text: i18n.M("matches.unmatch_failed")}, nil
+	}
+	return Output{Text: i18n.M("matches.unmatched")}, nil
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPhotos — лимит фото в анкете, такой же, как в user service.
+const maxPhotos = 5
+
+// onMorePhoto добавляет фото при регистрации, пока не наберётся maxPhotos.
+func (c *Core) onMorePhoto(ctx context.Context, chatID int64, s *session, photo []byte) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable"), Kind: ReplyPhotosDone}, nil
+	}
+
+	photos, err := c.users.AddPhoto(ctx, me.GetId(), bytes.NewReader(photo))
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return c.finishPhotos(s), nil
+		}
+		log.Printf("core: AddPhoto: %v", err)
+		return Output{Text: i18n.M("photo.failed"), Kind: ReplyPhotosDone}, nil
+	}
+	if len(photos) >= maxPhotos {
+		return c.finishPhotos(s), nil
+	}
+
+	return Output{
+		Text: i18n.M("photo.more", len(photos), maxPhotos),
+		Kind: ReplyPhotosDone,
+	}, nil
+}
+
+// finishPhotos переходит от фото к необязательному интро.
+func (c *Core) finishPhotos(s *session) Output {
+	return askIntro(s)
+}
+
+// finishProfile завершает регистрацию и возвращает в меню.
+func finishProfile(s *session) Output {
+	s.State = stMenu
+	return Output{Text: withMenu("profile.saved"), Kind: ReplyMenu}
+}
+
+// photoManager показывает фото анкеты с кнопками "сделать главным" и "удалить".
+func (c *Core) photoManager(ctx context.Context, chatID int64, s *session, note i18n.Msg) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	s.State = stEditField
+	s.EditField = fieldPhoto
+
+	ids := make([]int64, 0, len(me.GetPhotos()))
+	for _, p := range me.GetPhotos() {
+		ids = append(ids, p.GetId())
+	}
+	return Output{
+		Text:     i18n.M("edit.photos", note, len(ids), maxPhotos),
+		Kind:     ReplyEditPhotos,
+		Photos:   photoURLs(me),
+		PhotoIDs: ids,
+	}, nil
+}
+
+func (c *Core) onEditPhoto(ctx context.Context, chatID int64, s *session, photo []byte) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	if _, err := c.users.AddPhoto(ctx, me.GetId(), bytes.NewReader(photo)); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return c.photoManager(ctx, chatID, s, i18n.M("photo.limit", maxPhotos))
+		}
+		log.Printf("core: AddPhoto: %v", err)
+		return c.photoManager(ctx, chatID, s, i18n.M("photo.failed"))
+	}
+	return c.photoManager(ctx, chatID, s, i18n.M("photo.added"))
+}
+
+// onPhotoAction обрабатывает кнопки "photo:main:<id>" и "photo:del:<id>".
+func (c *Core) onPhotoAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if s.State != stEditField || s.EditField != fieldPhoto {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+
+	op, rawID, _ := strings.Cut(arg, ":")
+	photoID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	switch op {
+	case "main":
+		order := []int64{photoID}
+		for _, p := range me.GetPhotos() {
+			if p.GetId() != photoID {
+				order = append(order, p.GetId())
+			}
+		}
+		if _, err := c.users.ReorderPhotos(ctx, me.GetId(), order); err != nil {
+			log.Printf("core: ReorderPhotos: %v", err)
+			return c.photoManager(ctx, chatID, s, i18n.M("photo.failed"))
+		}
+		return c.photoManager(ctx, chatID, s, i18n.M("photo.main_set"))
+
+	case "del":
+		// в анкете должно остаться хотя бы одно фото
+		if len(me.GetPhotos()) <= 1 {
+			return c.photoManager(ctx, chatID, s, i18n.M("photo.last"))
+		}
+		if _, err := c.users.RemovePhoto(ctx, me.GetId(), photoID); err != nil {
+			log.Printf("core: RemovePhoto: %v", err)
+			return c.photoManager(ctx, chatID, s, i18n.M("photo.failed"))
+		}
+		return c.photoManager(ctx, chatID, s, i18n.M("photo.removed"))
+	}
+
+	return Output{Text: i18n.M("action.unknown")}, nil
+}
+
+// photoURLs возвращает фото анкеты по порядку, главное — первое.
+func photoURLs(u *userpb.User) []string {
+	urls := make([]string, 0, len(u.GetPhotos()))
+	for _, p := range u.GetPhotos() {
+		urls = append(urls, p.GetUrl())
+	}
+	if len(urls) == 0 && u.GetPhotoUrl() != "" {
+		urls = append(urls, u.GetPhotoUrl())
+	}
+	return urls
+}
@@ -6,6 +6,7 @@ import (
 	userpb "app/user/proto"
 	"context"
 	"io"
+	"time"
 )
 
 type UserClient interface {
@@ -14,11 +15,58 @@ type UserClient interface {
 	Create(ctx context.Context, user *userpb.User) (*userpb.User, error)
 	Update(ctx context.Context, user *userpb.User) (*userpb.User, error)
 	UpdatePhoto(ctx context.Context, userID int64, photo io.Reader) (*userpb.User, error)
+	AddPhoto(ctx context.Context, userID int64, photo io.Reader) ([]*userpb.Photo, error)
+	RemovePhoto(ctx context.Context, userID, photoID int64) ([]*userpb.Photo, error)
+	ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]*userpb.Photo, error)
 	ToggleVisibility(ctx context.Context, userID int64, isVisible bool) error
+	Delete(ctx context.Context, userID int64) error
+	SetBanned(ctx context.Context, userID int64, banned bool) error
+	GetStats(ctx context.Context, since time.Time) (*userpb.GetStatsResponse, error)
+	SetBotBlocked(ctx context.Context, userID int64, blocked bool) error
+	ListUsers(ctx context.Context, afterID int64, limit int32) ([]*userpb.User, error)
+	// UpdateSearchPrefs заменяет настройки поиска целиком.
+	UpdateSearchPrefs(ctx context.Context, userID int64, prefs *userpb.SearchPrefs) (*userpb.User, error)
+	// MarkActivated отмечает первый лайк пользователя; повторные вызовы ничего не меняют.
+	MarkActivated(ctx context.Context, userID int64) error
+	GetCampaignStats(ctx context.Context, since time.Time) ([]*userpb.CampaignStats, error)
+	// SetIntro заменяет интро анкеты; InvalidArgument — файл не подходит по виду, длительности или размеру.
+	SetIntro(ctx context.Context, userID int64, intro *userpb.Intro, file io.Reader) (*userpb.User, error)
 }
 
 type MatchClient interface {
-	GetCandidates(ctx context.Context, userID int64) ([]*matchpb.User, error)
-	Like(ctx context.Context, fromUserID int64, toUserID int64, isLike bool) error
+	// GetCandidates отдаёт следующую страницу выдачи: match service сам помнит, докуда она
+	// долистана. more == false — анкеты закончились, следующий вызов вернёт только новые.
+	GetCandidates(ctx context.Context, telegramID int64) (list []*matchpb.User, more bool, err error)
+	// Like сохраняет оценку; message — необязательное сообщение к лайку.
+	Like(ctx context.Context, fromUserID int64, toUserID int64, isLike bool, message string) error
+	// UndoLike отменяет последнюю оценку fromUserID; FailedPrecondition — отменять нечего.
+	UndoLike(ctx context.Context, fromUserID, toUserID int64) error
 	Match(ctx context.Context, fromUserID, toUserId int64) (bool, error)
+	// HasLike сообщает, стоит ли лайк fromUserID пользователю toUserID: отменённые лайки
+	// и лайки между заблокировавшими друг друга не считаются.
+	HasLike(ctx context.Context, fromUserID, toUserID int64) (bool, error)
+	DeleteUser(ctx context.Context, userID int64) error
+	ListIncomingLikes(ctx context.Context, userID int64, limit int32) ([]*matchpb.IncomingLike, error)
+	ListMatches(ctx context.Context, userID int64, cursor string, limit int32) ([]*matchpb.MatchedUser, string, error)
+	Unmatch(ctx context.Context, userID, otherUserID int64) error
+	Block(ctx context.Context, userID, blockedUserID int64) error
+	Report(ctx context.Context, reporterID, reportedUserID int64, reason, comment string) error
+	GetStats(ctx context.Context, since time.Time) (*matchpb.GetStatsResponse, error)
+	GetUserStats(ctx context.Context, userID int64) (*matchpb.GetUserStatsResponse, error)
+}
+
+type SessionStore interface {
+	Load(ctx context.Context, chatID int64) (*session, error)
+	Save(ctx context.Context, chatID int64, s *session) error
+	Delete(ctx context.Context, chatID int64) error
+	// Lock блокирует сессию чата между репликами notifier и возвращает функцию разблокировки.
+	Lock(ctx context.Context, chatID int64) (unlock func(), err error)
+}
+
+// BanCache помнит, заблокирован ли пользователь, чтобы не спрашивать user service
+// на каждый апдейт. ok == false — записи нет или она устарела.
+type BanCache interface {
+	Get(ctx context.Context, chatID int64) (banned, ok bool, err error)
+	Set(ctx context.Context, chatID int64, banned bool) error
+	Forget(ctx context.Context, chatID int64) error
 }
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/notifier/internal/i18n"
+)
+
+// refPrefix — payload ссылки-приглашения: t.me/<bot>?start=ref_<id анкеты>.
+const refPrefix = "ref_"
+
+// campaignReferral — кампания, к которой относятся регистрации по приглашениям.
+const campaignReferral = "referral"
+
+// Telegram допускает в payload ссылки до 64 символов A-Z, a-z, 0-9, _ и -.
+var campaignRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Период /campaigns по умолчанию и максимальный, в днях.
+const (
+	defaultCampaignDays = 30
+	maxCampaignDays     = 365
+)
+
+// parseStartPayload разбирает payload /start: ref_<id> — приглашение от пользователя,
+// любая другая допустимая строка — метка рекламной кампании.
+func parseStartPayload(payload string) (referrer int64, campaign string) {
+	payload = strings.TrimSpace(payload)
+	if !campaignRe.MatchString(payload) {
+		return 0, ""
+	}
+	if rest, ok := strings.CutPrefix(payload, refPrefix); ok {
+		if id, err := strconv.ParseInt(rest, 10, 64); err == nil && id > 0 {
+			return id, campaignReferral
+		}
+	}
+	return 0, payload
+}
+
+// AdminCampaigns показывает регистрации и активации по источникам за последние дни
+// (аргумент команды, по умолчанию 30). Активированным считается тот, кто поставил лайк.
+func (c *Core) AdminCampaigns(ctx context.Context, chatID int64, arg string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	if !c.IsAdmin(chatID) {
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	days := defaultCampaignDays
+	if arg = strings.TrimSpace(arg); arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 || n > maxCampaignDays {
+			return Output{Text: i18n.M("admin.campaigns.usage", maxCampaignDays)}, nil
+		}
+		days = n
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	list, err := c.users.GetCampaignStats(ctx, since)
+	if err != nil {
+		log.Printf("core: GetCampaignStats: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	if len(list) == 0 {
+		return Output{Text: i18n.M("admin.campaigns.empty", days)}, nil
+	}
+
+	lines := make([]string, 0, len(list))
+	for _, st := range list {
+		name := st.GetCampaign()
+		if name == "" {
+			name = i18n.T(s.Lang, "admin.campaign.organic")
+		}
+		var rate int64
+		if st.GetRegistered() > 0 {
+			rate = st.GetActivated() * 100 / st.GetRegistered()
+		}
+		lines = append(lines, i18n.T(s.Lang, "admin.campaign.line", name, st.GetRegistered(), st.GetActivated(), rate))
+	}
+	return Output{Text: i18n.M("admin.campaigns", days, strings.Join(lines, "\n"))}, nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"app/notifier/internal/i18n"
+)
+
+// ReportReasons — причины жалобы, их же принимает match service.
+var ReportReasons = []string{"spam", "fake", "abuse", "underage", "other"}
+
+// reasonBlock — вместо жалобы просто заблокировать.
+const reasonBlock = "block"
+
+// onReportAction обрабатывает кнопки под анкетой и выбор причины:
+// "report:<id>", "report:<id>:<reason>", "report:skip", "report:cancel".
+func (c *Core) onReportAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	switch arg {
+	case "skip":
+		if s.State != stReportComment {
+			return Output{Text: i18n.M("action.unavailable")}, nil
+		}
+		return c.submitReport(ctx, chatID, s, "")
+	case "cancel":
+		return c.cancelReport(s), nil
+	}
+
+	if s.State != stBrowsing || s.CurrentTarget == nil {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+	rawID, reason, _ := strings.Cut(arg, ":")
+	targetID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || targetID != s.CurrentTarget.UserID {
+		return Output{Text: i18n.M("browse.stale")}, nil
+	}
+
+	switch {
+	case reason == "":
+		return Output{Text: i18n.M("report.choose"), Kind: ReplyReportReason, TargetID: targetID}, nil
+	case reason == reasonBlock:
+		return c.block(ctx, chatID, s, targetID)
+	case slices.Contains(ReportReasons, reason):
+		s.State = stReportComment
+		s.Report = &reportDraft{TargetID: targetID, Reason: reason}
+		return Output{Text: i18n.M("report.comment"), Kind: ReplyReportComment}, nil
+	}
+	return Output{Text: i18n.M("action.unknown")}, nil
+}
+
+// block блокирует текущую анкету и показывает следующую.
+func (c *Core) block(ctx context.Context, chatID int64, s *session, targetID int64) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	if err := c.match.Block(ctx, me.GetId(), targetID); err != nil {
+		log.Printf("core: Block(%d): %v", targetID, err)
+		return Output{Text: i18n.M("report.failed"), Kind: ReplyBrowse, TargetID: targetID}, nil
+	}
+	return c.nextWithNote(ctx, chatID, s, "report.blocked")
+}
+
+// submitReport отправляет жалобу (match service заодно блокирует пользователя) и показывает следующую анкету.
+func (c *Core) submitReport(ctx context.Context, chatID int64, s *session, comment string) (Output, error) {
+	if s.Report == nil {
+		return c.cancelReport(s), nil
+	}
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	r := *s.Report
+	if err := c.match.Report(ctx, me.GetId(), r.TargetID, r.Reason, strings.TrimSpace(comment)); err != nil {
+		log.Printf("core: Report(%d, %s): %v", r.TargetID, r.Reason, err)
+		return Output{Text: i18n.M("report.failed"), Kind: ReplyReportComment}, nil
+	}
+
+	s.State = stBrowsing
+	s.Report = nil
+	return c.nextWithNote(ctx, chatID, s, "report.sent")
+}
+
+func (c *Core) cancelReport(s *session) Output {
+	s.Report = nil
+	if s.CurrentTarget == nil || (s.State != stBrowsing && s.State != stReportComment) {
+		return Output{Text: i18n.M("action.unavailable")}
+	}
+	s.State = stBrowsing
+	return Output{Text: i18n.M("report.cancelled"), Kind: ReplyBrowse, TargetID: s.CurrentTarget.UserID}
+}
+
+// nextWithNote показывает следующую анкету, предварив её коротким сообщением.
+func (c *Core) nextWithNote(ctx context.Context, chatID int64, s *session, key string) (Output, error) {
+	out, err := c.nextCandidate(ctx, chatID, s)
+	out.Notify = append([]Notification{{ChatID: chatID, Output: Output{Text: i18n.M(key)}}}, out.Notify...)
+	return out, err
+}
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// seekAnyone — в настройках поиска: показывать анкеты любого пола.
+const seekAnyone = "any"
+
+// SearchRadii — радиусы поиска (км), которые можно выбрать кнопками.
+var SearchRadii = []int32{10, 25, 50, 100}
+
+// Допустимый возрастной диапазон в настройках поиска.
+const (
+	minSeekAge = 18
+	maxSeekAge = 99
+)
+
+// searchSettings показывает текущие настройки поиска и кнопки их изменения.
+func (c *Core) searchSettings(ctx context.Context, chatID int64, s *session, key string) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	return searchScreen(s, me, key), nil
+}
+
+func searchScreen(s *session, me *userpb.User, key string) Output {
+	s.State = stMenu
+
+	p := me.GetSearchPrefs()
+	return Output{
+		Text: i18n.M(key, i18n.M("search.current", seekGenderLabel(p), seekAgeLabel(p), seekWhereLabel(me))),
+		Kind: ReplySearch,
+	}
+}
+
+// onSearchAction обрабатывает кнопки "search:<поле>" и "search:<поле>:<значение>".
+func (c *Core) onSearchAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if s.State != stMenu && s.State != stSearchAge {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+
+	field, value, set := strings.Cut(arg, ":")
+	if !set {
+		switch field {
+		case "done":
+			s.State = stMenu
+			return Output{Text: withMenu("menu.choose"), Kind: ReplyMenu}, nil
+		case "back":
+			return c.searchSettings(ctx, chatID, s, "search.choose")
+		case "gender":
+			s.State = stMenu
+			return Output{Text: i18n.M("search.ask.gender"), Kind: ReplySearchGender}, nil
+		case "age":
+			s.State = stSearchAge
+			return Output{Text: i18n.M("search.ask.age", minSeekAge, maxSeekAge), Kind: ReplySearchAge}, nil
+		case "radius":
+			s.State = stMenu
+			return Output{Text: i18n.M("search.ask.radius"), Kind: ReplySearchRadius}, nil
+		}
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+
+	var apply func(p *userpb.SearchPrefs)
+	switch field {
+	case "gender":
+		gender, ok := map[string]string{"male": genderMale, "female": genderFemale, "any": seekAnyone, "default": ""}[value]
+		if !ok {
+			return Output{Text: i18n.M("action.unknown")}, nil
+		}
+		apply = func(p *userpb.SearchPrefs) { p.Gender = gender }
+	case "age":
+		if value != "default" {
+			return Output{Text: i18n.M("action.unknown")}, nil
+		}
+		apply = func(p *userpb.SearchPrefs) { p.MinAge, p.MaxAge = 0, 0 }
+	case "radius":
+		switch value {
+		case "any":
+			apply = func(p *userpb.SearchPrefs) { p.AnyCity, p.RadiusKm = true, 0 }
+		case "default":
+			apply = func(p *userpb.SearchPrefs) { p.AnyCity, p.RadiusKm = false, 0 }
+		default:
+			km, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || !slices.Contains(SearchRadii, int32(km)) {
+				return Output{Text: i18n.M("action.unknown")}, nil
+			}
+			apply = func(p *userpb.SearchPrefs) { p.AnyCity, p.RadiusKm = false, int32(km) }
+		}
+	default:
+		return Output{Text: i18n.M("action.unknown")}, nil
+	}
+	return c.updatePrefs(ctx, chatID, s, apply)
+}
+
+// submitSearchAge принимает возрастной диапазон вида "20-30".
+func (c *Core) submitSearchAge(ctx context.Context, chatID int64, s *session, text string) (Output, error) {
+	parts := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(parts) != 2 {
+		return Output{Text: i18n.M("search.age.invalid", minSeekAge, maxSeekAge), Kind: ReplySearchAge}, nil
+	}
+	lo, err1 := strconv.Atoi(parts[0])
+	hi, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || lo < minSeekAge || hi > maxSeekAge || lo > hi {
+		return Output{Text: i18n.M("search.age.invalid", minSeekAge, maxSeekAge), Kind: ReplySearchAge}, nil
+	}
+	return c.updatePrefs(ctx, chatID, s, func(p *userpb.SearchPrefs) { p.MinAge, p.MaxAge = int32(lo), int32(hi) })
+}
+
+// updatePrefs меняет настройки поиска и сбрасывает очередь анкет, собранную по старым.
+func (c *Core) updatePrefs(ctx context.Context, chatID int64, s *session, apply func(p *userpb.SearchPrefs)) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	prefs := &userpb.SearchPrefs{}
+	if cur := me.GetSearchPrefs(); cur != nil {
+		prefs = proto.Clone(cur).(*userpb.SearchPrefs)
+	}
+	apply(prefs)
+
+	updated, err := c.users.UpdateSearchPrefs(ctx, me.GetId(), prefs)
+	if err != nil {
+		log.Printf("core: UpdateSearchPrefs: %v", err)
+		return Output{Text: i18n.M("search.failed"), Kind: ReplySearch}, nil
+	}
+	s.Candidates = nil
+	s.CurrentTarget = nil
+	return searchScreen(s, updated, "search.saved"), nil
+}
+
+func seekGenderLabel(p *userpb.SearchPrefs) i18n.Msg {
+	switch p.GetGender() {
+	case "":
+		return i18n.M("search.gender.default")
+	case seekAnyone:
+		return i18n.M("search.gender.any")
+	case genderMale:
+		return i18n.M("search.gender.male")
+	default:
+		return i18n.M("search.gender.female")
+	}
+}
+
+func seekAgeLabel(p *userpb.SearchPrefs) i18n.Msg {
+	if p.GetMinAge() == 0 && p.GetMaxAge() == 0 {
+		return i18n.M("search.age.default")
+	}
+	return i18n.M("search.age.range", p.GetMinAge(), p.GetMaxAge())
+}
+
+// seekWhereLabel описывает, где ищем: радиус работает, только если есть геопозиция.
+func seekWhereLabel(me *userpb.User) i18n.Msg {
+	p := me.GetSearchPrefs()
+	switch {
+	case p.GetAnyCity():
+		return i18n.M("search.where.any")
+	case me.GetGeo() == nil:
+		return i18n.M("search.where.city", me.GetLocation())
+	case p.GetRadiusKm() > 0:
+		return i18n.M("search.where.km", p.GetRadiusKm())
+	default:
+		return i18n.M("search.where.near")
+	}
+}
@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type state int
+
+const (
+	stIdle state = iota
+	stAskName
+	stAskAge
+	stAskCity
+	stAskGender
+	stAskDesc
+	stAskPhoto
+	stMenu
+	stBrowsing
+	stEditField
+	stAskMorePhotos
+	// stDeleted — аккаунт удалён, сессия стирается вместо сохранения.
+	stDeleted
+	stConfirmDelete
+	stReportComment
+	stLikeMessage
+	stSearchAge
+	stAskIntro
+)
+
+type candidate struct {
+	UserID     int64
+	TelegramID int64
+	// Message — сообщение, приложенное к входящему лайку.
+	Message string
+}
+
+func (c *candidate) id() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.UserID
+}
+
+type session struct {
+	State         state
+	Draft         draftProfile
+	Candidates    []candidate
+	CandidatesEnd bool // выдача match service закончилась, больше страниц не подгружаем
+	CurrentTarget *candidate
+	Inbox         bool        // листаем входящие лайки, а не обычную выдачу
+	InboxQueue    []candidate // очередь входящих лайков; выдача в Candidates ждёт возврата
+	MatchesCursor string      // курсор следующей страницы "Мои совпадения"
+	Report        *reportDraft
+	LastSwipe     *candidate // последняя оценённая анкета: её оценку можно отменить
+	EditField     string
+	Lang          string
+	UpdatedAt     time.Time // время последнего сохранения, от него считается TTL
+}
+
+// queue — очередь, из которой сейчас берутся анкеты: входящие лайки или обычная выдача.
+func (s *session) queue() *[]candidate {
+	if s.Inbox {
+		return &s.InboxQueue
+	}
+	return &s.Candidates
+}
+
+// reportDraft — жалоба, для которой ждём комментарий.
+type reportDraft struct {
+	TargetID int64
+	Reason   string
+}
+
+type draftProfile struct {
+	Name        string
+	Age         int32
+	City        string
+	Gender      string
+	Description string
+	PhotoString string
+	Geo         *geoPoint
+
+	// откуда пришёл новый пользователь: payload ссылки t.me/<bot>?start=...
+	Referrer int64
+	Campaign string
+}
+
+// geoPoint — геопозиция, которой пользователь поделился при регистрации.
+type geoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// MemorySessionStore хранит сессии в памяти процесса (для тестов и локального запуска).
+type MemorySessionStore struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[int64]*session
+}
+
+func NewMemorySessionStore(ttl time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{
+		ttl:      ttl,
+		sessions: make(map[int64]*session),
+	}
+}
+
+func (m *MemorySessionStore) Load(_ context.Context, chatID int64) (*session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.sessions[chatID]
+	if s == nil {
+		return nil, nil
+	}
+	// простаивающие сессии вычищаем при обращении
+	if m.ttl > 0 && time.Since(s.UpdatedAt) > m.ttl {
+		delete(m.sessions, chatID)
+		return nil, nil
+	}
+	return s, nil
+}
+
+func (m *MemorySessionStore) Save(_ context.Context, chatID int64, s *session) error {
+	m.mu.Lock()
+	m.sessions[chatID] = s
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemorySessionStore) Delete(_ context.Context, chatID int64) error {
+	m.mu.Lock()
+	delete(m.sessions, chatID)
+	m.mu.Unlock()
+	return nil
+}
+
+// Lock ничего не делает: хранилище живёт в одном процессе, а чаты блокирует Core.
+func (m *MemorySessionStore) Lock(context.Context, int64) (func(), error) {
+	return func() {}, nil
+}
+
+// RedisSessionStore хранит сессии в Redis, ключ живёт ttl с момента session.UpdatedAt.
+// Lock блокирует чат ключом в Redis, поэтому несколько реплик notifier не перезапишут
+// сессии друг друга.
+type RedisSessionStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisSessionStore(client *redis.Client, ttl time.Duration) *RedisSessionStore {
+	return &RedisSessionStore{client: client, ttl: ttl}
+}
+
+func (r *RedisSessionStore) Load(ctx context.Context, chatID int64) (*session, error) {
+	b, err := r.client.Get(ctx, sessionKey(chatID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s session
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *RedisSessionStore) Save(ctx context.Context, chatID int64, s *session) error {
+	expiration := time.Until(s.UpdatedAt.Add(r.ttl))
+	if expiration <= 0 {
+		return r.Delete(ctx, chatID)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, sessionKey(chatID), b, expiration).Err()
+}
+
+func (r *RedisSessionStore) Delete(ctx context.Context, chatID int64) error {
+	return r.client.Del(ctx, sessionKey(chatID)).Err()
+}
+
+const (
+	// sessionLockTTL больше таймаута любого апдейта: блокировка упавшей реплики
+	// освобождается сама, но не истекает посреди обработки.
+	sessionLockTTL  = 30 * time.Second
+	sessionLockWait = 20 * time.Millisecond
+)
+
+// unlockScript снимает блокировку, только если она всё ещё принадлежит token.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Lock ждёт, пока чат освободится, и блокирует его до вызова unlock или sessionLockTTL.
+func (r *RedisSessionStore) Lock(ctx context.Context, chatID int64) (func(), error) {
+	key, token := sessionLockKey(chatID), rand.Text()
+	for {
+		ok, err := r.client.SetNX(ctx, key, token, sessionLockTTL).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sessionLockWait):
+		}
+	}
+	return func() {
+		// контекст апдейта к этому моменту может истечь, а ключ надо снять сразу
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		_ = unlockScript.Run(ctx, r.client, []string{key}, token).Err()
+	}, nil
+}
+
+func sessionKey(chatID int64) string {
+	return fmt.Sprintf("notifier:session:%d", chatID)
+}
+
+func sessionLockKey(chatID int64) string {
+	return fmt.Sprintf("notifier:session:%d:lock", chatID)
+}
@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore(time.Hour)
+
+	if s, err := store.Load(ctx, 1); err != nil || s != nil {
+		t.Fatalf("missing session: got %+v, %v", s, err)
+	}
+
+	fresh := &session{State: stMenu, UpdatedAt: time.Now()}
+	if err := store.Save(ctx, 1, fresh); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := store.Load(ctx, 1); s == nil || s.State != stMenu {
+		t.Fatalf("saved session was not loaded: %+v", s)
+	}
+
+	// простоявшая дольше ttl сессия не возвращается и вычищается
+	stale := &session{State: stBrowsing, UpdatedAt: time.Now().Add(-2 * time.Hour)}
+	if err := store.Save(ctx, 2, stale); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := store.Load(ctx, 2); s != nil {
+		t.Fatalf("stale session was loaded: %+v", s)
+	}
+	if _, ok := store.sessions[2]; ok {
+		t.Fatal("stale session was not removed")
+	}
+
+	if err := store.Delete(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := store.Load(ctx, 1); s != nil {
+		t.Fatalf("deleted session was loaded: %+v", s)
+	}
+}
+
+func newRedisStore(t *testing.T, ttl time.Duration) (*RedisSessionStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisSessionStore(client, ttl), mr
+}
+
+func TestRedisSessionStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newRedisStore(t, time.Hour)
+
+	if s, err := store.Load(ctx, 1); err != nil || s != nil {
+		t.Fatalf("missing session: got %+v, %v", s, err)
+	}
+
+	want := &session{
+		State:         stBrowsing,
+		Candidates:    []candidate{{UserID: 3, TelegramID: 30}},
+		CurrentTarget: &candidate{UserID: 2, TelegramID: 20, Message: "hi"},
+		Draft:         draftProfile{Name: "Alice", Geo: &geoPoint{Lat: 52.52, Lon: 13.405}},
+		Lang:          "en",
+		UpdatedAt:     time.Now(),
+	}
+	if err := store.Save(ctx, 1, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Load(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != want.State || got.Lang != want.Lang || len(got.Candidates) != 1 || got.Candidates[0] != want.Candidates[0] {
+		t.Fatalf("session fields were lost: %+v", got)
+	}
+	if got.CurrentTarget == nil || *got.CurrentTarget != *want.CurrentTarget {
+		t.Fatalf("current target: got %+v, want %+v", got.CurrentTarget, want.CurrentTarget)
+	}
+	if got.Draft.Name != "Alice" || got.Draft.Geo == nil || *got.Draft.Geo != *want.Draft.Geo {
+		t.Fatalf("draft: got %+v", got.Draft)
+	}
+
+	if err := store.Delete(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := store.Load(ctx, 1); s != nil {
+		t.Fatalf("deleted session was loaded: %+v", s)
+	}
+}
+
+func TestRedisSessionStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	store, mr := newRedisStore(t, time.Hour)
+
+	tests := []struct {
+		name    string
+		age     time.Duration
+		wantTTL time.Duration // 0 — ключа быть не должно
+	}{
+		{name: "fresh session lives full ttl", age: 0, wantTTL: time.Hour},
+		{name: "ttl counts from UpdatedAt", age: 40 * time.Minute, wantTTL: 20 * time.Minute},
+		{name: "expired session is deleted on save", age: 2 * time.Hour},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatID := int64(i + 1)
+			// ключ уже есть: сохранение просроченной сессии должно его удалить
+			mr.Set(sessionKey(chatID), "{}")
+
+			if err := store.Save(ctx, chatID, &session{State: stMenu, UpdatedAt: time.Now().Add(-tt.age)}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantTTL == 0 {
+				if mr.Exists(sessionKey(chatID)) {
+					t.Fatal("expired session key was not deleted")
+				}
+				return
+			}
+			got := mr.TTL(sessionKey(chatID))
+			if got > tt.wantTTL || got < tt.wantTTL-time.Minute {
+				t.Fatalf("ttl: got %v, want about %v", got, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestRedisSessionStore_Lock(t *testing.T) {
+	ctx := context.Background()
+	store, mr := newRedisStore(t, time.Hour)
+	other := NewRedisSessionStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), time.Hour)
+
+	unlock, err := store.Lock(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// вторая реплика ждёт, пока чат не освободится
+	waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := other.Lock(waitCtx, 1); err == nil {
+		t.Fatal("second replica locked a busy chat")
+	}
+	if u, err := other.Lock(ctx, 2); err != nil {
+		t.Fatalf("other chat: %v", err)
+	} else {
+		u()
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		u, err := other.Lock(ctx, 1)
+		if err == nil {
+			u()
+		}
+		close(locked)
+	}()
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("chat was not released")
+	}
+}
+
+func TestRedisSessionStore_UnlockOnlyOwn(t *testing.T) {
+	ctx := context.Background()
+	store, mr := newRedisStore(t, time.Hour)
+
+	unlock, err := store.Lock(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// блокировка истекла, и чат заняла другая реплика: старый unlock не должен её снять
+	mr.FastForward(sessionLockTTL)
+	if _, err := store.Lock(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if !mr.Exists(sessionLockKey(1)) {
+		t.Fatal("stale unlock released another owner's lock")
+	}
+}
@@ -0,0 +1,93 @@
+package tg
+
+import (
+	"log"
+
+	"app/notifier/internal/i18n"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+// adminOnly пропускает к админским командам только администраторов. Для остальных
+// команда — обычный текст, как если бы её не существовало.
+func (h *Handler) adminOnly(next tb.HandlerFunc) tb.HandlerFunc {
+	return func(c tb.Context) error {
+		if sender := c.Sender(); sender == nil || !h.core.IsAdmin(sender.ID) {
+			return h.onText(c)
+		}
+		return next(c)
+	}
+}
+
+// banGate не пускает заблокированных пользователей дальше отказа.
+func (h *Handler) banGate(next tb.HandlerFunc) tb.HandlerFunc {
+	return func(c tb.Context) error {
+		sender := c.Sender()
+		if sender == nil {
+			return next(c)
+		}
+
+		ctx, cancel := h.newContext(c, tmoShort)
+		defer cancel()
+		if !h.core.Banned(ctx, sender.ID) {
+			return next(c)
+		}
+
+		text := i18n.T(h.core.Lang(ctx, sender.ID), "account.banned")
+		if c.Callback() != nil {
+			return c.Respond(&tb.CallbackResponse{Text: text})
+		}
+		if err := c.Send(text); err != nil {
+			log.Printf("tg.banGate: %v", err)
+		}
+		return nil
+	}
+}
+
+func (h *Handler) onStats(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.AdminStats(ctx, c.Sender().ID)
+	if err != nil {
+		log.Printf("core.AdminStats: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onCampaigns(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.AdminCampaigns(ctx, c.Sender().ID, c.Message().Payload)
+	if err != nil {
+		log.Printf("core.AdminCampaigns: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onAdminUser(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.AdminUser(ctx, c.Sender().ID, c.Message().Payload)
+	if err != nil {
+		log.Printf("core.AdminUser: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onBan(c tb.Context, banned bool) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.AdminBan(ctx, c.Sender().ID, c.Message().Payload, banned)
+	if err != nil {
+		log.Printf("core.AdminBan(%v): %v", banned, err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
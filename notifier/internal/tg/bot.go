@@ -6,9 +6,24 @@ import (
 	tb "gopkg.in/telebot.v4"
 )
 
-func NewBot(token string) (*tb.Bot, error) {
-	return tb.NewBot(tb.Settings{
+// NewBot создаёт бота. hook == nil — long polling, иначе апдейты приходят на вебхук.
+func NewBot(token string, hook *WebhookPoller) (*tb.Bot, error) {
+	var poller tb.Poller = &tb.LongPoller{Timeout: 10 * time.Second}
+	if hook != nil {
+		poller = hook
+	}
+
+	bot, err := tb.NewBot(tb.Settings{
 		Token:  token,
-		Poller: &tb.LongPoller{Timeout: 10 * time.Second},
+		Poller: poller,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if hook != nil {
+		return bot, hook.register(bot)
+	}
+	// пока вебхук установлен, getUpdates отвечает ошибкой 409
+	return bot, bot.RemoveWebhook()
 }
@@ -0,0 +1,125 @@
+package tg
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"app/notifier/internal/broadcast"
+	"app/notifier/internal/i18n"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+// BroadcastSender отправляет сообщения рассылки и переводит ошибки Telegram
+// в ошибки пакета broadcast.
+type BroadcastSender struct {
+	bot *tb.Bot
+}
+
+func NewBroadcastSender(bot *tb.Bot) *BroadcastSender {
+	return &BroadcastSender{bot: bot}
+}
+
+func (s *BroadcastSender) Send(ctx context.Context, chatID int64, text string) error {
+	// telebot не принимает контекст, поэтому отменённую рассылку проверяем до отправки
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err := s.bot.Send(tb.ChatID(chatID), text)
+
+	var flood tb.FloodError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, tb.ErrBlockedByUser), errors.Is(err, tb.ErrUserIsDeactivated), errors.Is(err, tb.ErrChatNotFound):
+		return broadcast.ErrBlocked
+	case errors.As(err, &flood):
+		return &broadcast.RetryError{After: time.Duration(flood.RetryAfter) * time.Second}
+	}
+	return err
+}
+
+func (h *Handler) onBroadcast(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	if h.broadcast == nil {
+		return h.reply(ctx, c, "broadcast.disabled")
+	}
+	text := c.Message().Payload
+	if text == "" {
+		return h.reply(ctx, c, "broadcast.usage")
+	}
+
+	job, err := h.broadcast.Start(ctx, text, c.Sender().ID)
+	switch {
+	case errors.Is(err, broadcast.ErrActive):
+		return h.reply(ctx, c, "broadcast.active", job.ID)
+	case err != nil:
+		log.Printf("broadcast.Start: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.reply(ctx, c, "broadcast.started", job.ID)
+}
+
+func (h *Handler) onBroadcastPause(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	if h.broadcast == nil {
+		return h.reply(ctx, c, "broadcast.disabled")
+	}
+	job, err := h.broadcast.Pause(ctx)
+	switch {
+	case errors.Is(err, broadcast.ErrNoJob), errors.Is(err, broadcast.ErrNotRunning):
+		return h.reply(ctx, c, "broadcast.not_running")
+	case err != nil:
+		log.Printf("broadcast.Pause: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.broadcastStatus(ctx, c, "broadcast.paused", job)
+}
+
+func (h *Handler) onBroadcastResume(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	if h.broadcast == nil {
+		return h.reply(ctx, c, "broadcast.disabled")
+	}
+	job, err := h.broadcast.Resume(ctx)
+	switch {
+	case errors.Is(err, broadcast.ErrNoJob), errors.Is(err, broadcast.ErrNotPaused):
+		return h.reply(ctx, c, "broadcast.not_paused")
+	case err != nil:
+		log.Printf("broadcast.Resume: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.broadcastStatus(ctx, c, "broadcast.resumed", job)
+}
+
+func (h *Handler) onBroadcastStatus(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	if h.broadcast == nil {
+		return h.reply(ctx, c, "broadcast.disabled")
+	}
+	job, err := h.broadcast.Status(ctx)
+	switch {
+	case errors.Is(err, broadcast.ErrNoJob):
+		return h.reply(ctx, c, "broadcast.none")
+	case err != nil:
+		log.Printf("broadcast.Status: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.broadcastStatus(ctx, c, "broadcast.status", job)
+}
+
+// broadcastStatus отвечает сообщением key с id, состоянием и счётчиками рассылки.
+func (h *Handler) broadcastStatus(ctx context.Context, c tb.Context, key string, job *broadcast.Job) error {
+	lang := h.core.Lang(ctx, c.Sender().ID)
+	return c.Send(i18n.T(lang, key, job.ID, i18n.T(lang, "broadcast.state."+string(job.Status)), job.Sent, job.Blocked, job.Failed))
+}
@@ -0,0 +1,831 @@
+package tg_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"app/notifier/internal"
+	"app/notifier/internal/broadcast"
+	"app/notifier/internal/fake"
+	"app/notifier/internal/i18n"
+	"app/notifier/internal/tg"
+	userpb "app/user/proto"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+var (
+	_ internal.UserClient  = (*fake.Users)(nil)
+	_ internal.MatchClient = (*fake.Matches)(nil)
+)
+
+// harness гоняет апдейты через настоящие Handler и Core; снаружи — только подделки.
+type harness struct {
+	t       *testing.T
+	api     *fake.BotAPI
+	bot     *tb.Bot
+	users   *fake.Users
+	matches *fake.Matches
+	bc      *broadcast.Broadcaster
+}
+
+// newHarness собирает бота; admins получают доступ к админским командам.
+func newHarness(t *testing.T, admins ...int64) *harness {
+	t.Helper()
+	return newLimitedHarness(t, nil, admins...)
+}
+
+// newLimitedHarness — то же, но апдейты проходят через limiter.
+func newLimitedHarness(t *testing.T, limiter tg.Limiter, admins ...int64) *harness {
+	t.Helper()
+
+	api := fake.NewBotAPI()
+	t.Cleanup(api.Close)
+
+	users := fake.NewUsers()
+	matches := fake.NewMatches(users)
+	core := internal.NewCore(users, matches, internal.NewMemorySessionStore(time.Hour), internal.NewMemoryBanCache(), admins)
+
+	bot, err := tb.NewBot(tb.Settings{
+		URL:   api.URL(),
+		Token: fake.BotToken,
+		// обработчики выполняются сразу, поэтому ответы бота видны после ProcessUpdate
+		Synchronous: true,
+		OnError: func(err error, _ tb.Context) {
+			t.Errorf("bot error: %v", err)
+		},
+	})
+	if err != nil {
+		t.Fatalf("tb.NewBot: %v", err)
+	}
+	// рассылка без ограничения частоты, чтобы тест не ждал
+	bc := broadcast.New(users, tg.NewBroadcastSender(bot), broadcast.NewMemoryStore(), 1000)
+	t.Cleanup(bc.Stop)
+	tg.NewHandler(bot, core, limiter, bc).Register()
+
+	t.Cleanup(func() {
+		if m := api.Unhandled(); len(m) > 0 {
+			t.Errorf("bot called methods the fake doesn't support: %v", m)
+		}
+	})
+	return &harness{t: t, api: api, bot: bot, users: users, matches: matches, bc: bc}
+}
+
+func (h *harness) send(u fake.User, text string) []fake.Sent {
+	h.bot.ProcessUpdate(h.api.TextUpdate(u, text))
+	return h.api.Take(u.ID)
+}
+
+func (h *harness) sendPhoto(u fake.User, data []byte) []fake.Sent {
+	h.bot.ProcessUpdate(h.api.PhotoUpdate(u, data))
+	return h.api.Take(u.ID)
+}
+
+func (h *harness) sendVoice(u fake.User, data []byte, duration int) []fake.Sent {
+	h.bot.ProcessUpdate(h.api.VoiceUpdate(u, data, duration))
+	return h.api.Take(u.ID)
+}
+
+func (h *harness) sendVideoNote(u fake.User, data []byte, duration int) []fake.Sent {
+	h.bot.ProcessUpdate(h.api.VideoNoteUpdate(u, data, duration))
+	return h.api.Take(u.ID)
+}
+
+func (h *harness) sendLocation(u fake.User, lat, lon float64) []fake.Sent {
+	h.bot.ProcessUpdate(h.api.LocationUpdate(u, lat, lon))
+	return h.api.Take(u.ID)
+}
+
+func (h *harness) tap(u fake.User, data string) []fake.Sent {
+	h.t.Helper()
+	upd, err := h.api.CallbackUpdate(u, data)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	h.bot.ProcessUpdate(upd)
+	return h.api.Take(u.ID)
+}
+
+// expect проверяет, что бот ответил ровно одним сообщением с текстом want.
+func (h *harness) expect(got []fake.Sent, want string) fake.Sent {
+	h.t.Helper()
+	if len(got) != 1 {
+		h.t.Fatalf("want 1 message %q, got %d: %+v", want, len(got), got)
+	}
+	if got[0].Text != want {
+		h.t.Fatalf("message text:\n got: %q\nwant: %q", got[0].Text, want)
+	}
+	return got[0]
+}
+
+func en(key string, args ...any) string {
+	return i18n.Render("en", i18n.M(key, args...))
+}
+
+func enMenu(key string) string {
+	return en(key, i18n.M("menu.items"))
+}
+
+func TestConversation_OnboardingToMatch(t *testing.T) {
+	h := newHarness(t)
+
+	bob := h.users.Put(&userpb.User{
+		TelegramId:  2002,
+		Username:    "Bob",
+		Age:         28,
+		Gender:      "Девушка",
+		Location:    "Berlin",
+		Description: "Climbing and coffee",
+		IsVisible:   true,
+	}, "https://photos.test/bob-1.jpg", "https://photos.test/bob-2.jpg")
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+
+	// анкета
+	h.expect(h.send(alice, "/start"), en("start.new"))
+	h.expect(h.send(alice, "Alice"), en("ask.age"))
+	h.expect(h.send(alice, "many"), en("ask.age.invalid"))
+	h.expect(h.send(alice, "27"), en("ask.city"))
+	msg := h.expect(h.send(alice, "Berlin"), en("ask.gender"))
+	if !msg.HasButton(tg.ActMale) || !msg.HasButton(tg.ActFemale) {
+		t.Fatalf("gender question has no gender buttons: %+v", msg.Buttons)
+	}
+	h.expect(h.tap(alice, tg.ActMale), en("ask.desc"))
+	h.expect(h.send(alice, "Backend developer"), en("ask.photo", 5))
+
+	// фото скачивается через getFile и уходит в user service
+	msg = h.expect(h.sendPhoto(alice, []byte("jpeg bytes")), en("photo.more", 1, 5))
+	if !msg.HasButton(tg.ActPhotos + ":done") {
+		t.Fatalf("photo reply has no Done button: %+v", msg.Buttons)
+	}
+	msg = h.expect(h.tap(alice, tg.ActPhotos+":done"), en("ask.intro", 60))
+	if !msg.HasButton(tg.ActIntro + ":skip") {
+		t.Fatalf("intro question has no Skip button: %+v", msg.Buttons)
+	}
+	msg = h.expect(h.tap(alice, tg.ActIntro+":skip"), enMenu("profile.saved"))
+	if len(msg.Keyboard) == 0 || msg.Keyboard[0][0] != "1" {
+		t.Fatalf("menu keyboard is missing: %+v", msg.Keyboard)
+	}
+
+	me, err := h.users.GetByTelegramID(t.Context(), alice.ID)
+	if err != nil {
+		t.Fatalf("profile was not created: %v", err)
+	}
+	if me.Username != "Alice" || me.Age != 27 || me.Location != "Berlin" || me.Gender != "Парень" {
+		t.Fatalf("unexpected profile: %+v", me)
+	}
+	if len(me.Photos) != 1 {
+		t.Fatalf("want 1 photo, got %d", len(me.Photos))
+	}
+
+	// Bob уже лайкнул Alice — её лайк в ответ даёт совпадение
+	if err := h.matches.Like(t.Context(), bob.Id, me.Id, true, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	got := h.send(alice, "1")
+	if len(got) != 2 {
+		t.Fatalf("want album and actions message, got %d: %+v", len(got), got)
+	}
+	if got[0].Method != "sendMediaGroup" || len(got[0].Photos) != 2 || !strings.HasPrefix(got[0].Text, "Bob, 28, Berlin") {
+		t.Fatalf("unexpected candidate card: %+v", got[0])
+	}
+	likeBob := tg.ActLike + ":" + strconv.FormatInt(bob.Id, 10)
+	if got[1].Text != en("card.actions") || !got[1].HasButton(likeBob) {
+		t.Fatalf("card actions have no like button: %+v", got[1])
+	}
+
+	// у Bob два фото: карточка совпадения — альбом, ссылка на чат — отдельным сообщением
+	got = h.tap(alice, likeBob)
+	if len(got) != 3 {
+		t.Fatalf("want match album, its link and end of browsing, got %d: %+v", len(got), got)
+	}
+	if !strings.HasPrefix(got[0].Text, en("match.card", "Bob")) {
+		t.Fatalf("alice didn't get the match card: %+v", got[0])
+	}
+	if len(got[1].Buttons) != 1 || got[1].Buttons[0][0].URL != "tg://user?id=2002" {
+		t.Fatalf("match card has no link to bob: %+v", got[1])
+	}
+	if got[2].Text != enMenu("browse.finished") {
+		t.Fatalf("unexpected reply after the last candidate: %q", got[2].Text)
+	}
+
+	// Bob с ботом ещё не разговаривал, поэтому получает язык по умолчанию
+	got = h.api.Take(bob.TelegramId)
+	if len(got) != 1 || !strings.HasPrefix(got[0].Text, i18n.Render(i18n.Default, i18n.M("match.card", "Alice"))) {
+		t.Fatalf("bob didn't get the match card: %+v", got)
+	}
+	if len(got[0].Buttons) != 1 || got[0].Buttons[0][0].URL != "https://t.me/alice" {
+		t.Fatalf("match card has no link to alice: %+v", got[0].Buttons)
+	}
+
+	// на каждое нажатие бот отвечает и убирает кнопки со старого сообщения
+	if n := len(h.api.Answered()); n != 4 {
+		t.Fatalf("want 4 answered callbacks, got %d", n)
+	}
+	if n := len(h.api.Edited()); n != 4 {
+		t.Fatalf("want 4 edited keyboards, got %d", n)
+	}
+}
+
+func TestConversation_ReportSkipsToNextCandidate(t *testing.T) {
+	h := newHarness(t)
+
+	var ids []int64
+	for i, name := range []string{"Carl", "Bob"} {
+		u := h.users.Put(&userpb.User{
+			TelegramId: int64(2000 + i),
+			Username:   name,
+			Age:        30,
+			Gender:     "Девушка",
+			Location:   "Berlin",
+			IsVisible:  true,
+		}, "https://photos.test/"+name+".jpg")
+		ids = append(ids, u.Id)
+	}
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+
+	// кандидаты показываются в порядке выдачи: сначала Carl
+	card := h.send(alice, "1")
+	if len(card) != 1 || card[0].Method != "sendPhoto" || !strings.HasPrefix(card[0].Text, "Carl") {
+		t.Fatalf("want Carl's single-photo card, got %+v", card)
+	}
+	carl := strconv.FormatInt(ids[0], 10)
+
+	h.expect(h.tap(alice, tg.ActReport+":"+carl), en("report.choose"))
+	h.expect(h.tap(alice, tg.ActReport+":"+carl+":fake"), en("report.comment"))
+
+	got := h.send(alice, "Stolen photos")
+	if len(got) != 2 || got[0].Text != en("report.sent") || !strings.HasPrefix(got[1].Text, "Bob") {
+		t.Fatalf("want report confirmation and Bob's card, got %+v", got)
+	}
+
+	reports := h.matches.Reports()
+	if len(reports) != 1 || reports[0].Reported != ids[0] || reports[0].Reason != "fake" || reports[0].Comment != "Stolen photos" {
+		t.Fatalf("unexpected reports: %+v", reports)
+	}
+
+	// кнопки прошлой карточки уже убраны — нажать их нельзя
+	if _, err := h.api.CallbackUpdate(alice, tg.ActLike+":"+carl); err == nil {
+		t.Fatal("like button of the reported card is still pressable")
+	}
+}
+
+func TestConversation_AdminBan(t *testing.T) {
+	admin := fake.User{ID: 9001, FirstName: "Admin", Lang: "en"}
+	h := newHarness(t, admin.ID)
+
+	carol := fake.User{ID: 3003, FirstName: "Carol", Lang: "en"}
+	u := h.users.Put(&userpb.User{TelegramId: carol.ID, Username: "Carol", Age: 25, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carol.jpg")
+
+	// для обычного пользователя админская команда — просто текст вне анкеты
+	h.expect(h.send(carol, "/start"), enMenu("menu.choose"))
+	h.expect(h.send(carol, "/stats"), en("menu.hint"))
+
+	h.expect(h.send(admin, "/ban"), en("admin.usage", "/ban"))
+	h.expect(h.send(admin, "/ban 404"), en("admin.not_found", 404))
+	h.expect(h.send(admin, "/ban 3003"), en("admin.banned", "Carol", 3003))
+
+	got, err := h.users.GetByID(t.Context(), u.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsBanned || got.IsVisible {
+		t.Fatalf("banned profile must be hidden: %+v", got)
+	}
+
+	h.expect(h.send(carol, "/start"), en("account.banned"))
+	h.expect(h.send(carol, "/resume"), en("account.banned"))
+
+	card := h.send(admin, "/user 3003")
+	if len(card) != 1 || card[0].Method != "sendPhoto" || !strings.Contains(card[0].Text, en("admin.status.banned")) {
+		t.Fatalf("want Carol's card with banned status, got %+v", card)
+	}
+
+	stats := h.send(admin, "/stats")
+	if len(stats) != 1 || !strings.Contains(stats[0].Text, "Registrations: 1\n") || !strings.Contains(stats[0].Text, "visible in search: 0, banned: 1") {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	h.expect(h.send(admin, "/unban 3003"), en("admin.unbanned", "Carol", 3003))
+	h.expect(h.send(carol, "/start"), enMenu("menu.choose"))
+}
+
+func TestConversation_Broadcast(t *testing.T) {
+	admin := fake.User{ID: 9001, FirstName: "Admin", Lang: "en"}
+	h := newHarness(t, admin.ID)
+
+	var ids []int64
+	for i, name := range []string{"Bob", "Carl", "Dan"} {
+		u := h.users.Put(&userpb.User{TelegramId: int64(2000 + i), Username: name, Age: 30, Gender: "Парень", Location: "Berlin"},
+			"https://photos.test/"+name+".jpg")
+		ids = append(ids, u.Id)
+	}
+	carl := fake.User{ID: 2001, FirstName: "Carl", Lang: "en"}
+	h.api.SetBlocked(carl.ID, true)
+
+	h.expect(h.send(admin, "/broadcast"), en("broadcast.usage"))
+	h.expect(h.send(admin, "/broadcast_status"), en("broadcast.none"))
+
+	got := h.send(admin, "/broadcast New feature: voice intros!")
+	job, err := h.bc.Status(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.expect(got, en("broadcast.started", job.ID))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != broadcast.StatusDone {
+		if time.Now().After(deadline) {
+			t.Fatalf("broadcast didn't finish: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if job, err = h.bc.Status(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, chatID := range []int64{2000, 2002} {
+		got := h.api.Take(chatID)
+		if len(got) != 1 || got[0].Text != "New feature: voice intros!" {
+			t.Fatalf("chat %d: want the announcement, got %+v", chatID, got)
+		}
+	}
+	h.expect(h.send(admin, "/broadcast_status"), en("broadcast.status", job.ID, en("broadcast.state.done"), 2, 1, 0))
+
+	// заблокировавший бота отмечен и в следующие рассылки не попадает
+	u, err := h.users.GetByID(t.Context(), ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !u.BotBlocked {
+		t.Fatal("carl is not marked as having blocked the bot")
+	}
+
+	// после /start отметка снимается
+	h.api.SetBlocked(carl.ID, false)
+	h.expect(h.send(carl, "/start"), enMenu("menu.choose"))
+	if u, _ = h.users.GetByID(t.Context(), ids[1]); u.BotBlocked {
+		t.Fatal("carl is still marked as having blocked the bot after /start")
+	}
+}
+
+func TestConversation_MatchContactLink(t *testing.T) {
+	h := newHarness(t)
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	carl := fake.User{ID: 2001, FirstName: "Carl", Lang: "en", NoUsername: true}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	c := h.users.Put(&userpb.User{TelegramId: carl.ID, Username: "Carl", Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carl.jpg")
+	// у Carl нет имени пользователя, а ссылки по id он запретил
+	h.api.SetPrivate(carl.ID, true)
+
+	h.expect(h.send(carl, "/start"), enMenu("menu.choose"))
+	h.send(carl, "1")
+	h.expect(h.tap(carl, tg.ActLike+":"+strconv.FormatInt(me.Id, 10)), enMenu("browse.finished"))
+	h.api.Take(alice.ID) // уведомление о лайке
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	h.send(alice, "1")
+	got := h.tap(alice, tg.ActLike+":"+strconv.FormatInt(c.Id, 10))
+	if len(got) != 2 || !strings.HasPrefix(got[0].Text, en("match.card", "Carl")) {
+		t.Fatalf("alice didn't get the match card: %+v", got)
+	}
+	if len(got[0].Buttons) != 0 {
+		t.Fatalf("rejected contact button must be dropped: %+v", got[0].Buttons)
+	}
+
+	// имя Alice бот запомнил на /start, ссылка по нему работает при любой приватности
+	got = h.api.Take(carl.ID)
+	if len(got) != 1 || !strings.HasPrefix(got[0].Text, en("match.card", "Alice")) {
+		t.Fatalf("carl didn't get the match card: %+v", got)
+	}
+	if len(got[0].Buttons) != 1 || got[0].Buttons[0][0].URL != "https://t.me/alice" {
+		t.Fatalf("match card has no t.me link to alice: %+v", got[0].Buttons)
+	}
+}
+
+func TestConversation_LikeWithMessage(t *testing.T) {
+	h := newHarness(t)
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	carl := fake.User{ID: 2001, FirstName: "Carl", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	c := h.users.Put(&userpb.User{TelegramId: carl.ID, Username: "Carl", Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carl.jpg")
+	id := strconv.FormatInt(c.Id, 10)
+
+	h.expect(h.send(carl, "/start"), enMenu("menu.choose"))
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	card := h.send(alice, "1")
+	if len(card) != 1 || !card[0].HasButton(tg.ActLikeMsg+":"+id) {
+		t.Fatalf("card has no message button: %+v", card)
+	}
+
+	// отмена возвращает кнопки оценки
+	h.expect(h.tap(alice, tg.ActLikeMsg+":"+id), en("like.message.ask", 200))
+	msg := h.expect(h.tap(alice, tg.ActLikeMsg+":cancel"), en("like.message.cancelled"))
+	if !msg.HasButton(tg.ActLike + ":" + id) {
+		t.Fatalf("rating buttons are missing after cancel: %+v", msg.Buttons)
+	}
+
+	h.expect(h.tap(alice, tg.ActLikeMsg+":"+id), en("like.message.ask", 200))
+	h.expect(h.send(alice, strings.Repeat("a", 201)), en("like.message.too_long", 200))
+	h.expect(h.send(alice, "  Love your photos!  "), enMenu("browse.finished"))
+
+	got := h.api.Take(carl.ID)
+	if len(got) != 1 || got[0].Text != en("like.received.message", "Love your photos!") {
+		t.Fatalf("carl didn't get the like with the message: %+v", got)
+	}
+
+	// во входящих сообщение показывается под анкетой
+	card = h.send(carl, "5")
+	if len(card) != 1 || card[0].Text != en("card.message", "Alice, 27, Berlin\n", "Love your photos!") {
+		t.Fatalf("inbox card has no message: %+v", card)
+	}
+	if !card[0].HasButton(tg.ActLike + ":" + strconv.FormatInt(me.Id, 10)) {
+		t.Fatalf("inbox card has no like button: %+v", card[0].Buttons)
+	}
+}
+
+func TestConversation_Location(t *testing.T) {
+	h := newHarness(t)
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+
+	h.expect(h.send(alice, "/start"), en("start.new"))
+	h.expect(h.send(alice, "Alice"), en("ask.age"))
+	msg := h.expect(h.send(alice, "27"), en("ask.city"))
+	if len(msg.Keyboard) == 0 || msg.Keyboard[0][0] != en("btn.share_location") {
+		t.Fatalf("city question has no location button: %+v", msg.Keyboard)
+	}
+
+	// геопозиция не заменяет город: его всё равно спрашиваем для анкеты
+	msg = h.expect(h.sendLocation(alice, 52.52, 13.405), en("location.saved"))
+	if !msg.RemoveKeyboard {
+		t.Fatalf("location button was not removed: %+v", msg)
+	}
+	h.expect(h.send(alice, "Berlin"), en("ask.gender"))
+	h.expect(h.tap(alice, tg.ActMale), en("ask.desc"))
+	h.expect(h.send(alice, "Backend developer"), en("ask.photo", 5))
+	h.expect(h.sendPhoto(alice, []byte("jpeg bytes")), en("photo.more", 1, 5))
+	h.expect(h.tap(alice, tg.ActPhotos+":done"), en("ask.intro", 60))
+	h.expect(h.tap(alice, tg.ActIntro+":skip"), enMenu("profile.saved"))
+
+	me, err := h.users.GetByTelegramID(t.Context(), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Telegram отдаёт координаты как float32
+	if me.Location != "Berlin" || me.Geo.GetLatitude() != float64(float32(52.52)) || me.Geo.GetLongitude() != float64(float32(13.405)) {
+		t.Fatalf("unexpected profile location: %q %+v", me.Location, me.Geo)
+	}
+
+	// вне регистрации и редактирования геопозиция не нужна
+	h.expect(h.sendLocation(alice, 48.85, 2.35), en("location.unexpected"))
+
+	h.send(alice, "3")
+	h.expect(h.tap(alice, tg.ActEdit+":geo"), en("edit.ask.geo", i18n.M("geo.set")))
+	got := h.sendLocation(alice, 48.85, 2.35)
+	if len(got) != 1 || !strings.HasPrefix(got[0].Text, en("edit.saved", "")) {
+		t.Fatalf("unexpected reply to the new location: %+v", got)
+	}
+	me, _ = h.users.GetByTelegramID(t.Context(), alice.ID)
+	if me.Geo.GetLatitude() != float64(float32(48.85)) || me.Location != "Berlin" {
+		t.Fatalf("location was not updated: %q %+v", me.Location, me.Geo)
+	}
+}
+
+func TestConversation_SearchSettings(t *testing.T) {
+	h := newHarness(t)
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	h.users.Put(&userpb.User{TelegramId: 2001, Username: "Dan", Age: 30, Gender: "Парень", Location: "Munich", IsVisible: true},
+		"https://photos.test/dan.jpg")
+	h.users.Put(&userpb.User{TelegramId: 2002, Username: "Eve", Age: 45, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/eve.jpg")
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	current := func(gender, age, where i18n.Msg) i18n.Msg {
+		return i18n.M("search.current", gender, age, where)
+	}
+	msg := h.expect(h.send(alice, "7"), en("search.choose",
+		current(i18n.M("search.gender.default"), i18n.M("search.age.default"), i18n.M("search.where.city", "Berlin"))))
+	if !msg.HasButton(tg.ActSearch + ":gender") {
+		t.Fatalf("search settings have no buttons: %+v", msg.Buttons)
+	}
+
+	h.expect(h.tap(alice, tg.ActSearch+":gender"), en("search.ask.gender"))
+	h.tap(alice, tg.ActSearch+":gender:any")
+	h.expect(h.tap(alice, tg.ActSearch+":radius"), en("search.ask.radius"))
+	h.tap(alice, tg.ActSearch+":radius:any")
+	h.expect(h.tap(alice, tg.ActSearch+":age"), en("search.ask.age", 18, 99))
+	h.expect(h.send(alice, "old enough"), en("search.age.invalid", 18, 99))
+	h.expect(h.send(alice, "35-25"), en("search.age.invalid", 18, 99))
+	h.expect(h.send(alice, "25 - 35"), en("search.saved",
+		current(i18n.M("search.gender.any"), i18n.M("search.age.range", 25, 35), i18n.M("search.where.any"))))
+
+	u, _ := h.users.GetByID(t.Context(), me.Id)
+	if p := u.GetSearchPrefs(); p.GetGender() != "any" || p.GetMinAge() != 25 || p.GetMaxAge() != 35 || !p.GetAnyCity() {
+		t.Fatalf("unexpected stored prefs: %+v", p)
+	}
+
+	// Eve не подходит по возрасту, Dan — из другого города, но город больше не ограничен
+	h.expect(h.tap(alice, tg.ActSearch+":done"), enMenu("menu.choose"))
+	card := h.send(alice, "1")
+	if len(card) != 1 || !strings.HasPrefix(card[0].Text, "Dan, 30, Munich") {
+		t.Fatalf("want Dan's card, got %+v", card)
+	}
+}
+
+func TestConversation_Referral(t *testing.T) {
+	admin := fake.User{ID: 9001, FirstName: "Admin", Lang: "en"}
+	h := newHarness(t, admin.ID)
+
+	bob := h.users.Put(&userpb.User{TelegramId: 2001, Username: "Bob", Age: 30, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/bob.jpg")
+	carl := h.users.Put(&userpb.User{TelegramId: 2002, Username: "Carl", Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carl.jpg")
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	h.expect(h.send(alice, "/start ref_"+strconv.FormatInt(bob.Id, 10)), en("start.new"))
+	h.expect(h.send(alice, "Alice"), en("ask.age"))
+	h.expect(h.send(alice, "27"), en("ask.city"))
+	h.expect(h.send(alice, "Berlin"), en("ask.gender"))
+	h.expect(h.tap(alice, tg.ActMale), en("ask.desc"))
+	h.expect(h.send(alice, "Backend developer"), en("ask.photo", 5))
+	h.expect(h.sendPhoto(alice, []byte("jpeg bytes")), en("photo.more", 1, 5))
+	h.expect(h.tap(alice, tg.ActPhotos+":done"), en("ask.intro", 60))
+	h.expect(h.tap(alice, tg.ActIntro+":skip"), enMenu("profile.saved"))
+
+	me, err := h.users.GetByTelegramID(t.Context(), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.ReferrerId != bob.Id || me.Campaign != "referral" || me.Activated {
+		t.Fatalf("unexpected referral fields: %+v", me)
+	}
+
+	// повторный /start с другой меткой не перезаписывает источник
+	h.expect(h.send(alice, "/start summer_ads"), enMenu("menu.choose"))
+
+	// первый лайк активирует пользователя
+	h.send(alice, "1")
+	h.expect(h.tap(alice, tg.ActLike+":"+strconv.FormatInt(carl.Id, 10)), enMenu("browse.finished"))
+	me, _ = h.users.GetByTelegramID(t.Context(), alice.ID)
+	if me.Campaign != "referral" || !me.Activated {
+		t.Fatalf("user is not activated: %+v", me)
+	}
+
+	// недопустимый payload игнорируется
+	dan := fake.User{ID: 1002, FirstName: "Dan", Lang: "en"}
+	h.expect(h.send(dan, "/start no such campaign!"), en("start.new"))
+
+	h.expect(h.send(admin, "/campaigns 0"), en("admin.campaigns.usage", 365))
+	h.expect(h.send(admin, "/campaigns"), en("admin.campaigns", 30, strings.Join([]string{
+		en("admin.campaign.line", en("admin.campaign.organic"), 2, 0, 0),
+		en("admin.campaign.line", "referral", 1, 1, 100),
+	}, "\n")))
+}
+
+func TestConversation_UndoSwipe(t *testing.T) {
+	h := newHarness(t)
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	dan := h.users.Put(&userpb.User{TelegramId: 2002, Username: "Dan", Age: 28, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/dan.jpg")
+	carl := h.users.Put(&userpb.User{TelegramId: 2001, Username: "Carl", Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carl.jpg")
+	carlID, danID := strconv.FormatInt(carl.Id, 10), strconv.FormatInt(dan.Id, 10)
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	card := h.send(alice, "1")
+	if len(card) != 1 || !card[0].HasButton(tg.ActDislike+":"+danID) || card[0].HasButton(tg.ActUndo+":"+danID) {
+		t.Fatalf("first card must be Dan's without undo: %+v", card)
+	}
+
+	card = h.tap(alice, tg.ActDislike+":"+danID)
+	if len(card) != 1 || !card[0].HasButton(tg.ActLike+":"+carlID) || !card[0].HasButton(tg.ActUndo+":"+danID) {
+		t.Fatalf("Carl's card has no undo for Dan: %+v", card)
+	}
+
+	// отмена возвращает анкету Dan, а Carl снова ждёт в очереди
+	card = h.tap(alice, tg.ActUndo+":"+danID)
+	if len(card) != 1 || !card[0].HasButton(tg.ActLike+":"+danID) || card[0].HasButton(tg.ActUndo+":"+danID) {
+		t.Fatalf("undo didn't bring Dan back: %+v", card)
+	}
+	if h.matches.Rated(me.Id, dan.Id) {
+		t.Fatal("the dislike was not removed")
+	}
+
+	// после новой оценки отменить можно только её
+	card = h.tap(alice, tg.ActLike+":"+danID)
+	if len(card) != 1 || !card[0].HasButton(tg.ActUndo+":"+danID) {
+		t.Fatalf("Carl's card has no undo for the like: %+v", card)
+	}
+	h.expect(h.tap(alice, tg.ActLike+":"+carlID), enMenu("browse.finished"))
+
+	// отменённый дизлайк никак не виден, лайк приходит один раз
+	got := h.api.Take(dan.TelegramId)
+	if len(got) != 1 || !got[0].HasButton(tg.ActLiker+":"+strconv.FormatInt(me.Id, 10)) {
+		t.Fatalf("dan didn't get the like: %+v", got)
+	}
+}
+
+func TestConversation_CandidatePages(t *testing.T) {
+	h := newHarness(t)
+	h.matches.PageSize = 2
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	ids := map[string]string{}
+	for i, name := range []string{"Ann", "Bea", "Cleo", "Dora", "Eve"} {
+		u := h.users.Put(&userpb.User{TelegramId: int64(2000 + i), Username: name, Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+			"https://photos.test/"+name+".jpg")
+		ids[name] = strconv.FormatInt(u.Id, 10)
+	}
+
+	expectCard := func(got []fake.Sent, name string) {
+		t.Helper()
+		if len(got) != 1 || !strings.HasPrefix(got[0].Text, name+",") {
+			t.Fatalf("want %s's card, got %+v", name, got)
+		}
+	}
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	expectCard(h.send(alice, "1"), "Ann")
+	expectCard(h.tap(alice, tg.ActDislike+":"+ids["Ann"]), "Bea")
+
+	// после перерыва просмотр продолжается, показанные анкеты не повторяются
+	h.expect(h.tap(alice, tg.ActSleep), enMenu("browse.sleep"))
+	expectCard(h.send(alice, "1"), "Cleo")
+
+	// следующие страницы подгружаются по мере просмотра
+	expectCard(h.tap(alice, tg.ActDislike+":"+ids["Cleo"]), "Dora")
+	expectCard(h.tap(alice, tg.ActLike+":"+ids["Dora"]), "Eve")
+	h.expect(h.tap(alice, tg.ActDislike+":"+ids["Eve"]), enMenu("browse.finished"))
+}
+
+func TestConversation_InboxKeepsCandidates(t *testing.T) {
+	h := newHarness(t)
+	h.matches.PageSize = 2
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	ids := map[string]string{}
+	for i, name := range []string{"Ann", "Bea", "Cleo", "Dora", "Eve"} {
+		u := h.users.Put(&userpb.User{TelegramId: int64(2000 + i), Username: name, Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+			"https://photos.test/"+name+".jpg")
+		ids[name] = strconv.FormatInt(u.Id, 10)
+	}
+	// Zoe из другого города в выдачу не попадает, но лайкнула Alice
+	zoe := h.users.Put(&userpb.User{TelegramId: 3001, Username: "Zoe", Age: 29, Gender: "Девушка", Location: "Paris", IsVisible: true},
+		"https://photos.test/zoe.jpg")
+	h.matches.Like(t.Context(), zoe.Id, me.Id, true, "")
+
+	expectCard := func(got []fake.Sent, name string) {
+		t.Helper()
+		if len(got) != 1 || !strings.HasPrefix(got[0].Text, name+",") {
+			t.Fatalf("want %s's card, got %+v", name, got)
+		}
+	}
+
+	// первая же карточка подгружает две страницы: курсор match service уже за Dora
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	expectCard(h.send(alice, "1"), "Ann")
+	expectCard(h.tap(alice, tg.ActDislike+":"+ids["Ann"]), "Bea")
+	h.expect(h.tap(alice, tg.ActSleep), enMenu("browse.sleep"))
+
+	expectCard(h.send(alice, "5"), "Zoe")
+	h.expect(h.tap(alice, tg.ActSleep), enMenu("browse.sleep"))
+
+	// после входящих выдача продолжается с того же места, ни одна анкета не пропала
+	expectCard(h.send(alice, "1"), "Cleo")
+	expectCard(h.tap(alice, tg.ActDislike+":"+ids["Cleo"]), "Dora")
+	expectCard(h.tap(alice, tg.ActDislike+":"+ids["Dora"]), "Eve")
+	h.expect(h.tap(alice, tg.ActDislike+":"+ids["Eve"]), enMenu("browse.finished"))
+}
+
+func TestConversation_Intro(t *testing.T) {
+	h := newHarness(t)
+
+	bob := h.users.Put(&userpb.User{
+		TelegramId: 2002,
+		Username:   "Bob",
+		Age:        28,
+		Gender:     "Девушка",
+		Location:   "Berlin",
+		IsVisible:  true,
+		Intro:      &userpb.Intro{Kind: "voice", Url: "https://media.test/bob.ogg", Duration: 7, FileId: "bob-voice"},
+	}, "https://photos.test/bob.jpg")
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+
+	h.expect(h.send(alice, "/start"), en("start.new"))
+	h.expect(h.send(alice, "Alice"), en("ask.age"))
+	h.expect(h.send(alice, "27"), en("ask.city"))
+	h.expect(h.send(alice, "Berlin"), en("ask.gender"))
+	h.expect(h.tap(alice, tg.ActMale), en("ask.desc"))
+	h.expect(h.send(alice, "Backend developer"), en("ask.photo", 5))
+
+	// интро не ждём, пока анкета не дошла до этого шага
+	h.expect(h.sendVoice(alice, []byte("ogg bytes"), 5), en("intro.unexpected"))
+
+	h.expect(h.sendPhoto(alice, []byte("jpeg bytes")), en("photo.more", 1, 5))
+	h.expect(h.tap(alice, tg.ActPhotos+":done"), en("ask.intro", 60))
+
+	// слишком длинное интро отклоняется до скачивания, шаг остаётся прежним
+	h.expect(h.sendVideoNote(alice, []byte("mp4 bytes"), 61), en("intro.invalid", 60, 3))
+	h.expect(h.sendVideoNote(alice, []byte("mp4 bytes"), 15), enMenu("profile.saved"))
+
+	me, err := h.users.GetByTelegramID(t.Context(), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.Intro.GetKind() != "video_note" || me.Intro.GetDuration() != 15 || me.Intro.GetFileId() == "" {
+		t.Fatalf("unexpected intro: %+v", me.Intro)
+	}
+
+	// своя анкета показывается с кружком, по file_id
+	got := h.send(alice, "2")
+	if len(got) != 2 || got[1].Method != "sendVideoNote" || got[1].Media != me.Intro.GetFileId() {
+		t.Fatalf("own profile has no video note: %+v", got)
+	}
+
+	// голосовое кандидата идёт следом за карточкой
+	got = h.send(alice, "1")
+	if len(got) != 2 {
+		t.Fatalf("want candidate card and intro, got %d: %+v", len(got), got)
+	}
+	if got[0].Method != "sendPhoto" || !strings.HasPrefix(got[0].Text, "Bob, 28, Berlin") ||
+		!got[0].HasButton(tg.ActLike+":"+strconv.FormatInt(bob.Id, 10)) {
+		t.Fatalf("unexpected candidate card: %+v", got[0])
+	}
+	if got[1].Method != "sendVoice" || got[1].Media != "bob-voice" {
+		t.Fatalf("candidate intro was not sent: %+v", got[1])
+	}
+}
+
+func TestConversation_LikerCardChecksTheLike(t *testing.T) {
+	h := newHarness(t)
+	ctx := t.Context()
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+
+	// likedBy регистрирует пользователя, который лайкает Alice: ей приходит уведомление с кнопкой анкеты
+	likedBy := func(id int64, name string) *userpb.User {
+		t.Helper()
+		u := fake.User{ID: id, FirstName: name, Lang: "en"}
+		p := h.users.Put(&userpb.User{TelegramId: id, Username: name, Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+			"https://photos.test/"+name+".jpg")
+		h.expect(h.send(u, "/start"), enMenu("menu.choose"))
+		h.send(u, "1")
+		h.tap(u, tg.ActLike+":"+strconv.FormatInt(me.Id, 10))
+		if got := h.api.Take(alice.ID); len(got) != 1 {
+			t.Fatalf("want the like from %s, got %+v", name, got)
+		}
+		return p
+	}
+	liker := func(u *userpb.User) string { return tg.ActLiker + ":" + strconv.FormatInt(u.Id, 10) }
+
+	bob := likedBy(2001, "Bob")
+	got := h.tap(alice, liker(bob))
+	if len(got) != 1 || got[0].Text == en("liker.failed") {
+		t.Fatalf("want Bob's card, got %+v", got)
+	}
+
+	// скрытая анкета не показывается, даже если лайк стоит
+	carl := likedBy(2002, "Carl")
+	if err := h.users.ToggleVisibility(ctx, carl.Id, false); err != nil {
+		t.Fatal(err)
+	}
+	h.expect(h.tap(alice, liker(carl)), en("liker.failed"))
+
+	dan := likedBy(2003, "Dan")
+	if err := h.matches.UndoLike(ctx, dan.Id, me.Id); err != nil {
+		t.Fatal(err)
+	}
+	h.expect(h.tap(alice, liker(dan)), en("liker.failed"))
+
+	eve := likedBy(2004, "Eve")
+	if err := h.matches.Block(ctx, me.Id, eve.Id); err != nil {
+		t.Fatal(err)
+	}
+	h.expect(h.tap(alice, liker(eve)), en("liker.failed"))
+}
@@ -0,0 +1,22 @@
+package tg
+
+import (
+	"net/http"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+// SetClock подменяет часы ограничителя, чтобы тесты не ждали пополнения токенов.
+func (l *RateLimiter) SetClock(now func() time.Time) { l.now = now }
+
+// SetClock подменяет часы, по которым RedisRateLimiter пополняет ведра.
+func (l *RedisRateLimiter) SetClock(now func() time.Time) { l.now = now }
+
+// Handler отдаёт HTTP-обработчик вебхука без запуска сервера.
+func (p *WebhookPoller) Handler(dest chan<- tb.Update, stop <-chan struct{}) http.Handler {
+	return p.handler(dest, stop)
+}
+
+// Close освобождает порт, занятый NewWebhookPoller.
+func (p *WebhookPoller) Close() error { return p.lis.Close() }
@@ -3,29 +3,59 @@ package tg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"strings"
 	"time"
 
 	"app/notifier/internal"
+	"app/notifier/internal/broadcast"
+	"app/notifier/internal/i18n"
 
 	tb "gopkg.in/telebot.v4"
 )
 
 type Handler struct {
-	bot  *tb.Bot
-	core *internal.Core
+	bot       *tb.Bot
+	core      *internal.Core
+	limiter   Limiter
+	broadcast *broadcast.Broadcaster
 }
 
-func NewHandler(bot *tb.Bot, core *internal.Core) *Handler {
-	return &Handler{bot: bot, core: core}
+// NewHandler создаёт обработчик апдейтов; limiter == nil отключает ограничение частоты,
+// bc == nil — рассылки.
+func NewHandler(bot *tb.Bot, core *internal.Core, limiter Limiter, bc *broadcast.Broadcaster) *Handler {
+	return &Handler{bot: bot, core: core, limiter: limiter, broadcast: bc}
 }
 
 func (h *Handler) Register() {
+	h.bot.Use(h.rateLimit, h.banGate)
 	h.bot.Handle("/start", h.onStart)
+	h.bot.Handle("/language", h.onLanguage)
+	h.bot.Handle("/pause", func(c tb.Context) error { return h.onVisibility(c, false) })
+	h.bot.Handle("/resume", func(c tb.Context) error { return h.onVisibility(c, true) })
+	h.bot.Handle("/delete", h.onDelete)
+	h.bot.Handle("/timezone", h.onTimezone)
 	h.bot.Handle(tb.OnText, h.onText)
+
+	admin := h.bot.Group()
+	admin.Use(h.adminOnly)
+	admin.Handle("/stats", h.onStats)
+	admin.Handle("/user", h.onAdminUser)
+	admin.Handle("/campaigns", h.onCampaigns)
+	admin.Handle("/ban", func(c tb.Context) error { return h.onBan(c, true) })
+	admin.Handle("/unban", func(c tb.Context) error { return h.onBan(c, false) })
+	admin.Handle("/broadcast", h.onBroadcast)
+	admin.Handle("/broadcast_pause", h.onBroadcastPause)
+	admin.Handle("/broadcast_resume", h.onBroadcastResume)
+	admin.Handle("/broadcast_status", h.onBroadcastStatus)
+
 	h.bot.Handle(tb.OnPhoto, h.onPhoto)
+	h.bot.Handle(tb.OnVoice, h.onIntro)
+	h.bot.Handle(tb.OnVideoNote, h.onIntro)
+	h.bot.Handle(tb.OnLocation, h.onLocation)
+	h.bot.Handle(tb.OnCallback, h.onCallback)
 }
 
 const (
@@ -35,64 +65,124 @@ const (
 	maxPhotoSize = 8 << 20 // 8MB
 )
 
+// newContext создаёт контекст апдейта с таймаутом, языком и именем пользователя отправителя.
+func (h *Handler) newContext(c tb.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := i18n.WithLang(context.Background(), c.Sender().LanguageCode)
+	ctx = internal.WithTgUsername(ctx, c.Sender().Username)
+	return context.WithTimeout(ctx, timeout)
+}
+
+// reply отправляет локализованное сообщение без клавиатуры.
+func (h *Handler) reply(ctx context.Context, c tb.Context, key string, args ...any) error {
+	return c.Send(i18n.T(h.core.Lang(ctx, c.Sender().ID), key, args...))
+}
+
 func (h *Handler) onStart(c tb.Context) error {
-	ctx, cancel := context.WithTimeout(context.Background(), tmoShort)
+	ctx, cancel := h.newContext(c, tmoShort)
 	defer cancel()
 
-	out, err := h.core.OnStart(ctx, c.Sender().ID)
+	out, err := h.core.OnStart(ctx, c.Sender().ID, c.Message().Payload)
 	if err != nil {
 		log.Printf("core.OnStart: %v", err)
-		return c.Send("Что-то пошло не так. Попробуй ещё раз.")
+		return h.reply(ctx, c, "error.generic")
 	}
 	return h.render(c, out)
 }
 
-func (h *Handler) onText(c tb.Context) error {
-	txt := c.Text()
-
-	// Кнопки лайк/дизлайк/сон приходят как текст
-	if txt == "❤️" || txt == "👎" || txt == "💤" {
-		var action string
-		switch txt {
-		case "❤️":
-			action = "like"
-		case "👎":
-			action = "dislike"
-		case "💤":
-			action = "sleep"
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), tmoShort)
-		defer cancel()
-		out, err := h.core.OnCallback(ctx, c.Sender().ID, action)
-		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
-			log.Printf("core.OnCallback(%s): %v", action, err)
-			return c.Send("Действие не удалось. Попробуй ещё раз.")
-		}
-		return h.render(c, out)
+func (h *Handler) onLanguage(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.OnLanguage(ctx, c.Sender().ID, c.Message().Payload)
+	if err != nil {
+		log.Printf("core.OnLanguage: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onVisibility(c tb.Context, visible bool) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.OnVisibility(ctx, c.Sender().ID, visible)
+	if err != nil {
+		log.Printf("core.OnVisibility(%v): %v", visible, err)
+		return h.reply(ctx, c, "error.generic")
 	}
+	return h.render(c, out)
+}
 
-	// Остальной текст (меню 1/2/3, пол, ответы на вопросы анкеты)
-	ctx, cancel := context.WithTimeout(context.Background(), tmoText)
+func (h *Handler) onDelete(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
 	defer cancel()
-	out, err := h.core.OnText(ctx, c.Sender().ID, txt)
+
+	out, err := h.core.OnDelete(ctx, c.Sender().ID)
+	if err != nil {
+		log.Printf("core.OnDelete: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onTimezone(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	out, err := h.core.OnTimezone(ctx, c.Sender().ID, c.Message().Payload)
+	if err != nil {
+		log.Printf("core.OnTimezone: %v", err)
+		return h.reply(ctx, c, "error.generic")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onText(c tb.Context) error {
+	// Текст: меню 1-5, пол, ответы на вопросы анкеты
+	ctx, cancel := h.newContext(c, tmoText)
+	defer cancel()
+	out, err := h.core.OnText(ctx, c.Sender().ID, c.Text())
 	if err != nil {
 		log.Printf("core.OnText: %v", err)
-		return c.Send("Не понял сообщение. Попробуй ещё раз.")
+		return h.reply(ctx, c, "error.text")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onCallback(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoShort)
+	defer cancel()
+
+	action := c.Callback().Data
+	out, err := h.core.OnCallback(ctx, c.Sender().ID, action)
+	_ = c.Respond()
+	// убираем кнопки с сообщения, чтобы по нему нельзя было нажать повторно
+	if msg := c.Message(); msg != nil && msg.ReplyMarkup != nil {
+		if _, err := h.bot.EditReplyMarkup(msg, nil); err != nil {
+			log.Printf("tg.editReplyMarkup: %v", err)
+		}
+	}
+	if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		log.Printf("core.OnCallback(%s): %v", action, err)
+		return h.reply(ctx, c, "error.action")
 	}
 	return h.render(c, out)
 }
 
 func (h *Handler) onPhoto(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoPhoto)
+	defer cancel()
+
 	p := c.Message().Photo
 	if p == nil {
-		return c.Send("Пришли, пожалуйста, фото изображением, не файлом.")
+		return h.reply(ctx, c, "photo.not_image")
 	}
 
 	file := p.MediaFile()
 	rc, err := h.bot.File(file) // telebot ожидает *tb.File
 	if err != nil {
 		log.Printf("tg.getFile: %v", err)
-		return c.Send("Не удалось получить фото, попробуй ещё раз.")
+		return h.reply(ctx, c, "photo.get_failed")
 	}
 	defer func() {
 		if closer, ok := rc.(io.Closer); ok {
@@ -104,49 +194,261 @@ func (h *Handler) onPhoto(c tb.Context) error {
 	data, err := io.ReadAll(lr)
 	if err != nil {
 		log.Printf("tg.readPhoto: %v", err)
-		return c.Send("Не удалось прочитать фото, попробуй ещё раз.")
+		return h.reply(ctx, c, "photo.read_failed")
 	}
 	if lr.N <= 0 {
-		return c.Send("Фото слишком большое. Отправь файл поменьше (до 8MB).")
+		return h.reply(ctx, c, "photo.too_big", maxPhotoSize>>20)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), tmoPhoto)
-	defer cancel()
-
 	out, err := h.core.OnPhoto(ctx, c.Sender().ID, data)
 	if err != nil {
 		log.Printf("core.OnPhoto: %v", err)
-		return c.Send("Не удалось обработать фото, попробуй ещё раз.")
+		return h.reply(ctx, c, "photo.failed")
+	}
+	return h.render(c, out)
+}
+
+// onIntro принимает голосовое или видео-кружок для анкеты.
+func (h *Handler) onIntro(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoPhoto)
+	defer cancel()
+
+	var in internal.IntroUpload
+	var file tb.File
+	switch m := c.Message(); {
+	case m.Voice != nil:
+		in.Kind, in.Duration, file = internal.IntroVoice, m.Voice.Duration, m.Voice.File
+	case m.VideoNote != nil:
+		in.Kind, in.Duration, file = internal.IntroVideoNote, m.VideoNote.Duration, m.VideoNote.File
+	default:
+		return nil
+	}
+	// длительность и размер известны заранее: слишком длинное интро не скачиваем
+	if in.Duration > internal.MaxIntroSeconds || file.FileSize > internal.MaxIntroSize {
+		return h.reply(ctx, c, "intro.invalid", internal.MaxIntroSeconds, internal.MaxIntroSize>>20)
+	}
+
+	rc, err := h.bot.File(&file)
+	if err != nil {
+		log.Printf("tg.getFile: %v", err)
+		return h.reply(ctx, c, "intro.get_failed")
+	}
+	defer func() {
+		if closer, ok := rc.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}()
+
+	lr := &io.LimitedReader{R: rc, N: internal.MaxIntroSize + 1}
+	data, err := io.ReadAll(lr)
+	if err != nil {
+		log.Printf("tg.readIntro: %v", err)
+		return h.reply(ctx, c, "intro.get_failed")
+	}
+	if lr.N <= 0 {
+		return h.reply(ctx, c, "intro.invalid", internal.MaxIntroSeconds, internal.MaxIntroSize>>20)
+	}
+	in.FileID, in.Data = file.FileID, data
+
+	out, err := h.core.OnIntro(ctx, c.Sender().ID, in)
+	if err != nil {
+		log.Printf("core.OnIntro: %v", err)
+		return h.reply(ctx, c, "intro.failed")
+	}
+	return h.render(c, out)
+}
+
+func (h *Handler) onLocation(c tb.Context) error {
+	ctx, cancel := h.newContext(c, tmoText)
+	defer cancel()
+
+	loc := c.Message().Location
+	if loc == nil {
+		return nil
+	}
+	out, err := h.core.OnLocation(ctx, c.Sender().ID, float64(loc.Lat), float64(loc.Lng))
+	if err != nil {
+		log.Printf("core.OnLocation: %v", err)
+		return h.reply(ctx, c, "error.generic")
 	}
 	return h.render(c, out)
 }
 
 func (h *Handler) render(c tb.Context, out internal.Output) error {
-	// Если есть картинка — отправляем как фото с подписью
-	if out.PhotoString != "" {
-		var photo *tb.Photo
-		if strings.HasPrefix(out.PhotoString, "file_id:") {
-			id := strings.TrimPrefix(out.PhotoString, "file_id:")
-			photo = &tb.Photo{File: tb.File{FileID: id}, Caption: out.Text}
-		} else if strings.HasPrefix(out.PhotoString, "http://") || strings.HasPrefix(out.PhotoString, "https://") {
-			photo = &tb.Photo{File: tb.FromURL(out.PhotoString), Caption: out.Text}
+	for _, n := range out.Notify {
+		if err := h.send(tb.ChatID(n.ChatID), n.Output); err != nil {
+			log.Printf("tg.notify(%d): %v", n.ChatID, err)
 		}
-		if photo != nil {
-			return c.Send(photo, keyboardByKind(out.Kind))
+	}
+	return h.send(c.Recipient(), out)
+}
+
+func (h *Handler) send(to tb.Recipient, out internal.Output) error {
+	if err := h.sendMessage(to, out); err != nil {
+		return err
+	}
+	if out.Intro == nil {
+		return nil
+	}
+	// интро идёт следом за анкетой; если оно не ушло, анкета всё равно показана
+	if err := h.sendIntro(to, out.Intro); err != nil {
+		log.Printf("tg.sendIntro: %v", err)
+	}
+	return nil
+}
+
+func (h *Handler) sendMessage(to tb.Recipient, out internal.Output) error {
+	text := i18n.Render(out.Lang, out.Text)
+
+	photos := make([]*tb.Photo, 0, len(out.Photos))
+	for _, p := range out.Photos {
+		if photo := tgPhoto(p); photo != nil {
+			photos = append(photos, photo)
+		}
+	}
+
+	switch {
+	case len(photos) > 1:
+		// Несколько фото — альбомом, подпись на первом фото
+		album := make(tb.Album, 0, len(photos))
+		for _, p := range photos {
+			album = append(album, p)
 		}
-		// если формат неизвестен — отправим как текст
+		album.SetCaption(text)
+		if _, err := h.bot.SendAlbum(to, album); err != nil {
+			return err
+		}
+		if keyboardFor(out) == nil {
+			return nil
+		}
+		// к альбому нельзя прикрепить кнопки, отправляем их отдельным сообщением
+		return h.sendWithKeyboard(to, i18n.T(out.Lang, "card.actions"), out)
+	case len(photos) == 1:
+		photos[0].Caption = text
+		return h.sendWithKeyboard(to, photos[0], out)
+	}
+	return h.sendWithKeyboard(to, text, out)
+}
+
+// sendWithKeyboard отправляет сообщение с клавиатурой out. Кнопку-ссылку на контакт Telegram
+// отклоняет, если собеседник запретил ссылки на себя в настройках приватности, — тогда
+// сообщение уходит без неё, а не теряется целиком.
+func (h *Handler) sendWithKeyboard(to tb.Recipient, what any, out internal.Output) error {
+	_, err := h.bot.Send(to, what, keyboardFor(out))
+	if err == nil || out.Link == "" || !buttonRejected(err) {
+		return err
+	}
+	log.Printf("tg.send: contact button rejected, sending without it: %v", err)
+	out.Link = ""
+	if kb := keyboardFor(out); kb != nil {
+		_, err = h.bot.Send(to, what, kb)
+	} else {
+		_, err = h.bot.Send(to, what)
+	}
+	return err
+}
+
+// buttonRejected сообщает, что Telegram не принял кнопку со ссылкой на пользователя
+// (BUTTON_USER_PRIVACY_RESTRICTED, BUTTON_USER_INVALID).
+func buttonRejected(err error) bool {
+	return strings.Contains(err.Error(), "BUTTON_USER")
+}
+
+// sendIntro отправляет голосовое или кружок анкеты. Кружок по ссылке Telegram не принимает,
+// поэтому для него нужен file_id.
+func (h *Handler) sendIntro(to tb.Recipient, in *internal.Intro) error {
+	file, ok := tgFile(in.Src)
+	if !ok {
+		return fmt.Errorf("unsupported intro source %q", in.Src)
+	}
+	var media tb.Sendable
+	switch in.Kind {
+	case internal.IntroVoice:
+		media = &tb.Voice{File: file}
+	case internal.IntroVideoNote:
+		media = &tb.VideoNote{File: file}
+	default:
+		return fmt.Errorf("unknown intro kind %q", in.Kind)
+	}
+	_, err := h.bot.Send(to, media)
+	return err
+}
+
+// tgPhoto собирает фото из file_id или ссылки; для неизвестного формата возвращает nil.
+func tgPhoto(src string) *tb.Photo {
+	file, ok := tgFile(src)
+	if !ok {
+		return nil
+	}
+	return &tb.Photo{File: file}
+}
+
+// tgFile собирает файл из "file_id:<id>" или ссылки.
+func tgFile(src string) (tb.File, bool) {
+	switch {
+	case strings.HasPrefix(src, "file_id:"):
+		return tb.File{FileID: strings.TrimPrefix(src, "file_id:")}, true
+	case strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://"):
+		return tb.FromURL(src), true
+	default:
+		return tb.File{}, false
 	}
-	return c.Send(out.Text, keyboardByKind(out.Kind))
 }
 
-func keyboardByKind(k internal.ReplyKind) *tb.ReplyMarkup {
-	switch k {
+func keyboardFor(out internal.Output) *tb.ReplyMarkup {
+	switch out.Kind {
 	case internal.ReplyMenu:
 		return MenuKeyboard()
 	case internal.ReplyGender:
-		return GenderKeyboard()
+		return GenderKeyboard(out.Lang)
 	case internal.ReplyBrowse:
-		return BrowseKeyboard()
+		return BrowseKeyboard(out.TargetID, out.UndoID)
+	case internal.ReplyLiked:
+		return LikedKeyboard(out.Lang, out.TargetID)
+	case internal.ReplyMatch:
+		return MatchKeyboard(out.Lang, out.Link)
+	case internal.ReplyLanguage:
+		return LanguageKeyboard()
+	case internal.ReplyEdit:
+		return EditKeyboard(out.Lang)
+	case internal.ReplyEditField:
+		return EditFieldKeyboard(out.Lang)
+	case internal.ReplyEditGender:
+		return EditGenderKeyboard(out.Lang)
+	case internal.ReplyPhotosDone:
+		return PhotosDoneKeyboard(out.Lang)
+	case internal.ReplyIntro:
+		return IntroKeyboard(out.Lang)
+	case internal.ReplyEditPhotos:
+		return EditPhotosKeyboard(out.Lang, out.PhotoIDs)
+	case internal.ReplyResume:
+		return ResumeKeyboard(out.Lang)
+	case internal.ReplyDeleteConfirm:
+		return DeleteKeyboard(out.Lang)
+	case internal.ReplyRemoveKeyboard:
+		return &tb.ReplyMarkup{RemoveKeyboard: true}
+	case internal.ReplyMatchItem:
+		return MatchItemKeyboard(out.Lang, out.Link, out.TargetID)
+	case internal.ReplyMatchesMore:
+		return MatchesMoreKeyboard(out.Lang)
+	case internal.ReplyReportReason:
+		return ReportReasonKeyboard(out.Lang, out.TargetID)
+	case internal.ReplyReportComment:
+		return ReportCommentKeyboard(out.Lang)
+	case internal.ReplyLikeMessage:
+		return LikeMessageKeyboard(out.Lang)
+	case internal.ReplyAskCity:
+		return AskCityKeyboard(out.Lang)
+	case internal.ReplyEditGeo:
+		return EditGeoKeyboard(out.Lang)
+	case internal.ReplySearch:
+		return SearchKeyboard(out.Lang)
+	case internal.ReplySearchGender:
+		return SearchGenderKeyboard(out.Lang)
+	case internal.ReplySearchAge:
+		return SearchAgeKeyboard(out.Lang)
+	case internal.ReplySearchRadius:
+		return SearchRadiusKeyboard(out.Lang)
 	default:
 		return nil
 	}
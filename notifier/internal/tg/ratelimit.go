@@ -0,0 +1,198 @@
+package tg
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"app/notifier/internal/i18n"
+
+	"github.com/redis/go-redis/v9"
+	tb "gopkg.in/telebot.v4"
+)
+
+// droppedUpdates — сколько апдейтов отброшено ограничителем, по типу апдейта.
+var droppedUpdates = expvar.NewMap("notifier_updates_dropped")
+
+// Limiter решает, обрабатывать ли апдейт отправителя. warn == true только для первого
+// отказа подряд, чтобы не отвечать на каждое лишнее сообщение.
+type Limiter interface {
+	Allow(senderID int64) (ok, warn bool)
+}
+
+// RateLimiter — token bucket на каждого отправителя в памяти процесса: burst апдейтов сразу,
+// дальше rate апдейтов в секунду.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	idle  time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[int64]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// warned — пользователь уже получил предупреждение в текущей серии отказов.
+	warned bool
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		idle:      10 * time.Minute,
+		now:       time.Now,
+		buckets:   make(map[int64]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow расходует токен отправителя. warn == true только для первого отказа подряд,
+// чтобы не отвечать на каждое лишнее сообщение.
+func (l *RateLimiter) Allow(senderID int64) (ok, warn bool) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b := l.buckets[senderID]
+	if b == nil {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[senderID] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.warned = false
+		return true, false
+	}
+	warn = !b.warned
+	b.warned = true
+	return false, warn
+}
+
+// RedisRateLimiter — тот же token bucket, но ведра лежат в Redis и общие для всех
+// реплик notifier, поэтому лимит не растёт с числом реплик.
+type RedisRateLimiter struct {
+	client *redis.Client
+	rate   float64
+	burst  int
+	idle   time.Duration
+	now    func() time.Time
+}
+
+func NewRedisRateLimiter(client *redis.Client, rate float64, burst int) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client: client,
+		rate:   rate,
+		burst:  burst,
+		idle:   10 * time.Minute,
+		now:    time.Now,
+	}
+}
+
+// allowScript пополняет ведро с момента прошлого апдейта и расходует токен;
+// возвращает {ok, warn}. Ведро отправителя, который давно ничего не присылал, истекает.
+var allowScript = redis.NewScript(`
+local rate, burst, now, idle = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4])
+local b = redis.call("HMGET", KEYS[1], "tokens", "last", "warned")
+local tokens, last = tonumber(b[1]), tonumber(b[2])
+if tokens == nil or last == nil then
+	tokens, last = burst, now
+end
+tokens = math.min(burst, tokens + math.max(0, now - last) / 1000 * rate)
+local ok, warn, warned = 0, 0, "0"
+if tokens >= 1 then
+	tokens = tokens - 1
+	ok = 1
+else
+	if b[3] ~= "1" then
+		warn = 1
+	end
+	warned = "1"
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(now), "warned", warned)
+redis.call("PEXPIRE", KEYS[1], idle)
+return {ok, warn}`)
+
+// Allow расходует токен отправителя. Если Redis недоступен, апдейт пропускается:
+// бот не должен молчать из-за сбоя ограничителя.
+func (l *RedisRateLimiter) Allow(senderID int64) (ok, warn bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := allowScript.Run(ctx, l.client, []string{rateLimitKey(senderID)},
+		l.rate, l.burst, l.now().UnixMilli(), l.idle.Milliseconds()).Int64Slice()
+	if err != nil || len(res) != 2 {
+		log.Printf("tg.RedisRateLimiter: %d: %v", senderID, err)
+		return true, false
+	}
+	return res[0] == 1, res[1] == 1
+}
+
+func rateLimitKey(senderID int64) string {
+	return fmt.Sprintf("notifier:ratelimit:%d", senderID)
+}
+
+// sweep удаляет ведра отправителей, которые давно ничего не присылали.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idle {
+		return
+	}
+	for id, b := range l.buckets {
+		if now.Sub(b.last) > l.idle {
+			delete(l.buckets, id)
+		}
+	}
+	l.lastSweep = now
+}
+
+// rateLimit отбрасывает апдейты отправителей, превысивших лимит.
+func (h *Handler) rateLimit(next tb.HandlerFunc) tb.HandlerFunc {
+	return func(c tb.Context) error {
+		sender := c.Sender()
+		if h.limiter == nil || sender == nil {
+			return next(c)
+		}
+
+		ok, warn := h.limiter.Allow(sender.ID)
+		if ok {
+			return next(c)
+		}
+
+		kind := "message"
+		if c.Callback() != nil {
+			kind = "callback"
+		}
+		droppedUpdates.Add(kind, 1)
+
+		// на нажатие кнопки отвечаем всегда, иначе у пользователя крутится индикатор загрузки
+		if kind == "message" && !warn {
+			return nil
+		}
+
+		ctx, cancel := h.newContext(c, tmoShort)
+		defer cancel()
+		text := i18n.T(h.core.Lang(ctx, sender.ID), "ratelimit.slow")
+
+		if kind == "callback" {
+			return c.Respond(&tb.CallbackResponse{Text: text})
+		}
+		if err := c.Send(text); err != nil {
+			log.Printf("tg.rateLimit: %v", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package tg_test
+
+import (
+	"expvar"
+	"strconv"
+	"testing"
+	"time"
+
+	"app/notifier/internal/fake"
+	"app/notifier/internal/tg"
+	userpb "app/user/proto"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// limiters — реализации Limiter с подменяемыми часами: в памяти и общая в Redis.
+func limiters(t *testing.T) map[string]func(now func() time.Time) tg.Limiter {
+	t.Helper()
+	return map[string]func(now func() time.Time) tg.Limiter{
+		"memory": func(now func() time.Time) tg.Limiter {
+			l := tg.NewRateLimiter(1, 2)
+			l.SetClock(now)
+			return l
+		},
+		"redis": func(now func() time.Time) tg.Limiter {
+			mr := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = client.Close() })
+			l := tg.NewRedisRateLimiter(client, 1, 2)
+			l.SetClock(now)
+			return l
+		},
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	type step struct {
+		after    time.Duration // сдвиг часов перед запросом
+		sender   int64
+		ok, warn bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst allowed, excess dropped",
+			steps: []step{
+				{sender: 1, ok: true},
+				{sender: 1, ok: true},
+				{sender: 1, ok: false, warn: true},
+				{sender: 1, ok: false, warn: false},
+			},
+		},
+		{
+			name: "refill after the interval",
+			steps: []step{
+				{sender: 1, ok: true},
+				{sender: 1, ok: true},
+				{sender: 1, ok: false, warn: true},
+				{after: 500 * time.Millisecond, sender: 1, ok: false, warn: false},
+				{after: 500 * time.Millisecond, sender: 1, ok: true},
+				// после пропущенного апдейта предупреждение снова разрешено
+				{sender: 1, ok: false, warn: true},
+			},
+		},
+		{
+			name: "refill is capped at burst",
+			steps: []step{
+				{sender: 1, ok: true},
+				{after: time.Minute, sender: 1, ok: true},
+				{sender: 1, ok: true},
+				{sender: 1, ok: false, warn: true},
+			},
+		},
+		{
+			name: "senders are isolated",
+			steps: []step{
+				{sender: 1, ok: true},
+				{sender: 1, ok: true},
+				{sender: 1, ok: false, warn: true},
+				{sender: 2, ok: true},
+				{sender: 2, ok: true},
+				{sender: 2, ok: false, warn: true},
+			},
+		},
+	}
+	for kind, newLimiter := range limiters(t) {
+		for _, tt := range tests {
+			t.Run(kind+"/"+tt.name, func(t *testing.T) {
+				now := time.Now()
+				l := newLimiter(func() time.Time { return now })
+
+				for i, s := range tt.steps {
+					now = now.Add(s.after)
+					ok, warn := l.Allow(s.sender)
+					if ok != s.ok || warn != s.warn {
+						t.Fatalf("step %d: Allow(%d) = %v, %v; want %v, %v", i, s.sender, ok, warn, s.ok, s.warn)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimit_DropsAndCounts(t *testing.T) {
+	dropped := expvar.Get("notifier_updates_dropped").(*expvar.Map)
+	count := func(kind string) int64 {
+		if v, ok := dropped.Get(kind).(*expvar.Int); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	messages, callbacks := count("message"), count("callback")
+
+	// часы стоят, поэтому токены не пополняются
+	l := tg.NewRateLimiter(1, 2)
+	now := time.Now()
+	l.SetClock(func() time.Time { return now })
+	h := newLimitedHarness(t, l)
+
+	alice := fake.User{ID: 1001, FirstName: "Alice", Lang: "en"}
+	me := h.users.Put(&userpb.User{TelegramId: alice.ID, Username: "Alice", Age: 27, Gender: "Парень", Location: "Berlin", IsVisible: true},
+		"https://photos.test/alice.jpg")
+	carl := h.users.Put(&userpb.User{TelegramId: 2001, Username: "Carl", Age: 30, Gender: "Девушка", Location: "Berlin", IsVisible: true},
+		"https://photos.test/carl.jpg")
+
+	h.expect(h.send(alice, "/start"), enMenu("menu.choose"))
+	if card := h.send(alice, "1"); len(card) != 1 {
+		t.Fatalf("want Carl's card, got %+v", card)
+	}
+
+	// первый лишний апдейт получает предупреждение, следующие отбрасываются молча
+	h.expect(h.send(alice, "2"), en("ratelimit.slow"))
+	if got := h.send(alice, "2"); len(got) != 0 {
+		t.Fatalf("second dropped message must be silent, got %+v", got)
+	}
+	// на нажатие кнопки бот отвечает, но оценку не сохраняет
+	answered := len(h.api.Answered())
+	if got := h.tap(alice, tg.ActLike+":"+strconv.FormatInt(carl.Id, 10)); len(got) != 0 {
+		t.Fatalf("dropped callback must not send messages, got %+v", got)
+	}
+	if n := len(h.api.Answered()); n != answered+1 {
+		t.Fatalf("dropped callback was not answered")
+	}
+	if h.matches.Rated(me.Id, carl.Id) {
+		t.Fatal("dropped like was saved")
+	}
+
+	if got := count("message") - messages; got != 2 {
+		t.Fatalf("dropped messages counter grew by %d, want 2", got)
+	}
+	if got := count("callback") - callbacks; got != 1 {
+		t.Fatalf("dropped callbacks counter grew by %d, want 1", got)
+	}
+}
@@ -1,11 +1,34 @@
 package tg
 
-import tb "gopkg.in/telebot.v4"
+import (
+	"strconv"
+
+	"app/notifier/internal"
+	"app/notifier/internal/i18n"
+
+	tb "gopkg.in/telebot.v4"
+)
 
 const (
 	ActLike    = "like"
 	ActDislike = "dislike"
 	ActSleep   = "sleep"
+	ActLiker   = "liker"
+	ActLang    = "lang"
+	ActMale    = "gender_male"
+	ActFemale  = "gender_female"
+	ActEdit    = "edit"
+	ActPhotos  = "photos"
+	ActPhoto   = "photo"
+	ActResume  = "resume"
+	ActDelete  = "delete"
+	ActMatches = "matches"
+	ActUnmatch = "unmatch"
+	ActReport  = "report"
+	ActLikeMsg = "likemsg"
+	ActSearch  = "search"
+	ActUndo    = "undo"
+	ActIntro   = "intro"
 )
 
 func MenuKeyboard() *tb.ReplyMarkup {
@@ -13,23 +36,254 @@ func MenuKeyboard() *tb.ReplyMarkup {
 	btn1 := m.Text("1")
 	btn2 := m.Text("2")
 	btn3 := m.Text("3")
-	m.Reply(m.Row(btn1, btn2, btn3))
+	btn4 := m.Text("4")
+	btn5 := m.Text("5")
+	btn6 := m.Text("6")
+	btn7 := m.Text("7")
+	m.Reply(m.Row(btn1, btn2, btn3), m.Row(btn4, btn5, btn6), m.Row(btn7))
 	return m
 }
 
-func GenderKeyboard() *tb.ReplyMarkup {
-	m := &tb.ReplyMarkup{ResizeKeyboard: true}
-	male := m.Text("Парень")
-	female := m.Text("Девушка")
-	m.Reply(m.Row(male, female))
+func GenderKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	male := m.Data(i18n.T(lang, "gender.male"), "", ActMale)
+	female := m.Data(i18n.T(lang, "gender.female"), "", ActFemale)
+	m.Inline(m.Row(male, female))
 	return m
 }
 
-func BrowseKeyboard() *tb.ReplyMarkup {
-	m := &tb.ReplyMarkup{ResizeKeyboard: true}
-	like := m.Text("❤️")
-	dislike := m.Text("👎")
-	sleep := m.Text("💤")
-	m.Reply(m.Row(like, dislike, sleep))
+// BrowseKeyboard — кнопки под анкетой; undoID — анкета, оценку которой можно отменить (0 — нельзя).
+func BrowseKeyboard(targetID, undoID int64) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	like := m.Data("❤️", "", callbackData(ActLike, targetID))
+	message := m.Data("💌", "", callbackData(ActLikeMsg, targetID))
+	dislike := m.Data("👎", "", callbackData(ActDislike, targetID))
+	sleep := m.Data("💤", "", ActSleep)
+	report := m.Data("🚩", "", callbackData(ActReport, targetID))
+	row := m.Row(like, message, dislike, sleep, report)
+	if undoID != 0 {
+		row = append(tb.Row{m.Data("↩️", "", callbackData(ActUndo, undoID))}, row...)
+	}
+	m.Inline(row)
 	return m
 }
+
+// ReportReasonKeyboard — причины жалобы на анкету targetID.
+func ReportReasonKeyboard(lang string, targetID int64) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	prefix := callbackData(ActReport, targetID) + ":"
+	rows := make([]tb.Row, 0, len(internal.ReportReasons)+2)
+	for _, r := range internal.ReportReasons {
+		rows = append(rows, m.Row(m.Data(i18n.T(lang, "report.reason."+r), "", prefix+r)))
+	}
+	rows = append(rows,
+		m.Row(m.Data(i18n.T(lang, "btn.block"), "", prefix+"block")),
+		m.Row(m.Data(i18n.T(lang, "btn.cancel"), "", ActReport+":cancel")),
+	)
+	m.Inline(rows...)
+	return m
+}
+
+func ReportCommentKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	skip := m.Data(i18n.T(lang, "btn.skip"), "", ActReport+":skip")
+	cancel := m.Data(i18n.T(lang, "btn.cancel"), "", ActReport+":cancel")
+	m.Inline(m.Row(skip, cancel))
+	return m
+}
+
+func LikeMessageKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.Data(i18n.T(lang, "btn.cancel"), "", ActLikeMsg+":cancel")))
+	return m
+}
+
+func LikedKeyboard(lang string, likerID int64) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	show := m.Data(i18n.T(lang, "btn.view_profile"), "", callbackData(ActLiker, likerID))
+	m.Inline(m.Row(show))
+	return m
+}
+
+// MatchKeyboard возвращает nil без ссылки: контакт тогда показан только текстом карточки.
+func MatchKeyboard(lang, link string) *tb.ReplyMarkup {
+	if link == "" {
+		return nil
+	}
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.URL(i18n.T(lang, "btn.write"), link)))
+	return m
+}
+
+func MatchItemKeyboard(lang, link string, userID int64) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	unmatch := m.Data(i18n.T(lang, "btn.unmatch"), "", callbackData(ActUnmatch, userID))
+	if link == "" {
+		m.Inline(m.Row(unmatch))
+		return m
+	}
+	m.Inline(m.Row(m.URL(i18n.T(lang, "btn.write"), link), unmatch))
+	return m
+}
+
+func MatchesMoreKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.Data(i18n.T(lang, "btn.more"), "", ActMatches+":more")))
+	return m
+}
+
+func LanguageKeyboard() *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	btns := make([]tb.Btn, 0, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		btns = append(btns, m.Data(i18n.T(lang, "lang.name"), "", ActLang+":"+lang))
+	}
+	m.Inline(m.Row(btns...))
+	return m
+}
+
+func EditKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	btn := func(field string) tb.Btn {
+		return m.Data(i18n.T(lang, "btn.edit."+field), "", ActEdit+":"+field)
+	}
+	m.Inline(
+		m.Row(btn("name"), btn("age"), btn("city")),
+		m.Row(btn("gender"), btn("desc"), btn("photo")),
+		m.Row(btn("geo")),
+		m.Row(m.Data(i18n.T(lang, "btn.done"), "", ActEdit+":done")),
+	)
+	return m
+}
+
+func EditFieldKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.Data(i18n.T(lang, "btn.keep"), "", ActEdit+":keep")))
+	return m
+}
+
+// AskCityKeyboard — кнопка отправки геопозиции при регистрации; город вводится текстом.
+func AskCityKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{ResizeKeyboard: true, OneTimeKeyboard: true}
+	m.Reply(m.Row(m.Location(i18n.T(lang, "btn.share_location"))))
+	return m
+}
+
+// EditGeoKeyboard — новая геопозиция или возврат к редактированию анкеты.
+func EditGeoKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{ResizeKeyboard: true, OneTimeKeyboard: true}
+	m.Reply(
+		m.Row(m.Location(i18n.T(lang, "btn.share_location"))),
+		m.Row(m.Text(i18n.T(lang, "btn.keep"))),
+	)
+	return m
+}
+
+func EditGenderKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	male := m.Data(i18n.T(lang, "gender.male"), "", ActMale)
+	female := m.Data(i18n.T(lang, "gender.female"), "", ActFemale)
+	keep := m.Data(i18n.T(lang, "btn.keep"), "", ActEdit+":keep")
+	m.Inline(m.Row(male, female), m.Row(keep))
+	return m
+}
+
+func PhotosDoneKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.Data(i18n.T(lang, "btn.done"), "", ActPhotos+":done")))
+	return m
+}
+
+func IntroKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	m.Inline(m.Row(m.Data(i18n.T(lang, "btn.skip"), "", ActIntro+":skip")))
+	return m
+}
+
+// EditPhotosKeyboard — по строке на фото: сделать главным (кроме первого) и удалить.
+func EditPhotosKeyboard(lang string, photoIDs []int64) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	rows := make([]tb.Row, 0, len(photoIDs)+1)
+	for i, id := range photoIDs {
+		del := m.Data(i18n.T(lang, "btn.photo.del", i+1), "", callbackData(ActPhoto+":del", id))
+		if i == 0 {
+			rows = append(rows, m.Row(del))
+			continue
+		}
+		main := m.Data(i18n.T(lang, "btn.photo.main", i+1), "", callbackData(ActPhoto+":main", id))
+		rows = append(rows, m.Row(main, del))
+	}
+	rows = append(rows, m.Row(m.Data(i18n.T(lang, "btn.back"), "", ActEdit+":keep")))
+	m.Inline(rows...)
+	return m
+}
+
+// SearchKeyboard — экран "Настройки поиска".
+func SearchKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	btn := func(field string) tb.Btn {
+		return m.Data(i18n.T(lang, "btn.search."+field), "", ActSearch+":"+field)
+	}
+	m.Inline(
+		m.Row(btn("gender"), btn("age"), btn("radius")),
+		m.Row(m.Data(i18n.T(lang, "btn.done"), "", ActSearch+":done")),
+	)
+	return m
+}
+
+func SearchGenderKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	btn := func(value string) tb.Btn {
+		return m.Data(i18n.T(lang, "btn.seek."+value), "", ActSearch+":gender:"+value)
+	}
+	m.Inline(
+		m.Row(btn("male"), btn("female"), btn("any")),
+		m.Row(btn("default")),
+		m.Row(m.Data(i18n.T(lang, "btn.back"), "", ActSearch+":back")),
+	)
+	return m
+}
+
+func SearchAgeKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	def := m.Data(i18n.T(lang, "btn.seek.default"), "", ActSearch+":age:default")
+	back := m.Data(i18n.T(lang, "btn.back"), "", ActSearch+":back")
+	m.Inline(m.Row(def), m.Row(back))
+	return m
+}
+
+func SearchRadiusKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	radii := make([]tb.Btn, 0, len(internal.SearchRadii))
+	for _, km := range internal.SearchRadii {
+		radii = append(radii, m.Data(i18n.T(lang, "btn.km", km), "", ActSearch+":radius:"+strconv.Itoa(int(km))))
+	}
+	m.Inline(
+		m.Row(radii...),
+		m.Row(m.Data(i18n.T(lang, "btn.any_city"), "", ActSearch+":radius:any")),
+		m.Row(m.Data(i18n.T(lang, "btn.seek.default"), "", ActSearch+":radius:default")),
+		m.Row(m.Data(i18n.T(lang, "btn.back"), "", ActSearch+":back")),
+	)
+	return m
+}
+
+func ResumeKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	yes := m.Data(i18n.T(lang, "btn.resume"), "", ActResume+":browse")
+	no := m.Data(i18n.T(lang, "btn.not_now"), "", ActResume+":no")
+	m.Inline(m.Row(yes, no))
+	return m
+}
+
+func DeleteKeyboard(lang string) *tb.ReplyMarkup {
+	m := &tb.ReplyMarkup{}
+	yes := m.Data(i18n.T(lang, "btn.delete"), "", ActDelete+":yes")
+	no := m.Data(i18n.T(lang, "btn.cancel"), "", ActDelete+":no")
+	m.Inline(m.Row(yes), m.Row(no))
+	return m
+}
+
+// callbackData собирает данные inline-кнопки вида "<action>:<id>".
+func callbackData(action string, id int64) string {
+	return action + ":" + strconv.FormatInt(id, 10)
+}
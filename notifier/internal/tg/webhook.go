@@ -0,0 +1,169 @@
+package tg
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+const (
+	secretHeader  = "X-Telegram-Bot-Api-Secret-Token"
+	maxUpdateSize = 1 << 20
+)
+
+// secretToken — ограничения Telegram на secret_token: 1-256 символов A-Z, a-z, 0-9, _ и -.
+var secretToken = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// WebhookConfig — приём апдейтов через вебхук вместо long polling.
+type WebhookConfig struct {
+	// Listen — адрес HTTP-сервера, например ":8080".
+	Listen string
+	// PublicURL — адрес, на который Telegram присылает апдейты (обычно ингресс).
+	PublicURL string
+	// Path — путь обработчика на нашем сервере. Пустой — путь из PublicURL.
+	// Нужен, если прокси переписывает путь.
+	Path string
+	// SecretToken сверяется с заголовком X-Telegram-Bot-Api-Secret-Token.
+	SecretToken string
+	// CertFile и KeyFile включают TLS на самом сервере.
+	// Пустые — TLS терминирует прокси перед ботом.
+	CertFile string
+	KeyFile  string
+	// SelfSigned — отправить CertFile в Telegram, чтобы он доверял самоподписанному сертификату.
+	SelfSigned bool
+	// MaxConnections — сколько одновременных запросов может открыть Telegram (0 — по умолчанию).
+	MaxConnections int
+}
+
+// WebhookPoller принимает апдейты по HTTP. Кроме вебхука сервер отвечает на /healthz.
+type WebhookPoller struct {
+	cfg  WebhookConfig
+	path string
+	lis  net.Listener
+}
+
+// NewWebhookPoller проверяет настройки и сразу занимает порт,
+// чтобы ошибки конфигурации всплыли при старте, а не внутри Poll.
+func NewWebhookPoller(cfg WebhookConfig) (*WebhookPoller, error) {
+	public, err := url.Parse(cfg.PublicURL)
+	if err != nil || public.Scheme != "https" || public.Host == "" {
+		return nil, fmt.Errorf("webhook: public url must be an https:// address, got %q", cfg.PublicURL)
+	}
+	if cfg.SecretToken != "" && !secretToken.MatchString(cfg.SecretToken) {
+		return nil, errors.New("webhook: secret token may only contain A-Z, a-z, 0-9, _ and - (up to 256 characters)")
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("webhook: both cert and key files are required for TLS")
+	}
+	if cfg.SelfSigned && cfg.CertFile == "" {
+		return nil, errors.New("webhook: self-signed mode requires a cert file")
+	}
+
+	path := cfg.Path
+	if path == "" {
+		path = public.Path
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	lis, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %w", err)
+	}
+	return &WebhookPoller{cfg: cfg, path: path, lis: lis}, nil
+}
+
+// register сообщает Telegram адрес вебхука. При остановке вебхук не снимается:
+// рядом могут работать другие реплики.
+func (p *WebhookPoller) register(b *tb.Bot) error {
+	hook := &tb.Webhook{
+		MaxConnections: p.cfg.MaxConnections,
+		SecretToken:    p.cfg.SecretToken,
+		Endpoint:       &tb.WebhookEndpoint{PublicURL: p.cfg.PublicURL},
+	}
+	if p.cfg.SelfSigned {
+		hook.Endpoint.Cert = p.cfg.CertFile
+	}
+	return b.SetWebhook(hook)
+}
+
+// Poll реализует tb.Poller: обслуживает HTTP, пока бот не закроет stop.
+func (p *WebhookPoller) Poll(_ *tb.Bot, dest chan tb.Update, stop chan struct{}) {
+	srv := &http.Server{
+		Handler:           p.handler(dest, stop),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		var err error
+		if p.cfg.CertFile != "" {
+			err = srv.ServeTLS(p.lis, p.cfg.CertFile, p.cfg.KeyFile)
+		} else {
+			err = srv.Serve(p.lis)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("tg: webhook server: %v", err)
+		}
+	}()
+	log.Printf("tg: webhook listening on %s%s", p.lis.Addr(), p.path)
+
+	<-stop
+	ctx, cancel := context.WithTimeout(context.Background(), tmoShort)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("tg: webhook shutdown: %v", err)
+	}
+}
+
+// handler — маршруты сервера: вебхук и /healthz.
+func (p *WebhookPoller) handler(dest chan<- tb.Update, stop <-chan struct{}) http.Handler {
+	pattern := "POST " + p.path
+	if p.path == "/" {
+		pattern = "POST /{$}"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.Handle(pattern, p.updates(dest, stop))
+	return mux
+}
+
+// updates проверяет секрет и передаёт апдейт боту.
+func (p *WebhookPoller) updates(dest chan<- tb.Update, stop <-chan struct{}) http.Handler {
+	secret := []byte(p.cfg.SecretToken)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(secret) > 0 && subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), secret) != 1 {
+			http.Error(w, "invalid secret token", http.StatusUnauthorized)
+			return
+		}
+
+		var upd tb.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&upd); err != nil {
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case dest <- upd:
+		case <-stop:
+			// Telegram повторит доставку, если не получит 200
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		case <-r.Context().Done():
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		}
+	})
+}
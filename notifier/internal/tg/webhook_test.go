@@ -0,0 +1,91 @@
+package tg_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"app/notifier/internal/tg"
+
+	tb "gopkg.in/telebot.v4"
+)
+
+func TestWebhook(t *testing.T) {
+	p, err := tg.NewWebhookPoller(tg.WebhookConfig{
+		Listen:      "127.0.0.1:0",
+		PublicURL:   "https://bot.example.com/tg/hook",
+		SecretToken: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookPoller: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+
+	const update = `{"update_id": 7, "message": {"message_id": 1, "text": "hi", "chat": {"id": 1001, "type": "private"}}}`
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		secret   string
+		body     string
+		want     int
+		received bool // апдейт дошёл до бота
+	}{
+		{name: "missing secret", method: http.MethodPost, path: "/tg/hook", body: update, want: http.StatusUnauthorized},
+		{name: "wrong secret", method: http.MethodPost, path: "/tg/hook", secret: "guess", body: update, want: http.StatusUnauthorized},
+		{name: "valid update", method: http.MethodPost, path: "/tg/hook", secret: "s3cret", body: update, want: http.StatusOK, received: true},
+		{name: "malformed update", method: http.MethodPost, path: "/tg/hook", secret: "s3cret", body: "{", want: http.StatusBadRequest},
+		{name: "other path", method: http.MethodPost, path: "/", secret: "s3cret", body: update, want: http.StatusNotFound},
+		{name: "healthz", method: http.MethodGet, path: "/healthz", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := make(chan tb.Update, 1)
+			stop := make(chan struct{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			p.Handler(dest, stop).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			select {
+			case upd := <-dest:
+				if !tt.received {
+					t.Fatalf("update reached the bot: %+v", upd)
+				}
+				if upd.ID != 7 || upd.Message == nil || upd.Message.Text != "hi" {
+					t.Fatalf("unexpected update: %+v", upd)
+				}
+			default:
+				if tt.received {
+					t.Fatal("update didn't reach the bot")
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_ShuttingDown(t *testing.T) {
+	p, err := tg.NewWebhookPoller(tg.WebhookConfig{Listen: "127.0.0.1:0", PublicURL: "https://bot.example.com/"})
+	if err != nil {
+		t.Fatalf("NewWebhookPoller: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+
+	// бот уже остановлен и апдейты не читает: Telegram должен повторить доставку
+	stop := make(chan struct{})
+	close(stop)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id": 1}`))
+	rec := httptest.NewRecorder()
+	p.Handler(make(chan tb.Update), stop).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"app/notifier/internal/i18n"
+	userpb "app/user/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OnTimezone показывает часовой пояс пользователя или меняет его (/timezone Europe/Berlin).
+// По нему match-сервис считает, когда обновляется дневной лимит лайков.
+func (c *Core) OnTimezone(ctx context.Context, chatID int64, tz string) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		if me.GetTimezone() == "" {
+			return Output{Text: i18n.M("timezone.unset")}, nil
+		}
+		return Output{Text: i18n.M("timezone.current", me.GetTimezone())}, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return Output{Text: i18n.M("timezone.invalid", tz)}, nil
+	}
+
+	// только пояс: остальные поля анкеты могли измениться с момента чтения
+	if _, err := c.users.Update(ctx, &userpb.User{Id: me.GetId(), Timezone: loc.String()}); err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return Output{Text: i18n.M("timezone.invalid", tz)}, nil
+		}
+		log.Printf("core: Update timezone: %v", err)
+		return Output{Text: i18n.M("profile.update_failed")}, nil
+	}
+	return Output{Text: i18n.M("timezone.saved", loc.String())}, nil
+}
+
+// likeQuotaOutput распознаёт исчерпанный дневной лимит лайков. Анкета остаётся на экране:
+// пропустить её или выйти в меню по-прежнему можно.
+func likeQuotaOutput(err error, targetID int64) (Output, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return Output{}, false
+	}
+
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.RetryInfo)
+		if !ok || info.GetRetryDelay() == nil {
+			continue
+		}
+		mins := int(math.Ceil(info.GetRetryDelay().AsDuration().Minutes()))
+		return Output{
+			Text:     i18n.M("like.quota", mins/60, mins%60),
+			Kind:     ReplyBrowse,
+			TargetID: targetID,
+		}, true
+	}
+	return Output{Text: i18n.M("like.quota.later"), Kind: ReplyBrowse, TargetID: targetID}, true
+}
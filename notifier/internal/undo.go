@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"app/notifier/internal/i18n"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// undoSwipe отменяет последнюю оценку и возвращает её анкету на экран; анкета, которая
+// была на экране, снова становится следующей в очереди.
+func (c *Core) undoSwipe(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	targetID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || s.LastSwipe == nil || s.LastSwipe.UserID != targetID {
+		return Output{Text: i18n.M("undo.unavailable")}, nil
+	}
+
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	last := *s.LastSwipe
+	if err := c.match.UndoLike(ctx, me.GetId(), last.UserID); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			s.LastSwipe = nil
+			return Output{Text: i18n.M("undo.unavailable")}, nil
+		}
+		log.Printf("core: UndoLike(%d, %d): %v", me.GetId(), last.UserID, err)
+		return Output{Text: i18n.M("undo.failed")}, nil
+	}
+
+	// nextCandidate берёт с конца очереди
+	q := s.queue()
+	if s.CurrentTarget != nil {
+		*q = append(*q, *s.CurrentTarget)
+	}
+	*q = append(*q, last)
+	s.CurrentTarget = nil
+	s.LastSwipe = nil
+	return c.nextCandidate(ctx, chatID, s)
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"app/notifier/internal/i18n"
+)
+
+// OnVisibility скрывает анкету из поиска (/pause) или возвращает её (/resume).
+func (c *Core) OnVisibility(ctx context.Context, chatID int64, visible bool) (out Output, err error) {
+	defer c.lock(ctx, chatID)()
+	s := c.get(ctx, chatID)
+	defer c.done(ctx, chatID, s, &out)
+
+	return c.setVisibility(ctx, chatID, s, visible)
+}
+
+func (c *Core) setVisibility(ctx context.Context, chatID int64, s *session, visible bool) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+
+	s.State = stMenu
+	s.EditField = ""
+
+	if me.GetIsVisible() == visible {
+		if visible {
+			return Output{Text: withMenu("resume.already"), Kind: ReplyMenu}, nil
+		}
+		return Output{Text: withMenu("pause.already"), Kind: ReplyMenu}, nil
+	}
+
+	if err := c.users.ToggleVisibility(ctx, me.GetId(), visible); err != nil {
+		log.Printf("core: ToggleVisibility(%v): %v", visible, err)
+		return Output{Text: withMenu("visibility.failed"), Kind: ReplyMenu}, nil
+	}
+	if visible {
+		return Output{Text: withMenu("resume.done"), Kind: ReplyMenu}, nil
+	}
+	return Output{Text: withMenu("pause.done"), Kind: ReplyMenu}, nil
+}
+
+// togglePause переключает видимость анкеты из меню.
+func (c *Core) togglePause(ctx context.Context, chatID int64, s *session) (Output, error) {
+	me, err := c.users.GetByTelegramID(ctx, chatID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "user not found") {
+			return Output{Text: i18n.M("register.first")}, nil
+		}
+		log.Printf("core: GetByTelegramID: %v", err)
+		return Output{Text: i18n.M("error.unavailable")}, nil
+	}
+	return c.setVisibility(ctx, chatID, s, !me.GetIsVisible())
+}
+
+// onResumeAction обрабатывает ответ на вопрос "показать анкету и начать просмотр?".
+func (c *Core) onResumeAction(ctx context.Context, chatID int64, s *session, arg string) (Output, error) {
+	if s.State != stMenu {
+		return Output{Text: i18n.M("action.unavailable")}, nil
+	}
+
+	switch arg {
+	case "browse":
+		me, err := c.users.GetByTelegramID(ctx, chatID)
+		if err != nil {
+			log.Printf("core: GetByTelegramID: %v", err)
+			return Output{Text: i18n.M("error.unavailable")}, nil
+		}
+		if err := c.users.ToggleVisibility(ctx, me.GetId(), true); err != nil {
+			log.Printf("core: ToggleVisibility(true): %v", err)
+			return Output{Text: withMenu("visibility.failed"), Kind: ReplyMenu}, nil
+		}
+		return c.startBrowsing(ctx, chatID, s)
+	case "no":
+		return Output{Text: withMenu("menu.choose"), Kind: ReplyMenu}, nil
+	}
+
+	return Output{Text: i18n.M("action.unknown")}, nil
+}
+
+func visibilityStatus(visible bool) i18n.Msg {
+	if visible {
+		return i18n.M("profile.status.visible")
+	}
+	return i18n.M("profile.status.hidden")
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "app/user/internal/entity"
+
 type CandidateFilter struct {
 	TargetGender string  `json:"target_gender"`
 	MinAge       int     `json:"min_age"`
@@ -7,4 +9,18 @@ type CandidateFilter struct {
 	Location     string  `json:"location"`
 	Limit        int     `json:"limit"`
 	ExcludeIDs   []int64 `json:"exclude_ids"`
+	// Geo и RadiusKm включают поиск по расстоянию (см. GetCandidatesRequest).
+	Geo      *entity.GeoPoint `json:"geo,omitempty"`
+	RadiusKm float64          `json:"radius_km,omitempty"`
+	// AnyCity снимает ограничение по городу и расстоянию.
+	AnyCity bool `json:"any_city,omitempty"`
+	// After — выдача продолжается после этой анкеты.
+	After *CandidateCursor `json:"after,omitempty"`
+}
+
+// CandidateCursor — позиция анкеты в выдаче, которая отсортирована по расстоянию
+// (анкеты без него — в конце) и id.
+type CandidateCursor struct {
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+	ID         int64    `json:"id"`
 }
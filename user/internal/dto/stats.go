@@ -0,0 +1,16 @@
+package dto
+
+// Stats — сводка по анкетам для администраторов.
+type Stats struct {
+	Total      int64 `json:"total"`
+	Registered int64 `json:"registered"` // зарегистрировались начиная с указанного момента
+	Visible    int64 `json:"visible"`
+	Banned     int64 `json:"banned"`
+}
+
+// CampaignStats — регистрации и активации (первый лайк) по метке источника.
+type CampaignStats struct {
+	Campaign   string `json:"campaign"` // пустая — пришли без deep-link
+	Registered int64  `json:"registered"`
+	Activated  int64  `json:"activated"`
+}
@@ -1,10 +1,15 @@
 package dto
 
+import "app/user/internal/entity"
+
 type UpdateProfileInput struct {
-	Username    string `json:"username,omitempty"`
-	Age         int    `json:"age,omitempty"`
-	Gender      string `json:"gender,omitempty"`
-	Location    string `json:"location,omitempty"`
-	Description string `json:"description,omitempty"`
-	IsVisible   bool   `json:"is_visible,omitempty"`
+	Username    string           `json:"username,omitempty"`
+	Age         int              `json:"age,omitempty"`
+	Gender      string           `json:"gender,omitempty"`
+	Location    string           `json:"location,omitempty"`
+	Description string           `json:"description,omitempty"`
+	IsVisible   bool             `json:"is_visible,omitempty"`
+	Timezone    string           `json:"timezone,omitempty"`
+	Geo         *entity.GeoPoint `json:"geo,omitempty"`
+	TgUsername  string           `json:"tg_username,omitempty"`
 }
@@ -0,0 +1,17 @@
+package entity
+
+// Виды интро анкеты.
+const (
+	IntroVoice     = "voice"
+	IntroVideoNote = "video_note"
+)
+
+// Intro — голосовое сообщение или видео-кружок, который показывается вместе с анкетой.
+type Intro struct {
+	Kind      string `json:"kind"`
+	ObjectKey string `json:"object_key"`
+	URL       string `json:"url"`
+	// FileID — file_id в Telegram: по нему бот отправляет интро, не скачивая файл из хранилища.
+	FileID   string `json:"file_id,omitempty"`
+	Duration int    `json:"duration"` // секунды
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Photo struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	ObjectKey string    `json:"object_key"`
+	URL       string    `json:"url"`
+	Position  int       `json:"position"`
+	IsPrimary bool      `json:"is_primary"`
+	CreatedAt time.Time `json:"created_at"`
+}
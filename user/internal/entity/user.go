@@ -13,4 +13,37 @@ type User struct {
 	PhotoURL    string    `json:"photo_url,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	IsVisible   bool      `json:"is_visible"`
+	Photos      []Photo   `json:"photos,omitempty"`
+	Timezone    string    `json:"timezone,omitempty"`
+	IsBanned    bool      `json:"is_banned"`
+	BotBlocked  bool      `json:"bot_blocked"`
+	Geo         *GeoPoint `json:"geo,omitempty"`
+	ReferrerID  int64     `json:"referrer_id,omitempty"`
+	Campaign    string    `json:"campaign,omitempty"`
+	Activated   bool      `json:"activated"`
+	Intro       *Intro    `json:"intro,omitempty"`
+	TgUsername  string    `json:"tg_username,omitempty"`
+
+	Prefs SearchPrefs `json:"search_prefs"`
+
+	// DistanceKm — расстояние до ищущего, заполняется только в выдаче кандидатов.
+	DistanceKm *float64 `json:"-"`
+}
+
+// SearchPrefs — настройки поиска; нулевые значения — поиск по умолчанию.
+type SearchPrefs struct {
+	Gender   string `json:"gender,omitempty"` // "" — противоположный пол, SeekAnyone — любой
+	MinAge   int    `json:"min_age,omitempty"`
+	MaxAge   int    `json:"max_age,omitempty"`
+	RadiusKm int    `json:"radius_km,omitempty"`
+	AnyCity  bool   `json:"any_city,omitempty"`
+}
+
+// SeekAnyone — в SearchPrefs.Gender: показывать анкеты любого пола.
+const SeekAnyone = "any"
+
+// GeoPoint — координаты в градусах.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
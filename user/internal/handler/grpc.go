@@ -3,8 +3,10 @@ package handler
 import (
 	"bytes"
 	"context"
+	"errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"strings"
 	"time"
 
 	"app/user/internal/dto"
@@ -39,9 +41,16 @@ func (h *Handler) RegisterUser(ctx context.Context, req *userpb.RegisterUserRequ
 		Location:    req.GetLocation(),
 		Description: req.GetDescription(),
 		IsVisible:   req.GetIsVisible(),
+		Geo:         geoFromPB(req.GetGeo()),
+		ReferrerID:  req.GetReferrerId(),
+		Campaign:    req.GetCampaign(),
+		TgUsername:  req.GetTgUsername(),
 	}
 	created, err := h.uc.Create(ctx, u)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidGeo) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return &userpb.UserResponse{User: toPB(created)}, nil
@@ -64,9 +73,15 @@ func (h *Handler) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRe
 		Location:    req.GetLocation(),
 		Description: req.GetDescription(),
 		IsVisible:   req.GetIsVisible(),
+		Timezone:    req.GetTimezone(),
+		Geo:         geoFromPB(req.GetGeo()),
+		TgUsername:  req.GetTgUsername(),
 	}
 	updated, err := h.uc.Update(ctx, u)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTimezone) || errors.Is(err, usecase.ErrInvalidGeo) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return &userpb.UserResponse{User: toPB(updated)}, nil
@@ -79,16 +94,23 @@ func (h *Handler) GetCandidates(ctx context.Context, req *userpb.GetCandidatesRe
 		MaxAge:       int(req.GetMaxAge()),
 		Location:     req.GetLocation(),
 		Limit:        int(req.GetLimit()),
+		ExcludeIDs:   req.GetExcludeIds(),
+		Geo:          geoFromPB(req.GetGeo()),
+		RadiusKm:     req.GetRadiusKm(),
+		AnyCity:      req.GetAnyCity(),
 	}
-	list, err := h.uc.GetCandidatProfiles(ctx, filter)
+	list, next, err := h.uc.GetCandidatProfiles(ctx, filter, req.GetCursor())
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	out := make([]*userpb.User, 0, len(list))
 	for _, u := range list {
 		out = append(out, toPB(u))
 	}
-	return &userpb.GetCandidatesResponse{Candidates: out}, nil
+	return &userpb.GetCandidatesResponse{Candidates: out, NextCursor: next}, nil
 }
 
 func (h *Handler) ToggleVisibility(ctx context.Context, req *userpb.ToggleVisibilityRequest) (*userpb.ToggleVisibilityResponse, error) {
@@ -115,8 +137,171 @@ func (h *Handler) PhotoUpload(ctx context.Context, req *userpb.PhotoUploadReques
 	return &userpb.PhotoUploadResponse{PhotoUrl: u.PhotoURL}, nil
 }
 
+func (h *Handler) AddPhoto(ctx context.Context, req *userpb.AddPhotoRequest) (*userpb.PhotosResponse, error) {
+	if req == nil || len(req.GetFile()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty file")
+	}
+
+	photos, err := h.uc.AddPhoto(ctx, req.GetUserId(), bytes.NewReader(req.GetFile()))
+	if err != nil {
+		return nil, photoStatus(err)
+	}
+	return &userpb.PhotosResponse{Photos: photosToPB(photos)}, nil
+}
+
+func (h *Handler) RemovePhoto(ctx context.Context, req *userpb.RemovePhotoRequest) (*userpb.PhotosResponse, error) {
+	photos, err := h.uc.RemovePhoto(ctx, req.GetUserId(), req.GetPhotoId())
+	if err != nil {
+		return nil, photoStatus(err)
+	}
+	return &userpb.PhotosResponse{Photos: photosToPB(photos)}, nil
+}
+
+func (h *Handler) ReorderPhotos(ctx context.Context, req *userpb.ReorderPhotosRequest) (*userpb.PhotosResponse, error) {
+	photos, err := h.uc.ReorderPhotos(ctx, req.GetUserId(), req.GetPhotoIds())
+	if err != nil {
+		return nil, photoStatus(err)
+	}
+	return &userpb.PhotosResponse{Photos: photosToPB(photos)}, nil
+}
+
+func (h *Handler) DeleteAccount(ctx context.Context, req *userpb.DeleteAccountRequest) (*userpb.DeleteAccountResponse, error) {
+	if err := h.uc.DeleteAccount(ctx, req.GetUserId()); err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.DeleteAccountResponse{Success: true}, nil
+}
+
+func (h *Handler) SetBanned(ctx context.Context, req *userpb.SetBannedRequest) (*userpb.SetBannedResponse, error) {
+	if err := h.uc.SetBanned(ctx, req.GetUserId(), req.GetBanned()); err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.SetBannedResponse{}, nil
+}
+
+func (h *Handler) GetStats(ctx context.Context, req *userpb.GetStatsRequest) (*userpb.GetStatsResponse, error) {
+	st, err := h.uc.Stats(ctx, time.Unix(req.GetSince(), 0))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.GetStatsResponse{
+		Total:      st.Total,
+		Registered: st.Registered,
+		Visible:    st.Visible,
+		Banned:     st.Banned,
+	}, nil
+}
+
+func (h *Handler) SetBotBlocked(ctx context.Context, req *userpb.SetBotBlockedRequest) (*userpb.SetBotBlockedResponse, error) {
+	if err := h.uc.SetBotBlocked(ctx, req.GetUserId(), req.GetBlocked()); err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.SetBotBlockedResponse{}, nil
+}
+
+func (h *Handler) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	list, err := h.uc.ListUsers(ctx, req.GetAfterId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	out := make([]*userpb.User, 0, len(list))
+	for _, u := range list {
+		out = append(out, toPB(u))
+	}
+	return &userpb.ListUsersResponse{Users: out}, nil
+}
+
+func (h *Handler) UpdateSearchPrefs(ctx context.Context, req *userpb.UpdateSearchPrefsRequest) (*userpb.UserResponse, error) {
+	u, err := h.uc.UpdateSearchPrefs(ctx, req.GetUserId(), prefsFromPB(req.GetPrefs()))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidPrefs) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if strings.Contains(err.Error(), "user not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.UserResponse{User: toPB(u)}, nil
+}
+
+func (h *Handler) MarkActivated(ctx context.Context, req *userpb.MarkActivatedRequest) (*userpb.MarkActivatedResponse, error) {
+	if err := h.uc.MarkActivated(ctx, req.GetUserId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.MarkActivatedResponse{}, nil
+}
+
+func (h *Handler) GetCampaignStats(ctx context.Context, req *userpb.GetCampaignStatsRequest) (*userpb.GetCampaignStatsResponse, error) {
+	list, err := h.uc.CampaignStats(ctx, time.Unix(req.GetSince(), 0))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	out := make([]*userpb.CampaignStats, 0, len(list))
+	for _, st := range list {
+		out = append(out, &userpb.CampaignStats{
+			Campaign:   st.Campaign,
+			Registered: st.Registered,
+			Activated:  st.Activated,
+		})
+	}
+	return &userpb.GetCampaignStatsResponse{Campaigns: out}, nil
+}
+
+func (h *Handler) SetIntro(ctx context.Context, req *userpb.SetIntroRequest) (*userpb.UserResponse, error) {
+	intro := entity.Intro{
+		Kind:     req.GetKind(),
+		Duration: int(req.GetDuration()),
+		FileID:   req.GetFileId(),
+	}
+	u, err := h.uc.SetIntro(ctx, req.GetUserId(), intro, bytes.NewReader(req.GetFile()))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidIntro), errors.Is(err, usecase.ErrIntroTooLong), errors.Is(err, usecase.ErrIntroTooBig):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case strings.Contains(err.Error(), "user not found"):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userpb.UserResponse{User: toPB(u)}, nil
+}
+
 // --- helpers ---
 
+func photoStatus(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrTooManyPhotos):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrInvalidPhotoOrder):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func photosToPB(photos []entity.Photo) []*userpb.Photo {
+	out := make([]*userpb.Photo, 0, len(photos))
+	for _, p := range photos {
+		out = append(out, &userpb.Photo{
+			Id:        p.ID,
+			Url:       p.URL,
+			Position:  int32(p.Position),
+			IsPrimary: p.IsPrimary,
+		})
+	}
+	return out
+}
+
 func toPB(u *entity.User) *userpb.User {
 	if u == nil {
 		return nil
@@ -132,5 +317,62 @@ func toPB(u *entity.User) *userpb.User {
 		PhotoUrl:    u.PhotoURL,
 		IsVisible:   u.IsVisible,
 		CreatedAt:   u.CreatedAt.Format(time.RFC3339),
+		Photos:      photosToPB(u.Photos),
+		Timezone:    u.Timezone,
+		IsBanned:    u.IsBanned,
+		BotBlocked:  u.BotBlocked,
+		Geo:         geoToPB(u.Geo),
+		SearchPrefs: prefsToPB(u.Prefs),
+		ReferrerId:  u.ReferrerID,
+		Campaign:    u.Campaign,
+		Activated:   u.Activated,
+		Intro:       introToPB(u.Intro),
+		TgUsername:  u.TgUsername,
+	}
+}
+
+func introToPB(i *entity.Intro) *userpb.Intro {
+	if i == nil {
+		return nil
+	}
+	return &userpb.Intro{
+		Kind:     i.Kind,
+		Url:      i.URL,
+		Duration: int32(i.Duration),
+		FileId:   i.FileID,
+	}
+}
+
+func prefsFromPB(p *userpb.SearchPrefs) entity.SearchPrefs {
+	return entity.SearchPrefs{
+		Gender:   p.GetGender(),
+		MinAge:   int(p.GetMinAge()),
+		MaxAge:   int(p.GetMaxAge()),
+		RadiusKm: int(p.GetRadiusKm()),
+		AnyCity:  p.GetAnyCity(),
+	}
+}
+
+func prefsToPB(p entity.SearchPrefs) *userpb.SearchPrefs {
+	return &userpb.SearchPrefs{
+		Gender:   p.Gender,
+		MinAge:   int32(p.MinAge),
+		MaxAge:   int32(p.MaxAge),
+		RadiusKm: int32(p.RadiusKm),
+		AnyCity:  p.AnyCity,
+	}
+}
+
+func geoFromPB(g *userpb.GeoPoint) *entity.GeoPoint {
+	if g == nil {
+		return nil
+	}
+	return &entity.GeoPoint{Latitude: g.GetLatitude(), Longitude: g.GetLongitude()}
+}
+
+func geoToPB(g *entity.GeoPoint) *userpb.GeoPoint {
+	if g == nil {
+		return nil
 	}
+	return &userpb.GeoPoint{Latitude: g.Latitude, Longitude: g.Longitude}
 }
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"app/user/internal/entity"
+
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 )
@@ -41,33 +43,53 @@ func (m *Minio) EnsureBucket(ctx context.Context) error {
 	return m.Client.MakeBucket(ctx, m.Bucket, minio.MakeBucketOptions{})
 }
 
-// Upload загружает фото и возвращает URL.
+// mediaPhoto — вид файла для фото анкеты.
+const mediaPhoto = "photo"
+
+// mediaTypes — расширение ключа и content-type по умолчанию для каждого вида файлов.
+var mediaTypes = map[string]struct{ ext, contentType string }{
+	mediaPhoto:            {"jpg", "image/jpeg"},
+	entity.IntroVoice:     {"ogg", "audio/ogg"},
+	entity.IntroVideoNote: {"mp4", "video/mp4"},
+}
+
+// Upload загружает фото и возвращает ключ объекта и URL.
+func (m *Minio) Upload(ctx context.Context, userID int64, r io.Reader) (string, string, error) {
+	return m.UploadMedia(ctx, userID, mediaPhoto, r)
+}
+
+// UploadMedia загружает файл вида kind (фото, entity.IntroVoice, entity.IntroVideoNote)
+// и возвращает ключ объекта и URL.
 // Если указан BaseURL — вернёт "BaseURL/bucket/key" (path-style).
 // Если BaseURL пуст — вернёт presigned GET URL с m.Expiry.
-func (m *Minio) Upload(ctx context.Context, userID int64, r io.Reader) (string, error) {
+func (m *Minio) UploadMedia(ctx context.Context, userID int64, kind string, r io.Reader) (string, string, error) {
+	media, ok := mediaTypes[kind]
+	if !ok {
+		return "", "", fmt.Errorf("minio: unknown media kind %q", kind)
+	}
 	if m.Client == nil || m.Bucket == "" {
-		return "", fmt.Errorf("minio: not configured (client or bucket is empty)")
+		return "", "", fmt.Errorf("minio: not configured (client or bucket is empty)")
 	}
 
 	// читаем в память, чтобы знать размер и content-type
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	if len(data) == 0 {
-		return "", fmt.Errorf("empty file")
+		return "", "", fmt.Errorf("empty file")
 	}
 
-	key := fmt.Sprintf("users/%d/%d.jpg", userID, time.Now().UnixNano())
+	key := fmt.Sprintf("users/%d/%d.%s", userID, time.Now().UnixNano(), media.ext)
 
 	// валидируем путь (рекомендуется minio-go)
 	if err := s3utils.CheckValidObjectName(key); err != nil {
-		return "", fmt.Errorf("invalid object name: %w", err)
+		return "", "", fmt.Errorf("invalid object name: %w", err)
 	}
 
 	ct := http.DetectContentType(data)
 	if ct == "application/octet-stream" {
-		ct = "image/jpeg" // дефолт
+		ct = media.contentType // дефолт
 	}
 
 	// загрузка
@@ -77,18 +99,38 @@ func (m *Minio) Upload(ctx context.Context, userID int64, r io.Reader) (string,
 			StorageClass: "", // можно оставить пустым
 		})
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// 1) если задан BaseURL — возвращаем прямой path-style URL
 	if m.BaseURL != "" {
-		return fmt.Sprintf("%s/%s/%s", m.BaseURL, m.Bucket, key), nil
+		return key, fmt.Sprintf("%s/%s/%s", m.BaseURL, m.Bucket, key), nil
 	}
 
 	// 2) иначе — presigned GET URL (удобно, если MinIO не публичен)
 	u, err := m.Client.PresignedGetObject(ctx, m.Bucket, key, m.Expiry, nil)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	return key, u.String(), nil
+}
+
+// Remove удаляет объект из бакета.
+func (m *Minio) Remove(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	return m.Client.RemoveObject(ctx, m.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+// RemoveUser удаляет все объекты пользователя (префикс users/<id>/).
+func (m *Minio) RemoveUser(ctx context.Context, userID int64) error {
+	prefix := fmt.Sprintf("users/%d/", userID)
+	objects := m.Client.ListObjects(ctx, m.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true})
+	for rerr := range m.Client.RemoveObjects(ctx, m.Bucket, objects, minio.RemoveObjectsOptions{}) {
+		if rerr.Err != nil {
+			return fmt.Errorf("remove %s: %w", rerr.ObjectName, rerr.Err)
+		}
 	}
-	return u.String(), nil
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/lib/pq"
+	"time"
 )
 
 // UserRepo — интерфейс теперь с контекстами, можешь вынести его в doma
@@ -24,11 +25,14 @@ func (db *PostgresDB) Create(ctx context.Context, user *entity.User) (*entity.Us
 		INSERT INTO users (
 			telegram_id, username, age, 
 			gender, location, description, 
-		    photo_url, is_visible, created_at
+		    photo_url, is_visible, created_at, latitude, longitude,
+			referrer_id, campaign, tg_username
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9
-		) RETURNING id
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+			(SELECT id FROM users WHERE id = $12), $13, $14
+		) RETURNING id, COALESCE(referrer_id, 0)
 		  `
+	lat, lon := geoArgs(user.Geo)
 	err := db.DB.QueryRowContext(
 		ctx,
 		query,
@@ -41,7 +45,12 @@ func (db *PostgresDB) Create(ctx context.Context, user *entity.User) (*entity.Us
 		user.PhotoURL,
 		user.IsVisible,
 		user.CreatedAt,
-	).Scan(&user.ID)
+		lat,
+		lon,
+		user.ReferrerID,
+		user.Campaign,
+		user.TgUsername,
+	).Scan(&user.ID, &user.ReferrerID)
 
 	if err != nil {
 		return nil, err
@@ -55,12 +64,19 @@ func (db *PostgresDB) GetByTelegramID(ctx context.Context, telegramID int64) (*e
 		SELECT 
 			id, telegram_id, username, age,
 			gender, location, description,
-			photo_url, is_visible, created_at
+			photo_url, is_visible, created_at, timezone, is_banned, bot_blocked,
+			latitude, longitude,
+			seek_gender, seek_min_age, seek_max_age, seek_radius_km, seek_any_city,
+			COALESCE(referrer_id, 0), campaign, activated_at IS NOT NULL,
+			intro_kind, intro_key, intro_url, intro_file_id, intro_duration,
+			tg_username
 		FROM users
 		WHERE telegram_id = $1
 	`
 	var description sql.NullString
 	var photoURL sql.NullString
+	var lat, lon sql.NullFloat64
+	var intro entity.Intro
 
 	user := &entity.User{}
 
@@ -75,6 +91,25 @@ func (db *PostgresDB) GetByTelegramID(ctx context.Context, telegramID int64) (*e
 		&photoURL,
 		&user.IsVisible,
 		&user.CreatedAt,
+		&user.Timezone,
+		&user.IsBanned,
+		&user.BotBlocked,
+		&lat,
+		&lon,
+		&user.Prefs.Gender,
+		&user.Prefs.MinAge,
+		&user.Prefs.MaxAge,
+		&user.Prefs.RadiusKm,
+		&user.Prefs.AnyCity,
+		&user.ReferrerID,
+		&user.Campaign,
+		&user.Activated,
+		&intro.Kind,
+		&intro.ObjectKey,
+		&intro.URL,
+		&intro.FileID,
+		&intro.Duration,
+		&user.TgUsername,
 	)
 
 	if err != nil {
@@ -84,6 +119,22 @@ func (db *PostgresDB) GetByTelegramID(ctx context.Context, telegramID int64) (*e
 		return nil, err
 	}
 
+	if description.Valid {
+		user.Description = description.String
+	}
+	if photoURL.Valid {
+		user.PhotoURL = photoURL.String
+	}
+	user.Geo = geoFromNull(lat, lon)
+	if intro.Kind != "" {
+		user.Intro = &intro
+	}
+
+	user.Photos, err = db.ListPhotos(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -92,13 +143,20 @@ func (db *PostgresDB) GetProfile(ctx context.Context, userID int64) (*entity.Use
 		SELECT 
 			id, telegram_id, username, age,
 			gender, location, description,
-			photo_url, is_visible, created_at
+			photo_url, is_visible, created_at, timezone, is_banned, bot_blocked,
+			latitude, longitude,
+			seek_gender, seek_min_age, seek_max_age, seek_radius_km, seek_any_city,
+			COALESCE(referrer_id, 0), campaign, activated_at IS NOT NULL,
+			intro_kind, intro_key, intro_url, intro_file_id, intro_duration,
+			tg_username
 		FROM users
 		WHERE id = $1
 	`
 
 	var description sql.NullString
 	var photoURL sql.NullString
+	var lat, lon sql.NullFloat64
+	var intro entity.Intro
 
 	user := &entity.User{}
 	err := db.DB.QueryRowContext(ctx, query, userID).Scan(
@@ -112,6 +170,25 @@ func (db *PostgresDB) GetProfile(ctx context.Context, userID int64) (*entity.Use
 		&photoURL,
 		&user.IsVisible,
 		&user.CreatedAt,
+		&user.Timezone,
+		&user.IsBanned,
+		&user.BotBlocked,
+		&lat,
+		&lon,
+		&user.Prefs.Gender,
+		&user.Prefs.MinAge,
+		&user.Prefs.MaxAge,
+		&user.Prefs.RadiusKm,
+		&user.Prefs.AnyCity,
+		&user.ReferrerID,
+		&user.Campaign,
+		&user.Activated,
+		&intro.Kind,
+		&intro.ObjectKey,
+		&intro.URL,
+		&intro.FileID,
+		&intro.Duration,
+		&user.TgUsername,
 	)
 
 	if err != nil {
@@ -121,26 +198,54 @@ func (db *PostgresDB) GetProfile(ctx context.Context, userID int64) (*entity.Use
 		return nil, err
 	}
 
+	if description.Valid {
+		user.Description = description.String
+	}
+	if photoURL.Valid {
+		user.PhotoURL = photoURL.String
+	}
+	user.Geo = geoFromNull(lat, lon)
+	if intro.Kind != "" {
+		user.Intro = &intro
+	}
+
+	user.Photos, err = db.ListPhotos(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
 func (db *PostgresDB) UpdateProfile(ctx context.Context, userID int64, input dto.UpdateProfileInput) (*entity.User, error) {
 	query := `
 		UPDATE users
-		SET username = $1,
-			age = $2,
-			gender = $3,
-			location = $4,
-			description = $5
+		SET username = COALESCE(NULLIF($1, ''), username),
+			age = COALESCE(NULLIF($2, 0), age),
+			gender = COALESCE(NULLIF($3, ''), gender),
+			location = COALESCE(NULLIF($4, ''), location),
+			description = COALESCE(NULLIF($5, ''), description),
+			timezone = COALESCE(NULLIF($7, ''), timezone),
+			latitude = COALESCE($8, latitude),
+			longitude = COALESCE($9, longitude),
+			tg_username = COALESCE(NULLIF($10, ''), tg_username)
 		WHERE id = $6
-		RETURNING id, telegram_id, username, age, gender, location, description, photo_url, is_visible, created_at
+		RETURNING id, telegram_id, username, age, gender, location, description, photo_url, is_visible, created_at, timezone, is_banned, bot_blocked,
+			latitude, longitude,
+			seek_gender, seek_min_age, seek_max_age, seek_radius_km, seek_any_city,
+			COALESCE(referrer_id, 0), campaign, activated_at IS NOT NULL,
+			intro_kind, intro_key, intro_url, intro_file_id, intro_duration,
+			tg_username
 	`
 
 	var description sql.NullString
 	var photoURL sql.NullString
+	var lat, lon sql.NullFloat64
+	var intro entity.Intro
 
 	user := &entity.User{}
 
+	geoLat, geoLon := geoArgs(input.Geo)
 	err := db.DB.QueryRowContext(ctx, query,
 		input.Username,
 		input.Age,
@@ -148,6 +253,10 @@ func (db *PostgresDB) UpdateProfile(ctx context.Context, userID int64, input dto
 		input.Location,
 		input.Description,
 		userID,
+		input.Timezone,
+		geoLat,
+		geoLon,
+		input.TgUsername,
 	).Scan(
 		&user.ID,
 		&user.TelegramID,
@@ -159,6 +268,25 @@ func (db *PostgresDB) UpdateProfile(ctx context.Context, userID int64, input dto
 		&photoURL,
 		&user.IsVisible,
 		&user.CreatedAt,
+		&user.Timezone,
+		&user.IsBanned,
+		&user.BotBlocked,
+		&lat,
+		&lon,
+		&user.Prefs.Gender,
+		&user.Prefs.MinAge,
+		&user.Prefs.MaxAge,
+		&user.Prefs.RadiusKm,
+		&user.Prefs.AnyCity,
+		&user.ReferrerID,
+		&user.Campaign,
+		&user.Activated,
+		&intro.Kind,
+		&intro.ObjectKey,
+		&intro.URL,
+		&intro.FileID,
+		&intro.Duration,
+		&user.TgUsername,
 	)
 
 	if err != nil {
@@ -174,24 +302,61 @@ func (db *PostgresDB) UpdateProfile(ctx context.Context, userID int64, input dto
 	if photoURL.Valid {
 		user.PhotoURL = photoURL.String
 	}
+	user.Geo = geoFromNull(lat, lon)
+	if intro.Kind != "" {
+		user.Intro = &intro
+	}
 
 	return user, nil
 }
 
+// GetCandidates подбирает анкеты по фильтру. Если у ищущего есть геопозиция и задан радиус,
+// анкеты с геопозицией отбираются по расстоянию (формула гаверсинусов) и идут от ближних к дальним,
+// а анкеты без геопозиции — по совпадению города без учёта регистра; иначе ищем только по городу.
+// С AnyCity город и расстояние не ограничиваются, но ближние анкеты всё равно идут первыми.
+// filter.After продолжает выдачу по ключу (distance_km, id) с анкеты, следующей за курсором.
 func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFilter) ([]*entity.User, error) {
 	query := `
-        SELECT 
-            id, telegram_id, username, age, gender, location, description, photo_url, is_visible, created_at
-        FROM users
-        WHERE gender = $1
-          AND age BETWEEN $2 AND $3
-          AND location = $4
-          AND is_visible = TRUE
-          AND id <> ALL(COALESCE($6::bigint[], '{}'))  -- исключаем переданные ID
-        ORDER BY id
+        WITH c AS (
+            SELECT
+                id, telegram_id, username, age, gender, location, description, photo_url, is_visible, created_at,
+                latitude, longitude,
+                CASE WHEN latitude IS NOT NULL AND longitude IS NOT NULL AND $7::float8 IS NOT NULL THEN
+                    6371 * 2 * ASIN(LEAST(1, SQRT(
+                        POWER(SIN(RADIANS(latitude - $7::float8) / 2), 2) +
+                        COS(RADIANS($7::float8)) * COS(RADIANS(latitude)) *
+                        POWER(SIN(RADIANS(longitude - $8::float8) / 2), 2)
+                    )))
+                END AS distance_km
+            FROM users
+            WHERE ($1 = '' OR gender = $1)
+              AND age BETWEEN $2 AND $3
+              AND is_visible = TRUE
+              AND is_banned = FALSE
+              AND id <> ALL(COALESCE($6::bigint[], '{}'))  -- исключаем переданные ID
+        )
+        SELECT
+            id, telegram_id, username, age, gender, location, description, photo_url, is_visible, created_at,
+            latitude, longitude, distance_km
+        FROM c
+        WHERE ($10::bool
+           OR ($9::float8 > 0 AND distance_km <= $9::float8)
+           OR ((distance_km IS NULL OR $9::float8 <= 0) AND LOWER(TRIM(location)) = LOWER(TRIM($4))))
+          AND ($11::bigint IS NULL
+           OR ($12::float8 IS NOT NULL AND (distance_km > $12::float8 OR (distance_km = $12::float8 AND id > $11) OR distance_km IS NULL))
+           OR ($12::float8 IS NULL AND distance_km IS NULL AND id > $11))
+        ORDER BY distance_km NULLS LAST, id
         LIMIT $5
     `
 
+	lat, lon := geoArgs(filter.Geo)
+	var afterID, afterDist any
+	if filter.After != nil {
+		afterID = filter.After.ID
+		if filter.After.DistanceKm != nil {
+			afterDist = *filter.After.DistanceKm
+		}
+	}
 	rows, err := db.DB.QueryContext(ctx, query,
 		filter.TargetGender,
 		filter.MinAge,
@@ -199,6 +364,12 @@ func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFil
 		filter.Location,
 		filter.Limit,
 		pq.Array(filter.ExcludeIDs), // <-- важно
+		lat,
+		lon,
+		filter.RadiusKm,
+		filter.AnyCity,
+		afterID,
+		afterDist,
 	)
 	if err != nil {
 		return nil, err
@@ -211,6 +382,9 @@ func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFil
 			u         entity.User
 			descNull  sql.NullString
 			photoNull sql.NullString
+			latNull   sql.NullFloat64
+			lonNull   sql.NullFloat64
+			distNull  sql.NullFloat64
 		)
 		if err := rows.Scan(
 			&u.ID,
@@ -223,6 +397,9 @@ func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFil
 			&photoNull,
 			&u.IsVisible,
 			&u.CreatedAt,
+			&latNull,
+			&lonNull,
+			&distNull,
 		); err != nil {
 			return nil, err
 		}
@@ -232,6 +409,10 @@ func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFil
 		if photoNull.Valid {
 			u.PhotoURL = photoNull.String
 		}
+		u.Geo = geoFromNull(latNull, lonNull)
+		if distNull.Valid {
+			u.DistanceKm = &distNull.Float64
+		}
 		users = append(users, &u)
 	}
 	if err := rows.Err(); err != nil {
@@ -240,10 +421,25 @@ func (db *PostgresDB) GetCandidates(ctx context.Context, filter dto.CandidateFil
 	return users, nil
 }
 
+// geoArgs превращает координаты в параметры запроса; nil — NULL.
+func geoArgs(g *entity.GeoPoint) (lat, lon sql.NullFloat64) {
+	if g == nil {
+		return lat, lon
+	}
+	return sql.NullFloat64{Float64: g.Latitude, Valid: true}, sql.NullFloat64{Float64: g.Longitude, Valid: true}
+}
+
+func geoFromNull(lat, lon sql.NullFloat64) *entity.GeoPoint {
+	if !lat.Valid || !lon.Valid {
+		return nil
+	}
+	return &entity.GeoPoint{Latitude: lat.Float64, Longitude: lon.Float64}
+}
+
 func (db *PostgresDB) ToggleVisibility(ctx context.Context, userID int64, isVisible bool) error {
 	query := `
 		UPDATE users
-		SET is_visible = $1
+		SET is_visible = $1 AND NOT is_banned
 		WHERE id = $2`
 	_, err := db.DB.ExecContext(ctx, query, isVisible, userID)
 	if err != nil {
@@ -252,26 +448,374 @@ func (db *PostgresDB) ToggleVisibility(ctx context.Context, userID int64, isVisi
 	return nil
 }
 
-func (db *PostgresDB) UpdatePhoto(ctx context.Context, userID int64, url string) error {
+// Delete удаляет пользователя; его фото удаляются каскадно.
+func (db *PostgresDB) Delete(ctx context.Context, userID int64) error {
+	res, err := db.DB.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// SetBanned блокирует или разблокирует пользователя. Блокировка заодно скрывает анкету,
+// разблокировка видимость не возвращает — пользователь включит её сам.
+func (db *PostgresDB) SetBanned(ctx context.Context, userID int64, banned bool) error {
 	query := `
 		UPDATE users
-		SET photo_url = $1
-		WHERE id = $2
-	`
+		SET is_banned = $1,
+			is_visible = is_visible AND NOT $1
+		WHERE id = $2`
+	res, err := db.DB.ExecContext(ctx, query, banned, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
 
-	res, err := db.DB.ExecContext(ctx, query, url, userID)
+// SetBotBlocked отмечает, что пользователь заблокировал бота или снова начал им пользоваться.
+func (db *PostgresDB) SetBotBlocked(ctx context.Context, userID int64, blocked bool) error {
+	res, err := db.DB.ExecContext(ctx, `UPDATE users SET bot_blocked = $1 WHERE id = $2`, blocked, userID)
 	if err != nil {
 		return err
 	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
 
+func (db *PostgresDB) UpdateSearchPrefs(ctx context.Context, userID int64, prefs entity.SearchPrefs) error {
+	query := `
+		UPDATE users
+		SET seek_gender = $1, seek_min_age = $2, seek_max_age = $3, seek_radius_km = $4, seek_any_city = $5
+		WHERE id = $6`
+	res, err := db.DB.ExecContext(ctx, query,
+		prefs.Gender, prefs.MinAge, prefs.MaxAge, prefs.RadiusKm, prefs.AnyCity, userID)
+	if err != nil {
+		return err
+	}
 	rows, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
 
+// MarkActivated запоминает момент первой активности; повторный вызов ничего не меняет.
+func (db *PostgresDB) MarkActivated(ctx context.Context, userID int64) error {
+	_, err := db.DB.ExecContext(ctx,
+		`UPDATE users SET activated_at = NOW() WHERE id = $1 AND activated_at IS NULL`, userID)
+	return err
+}
+
+// SetIntro сохраняет интро анкеты; nil убирает его.
+func (db *PostgresDB) SetIntro(ctx context.Context, userID int64, intro *entity.Intro) error {
+	if intro == nil {
+		intro = &entity.Intro{}
+	}
+	query := `
+		UPDATE users
+		SET intro_kind = $1, intro_key = $2, intro_url = $3, intro_file_id = $4, intro_duration = $5
+		WHERE id = $6`
+	res, err := db.DB.ExecContext(ctx, query,
+		intro.Kind, intro.ObjectKey, intro.URL, intro.FileID, intro.Duration, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
 	if rows == 0 {
 		return errors.New("user not found")
 	}
+	return nil
+}
+
+// CampaignStats считает регистрации и активации по меткам deep-link начиная с since.
+func (db *PostgresDB) CampaignStats(ctx context.Context, since time.Time) ([]dto.CampaignStats, error) {
+	query := `
+		SELECT campaign, COUNT(*), COUNT(activated_at)
+		FROM users
+		WHERE created_at >= $1
+		GROUP BY campaign
+		ORDER BY COUNT(*) DESC, campaign`
+	rows, err := db.DB.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []dto.CampaignStats
+	for rows.Next() {
+		var st dto.CampaignStats
+		if err := rows.Scan(&st.Campaign, &st.Registered, &st.Activated); err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+// ListUsers отдаёт по возрастанию id пользователей с id > afterID, которым можно писать:
+// не заблокированных администратором и не заблокировавших бота.
+func (db *PostgresDB) ListUsers(ctx context.Context, afterID int64, limit int) ([]*entity.User, error) {
+	query := `
+		SELECT id, telegram_id, username, age, gender, location, description,
+			photo_url, is_visible, created_at, timezone
+		FROM users
+		WHERE id > $1
+		  AND is_banned = FALSE
+		  AND bot_blocked = FALSE
+		ORDER BY id
+		LIMIT $2`
+	rows, err := db.DB.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	var users []*entity.User
+	for rows.Next() {
+		var (
+			u         entity.User
+			descNull  sql.NullString
+			photoNull sql.NullString
+		)
+		if err := rows.Scan(
+			&u.ID,
+			&u.TelegramID,
+			&u.Username,
+			&u.Age,
+			&u.Gender,
+			&u.Location,
+			&descNull,
+			&photoNull,
+			&u.IsVisible,
+			&u.CreatedAt,
+			&u.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		if descNull.Valid {
+			u.Description = descNull.String
+		}
+		if photoNull.Valid {
+			u.PhotoURL = photoNull.String
+		}
+		users = append(users, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Stats считает анкеты; Registered — созданные начиная с since.
+func (db *PostgresDB) Stats(ctx context.Context, since time.Time) (dto.Stats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE created_at >= $1),
+			COUNT(*) FILTER (WHERE is_visible AND NOT is_banned),
+			COUNT(*) FILTER (WHERE is_banned)
+		FROM users`
+	var st dto.Stats
+	err := db.DB.QueryRowContext(ctx, query, since).Scan(&st.Total, &st.Registered, &st.Visible, &st.Banned)
+	return st, err
+}
+
+func (db *PostgresDB) ListPhotos(ctx context.Context, userID int64) ([]entity.Photo, error) {
+	return listPhotos(ctx, db.DB, userID)
+}
+
+// AddPhoto добавляет фото в конец галереи, а с primary=true — первым (основным).
+func (db *PostgresDB) AddPhoto(ctx context.Context, userID int64, photo entity.Photo, primary bool) ([]entity.Photo, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO user_photos (user_id, object_key, url, position)
+		SELECT $1, $2, $3,
+			CASE WHEN $4 THEN -1 ELSE COALESCE(MAX(position), -1) + 1 END
+		FROM user_photos
+		WHERE user_id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, photo.ObjectKey, photo.URL, primary); err != nil {
+		return nil, err
+	}
+	if err := normalizePhotos(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	photos, err := listPhotos(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return photos, tx.Commit()
+}
+
+// DeletePhoto удаляет фото пользователя и возвращает удалённую запись.
+func (db *PostgresDB) DeletePhoto(ctx context.Context, userID, photoID int64) (*entity.Photo, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		DELETE FROM user_photos
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, object_key, url, position, is_primary, created_at
+	`
+	var p entity.Photo
+	err = tx.QueryRowContext(ctx, query, photoID, userID).Scan(
+		&p.ID,
+		&p.UserID,
+		&p.ObjectKey,
+		&p.URL,
+		&p.Position,
+		&p.IsPrimary,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+	if err := normalizePhotos(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+	return &p, tx.Commit()
+}
+
+// ReorderPhotos расставляет фото в порядке photoIDs, первое становится основным.
+func (db *PostgresDB) ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]entity.Photo, error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE user_photos
+		SET position = array_position($2::bigint[], id) - 1
+		WHERE user_id = $1
+		  AND id = ANY($2::bigint[])
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, pq.Array(photoIDs)); err != nil {
+		return nil, err
+	}
+	if err := normalizePhotos(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	photos, err := listPhotos(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return photos, tx.Commit()
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func listPhotos(ctx context.Context, q queryer, userID int64) ([]entity.Photo, error) {
+	query := `
+		SELECT id, user_id, object_key, url, position, is_primary, created_at
+		FROM user_photos
+		WHERE user_id = $1
+		ORDER BY position, id
+	`
+	rows, err := q.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []entity.Photo
+	for rows.Next() {
+		var p entity.Photo
+		if err := rows.Scan(
+			&p.ID,
+			&p.UserID,
+			&p.ObjectKey,
+			&p.URL,
+			&p.Position,
+			&p.IsPrimary,
+			&p.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		photos = append(photos, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// normalizePhotos нумерует фото с нуля, делает первое основным и синхронизирует users.photo_url.
+func normalizePhotos(ctx context.Context, tx *sql.Tx, userID int64) error {
+	query := `
+		UPDATE user_photos p
+		SET position = r.rn - 1,
+			is_primary = (r.rn = 1)
+		FROM (
+			SELECT id, row_number() OVER (ORDER BY position, id) AS rn
+			FROM user_photos
+			WHERE user_id = $1
+		) r
+		WHERE p.id = r.id
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE users
+		SET photo_url = (
+			SELECT url FROM user_photos
+			WHERE user_id = $1 AND is_primary
+		)
+		WHERE id = $1
+	`
+	res, err := tx.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("user not found")
+	}
 	return nil
 }
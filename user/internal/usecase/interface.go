@@ -5,6 +5,7 @@ import (
 	"app/user/internal/entity"
 	"context"
 	"io"
+	"time"
 )
 
 type Repo interface {
@@ -14,7 +15,19 @@ type Repo interface {
 	UpdateProfile(ctx context.Context, userID int64, input dto.UpdateProfileInput) (*entity.User, error)
 	GetCandidates(ctx context.Context, filter dto.CandidateFilter) ([]*entity.User, error)
 	ToggleVisibility(ctx context.Context, userID int64, isVisible bool) error
-	UpdatePhoto(ctx context.Context, userID int64, url string) error
+	ListPhotos(ctx context.Context, userID int64) ([]entity.Photo, error)
+	AddPhoto(ctx context.Context, userID int64, photo entity.Photo, primary bool) ([]entity.Photo, error)
+	DeletePhoto(ctx context.Context, userID, photoID int64) (*entity.Photo, error)
+	ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]entity.Photo, error)
+	Delete(ctx context.Context, userID int64) error
+	SetBanned(ctx context.Context, userID int64, banned bool) error
+	Stats(ctx context.Context, since time.Time) (dto.Stats, error)
+	SetBotBlocked(ctx context.Context, userID int64, blocked bool) error
+	ListUsers(ctx context.Context, afterID int64, limit int) ([]*entity.User, error)
+	UpdateSearchPrefs(ctx context.Context, userID int64, prefs entity.SearchPrefs) error
+	MarkActivated(ctx context.Context, userID int64) error
+	CampaignStats(ctx context.Context, since time.Time) ([]dto.CampaignStats, error)
+	SetIntro(ctx context.Context, userID int64, intro *entity.Intro) error
 }
 
 type Cache interface {
@@ -24,5 +37,9 @@ type Cache interface {
 }
 
 type PhotoUploader interface {
-	Upload(ctx context.Context, userID int64, file io.Reader) (string, error)
+	Upload(ctx context.Context, userID int64, file io.Reader) (key string, url string, err error)
+	// UploadMedia загружает файл интро вида kind (entity.IntroVoice, entity.IntroVideoNote).
+	UploadMedia(ctx context.Context, userID int64, kind string, file io.Reader) (key string, url string, err error)
+	Remove(ctx context.Context, key string) error
+	RemoveUser(ctx context.Context, userID int64) error
 }
@@ -15,7 +15,22 @@ func NewMockMinioRepository() *MockMinioRepository {
 	return &MockMinioRepository{}
 }
 
-func (m *MockMinioRepository) Upload(ctx context.Context, userID int64, file io.Reader) (string, error) {
+func (m *MockMinioRepository) Upload(ctx context.Context, userID int64, file io.Reader) (string, string, error) {
 	args := m.Called(ctx, userID, file)
-	return args.String(0), args.Error(1)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockMinioRepository) UploadMedia(ctx context.Context, userID int64, kind string, file io.Reader) (string, string, error) {
+	args := m.Called(ctx, userID, kind, file)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockMinioRepository) Remove(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockMinioRepository) RemoveUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
 }
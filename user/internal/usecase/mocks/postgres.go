@@ -4,6 +4,7 @@ import (
 	"app/user/internal/dto"
 	"app/user/internal/entity"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -48,7 +49,76 @@ func (m *MockPostgresRepository) ToggleVisibility(ctx context.Context, userID in
 	return args.Error(0)
 }
 
-func (m *MockPostgresRepository) UpdatePhoto(ctx context.Context, userID int64, url string) error {
-	args := m.Called(ctx, userID, url)
+func (m *MockPostgresRepository) ListPhotos(ctx context.Context, userID int64) ([]entity.Photo, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.Photo), args.Error(1)
+}
+
+func (m *MockPostgresRepository) AddPhoto(ctx context.Context, userID int64, photo entity.Photo, primary bool) ([]entity.Photo, error) {
+	args := m.Called(ctx, userID, photo, primary)
+	return args.Get(0).([]entity.Photo), args.Error(1)
+}
+
+func (m *MockPostgresRepository) DeletePhoto(ctx context.Context, userID, photoID int64) (*entity.Photo, error) {
+	args := m.Called(ctx, userID, photoID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Photo), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPostgresRepository) ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]entity.Photo, error) {
+	args := m.Called(ctx, userID, photoIDs)
+	return args.Get(0).([]entity.Photo), args.Error(1)
+}
+
+func (m *MockPostgresRepository) Delete(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostgresRepository) SetBanned(ctx context.Context, userID int64, banned bool) error {
+	args := m.Called(ctx, userID, banned)
+	return args.Error(0)
+}
+
+func (m *MockPostgresRepository) Stats(ctx context.Context, since time.Time) (dto.Stats, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).(dto.Stats), args.Error(1)
+}
+
+func (m *MockPostgresRepository) SetBotBlocked(ctx context.Context, userID int64, blocked bool) error {
+	args := m.Called(ctx, userID, blocked)
+	return args.Error(0)
+}
+
+func (m *MockPostgresRepository) ListUsers(ctx context.Context, afterID int64, limit int) ([]*entity.User, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockPostgresRepository) UpdateSearchPrefs(ctx context.Context, userID int64, prefs entity.SearchPrefs) error {
+	args := m.Called(ctx, userID, prefs)
+	return args.Error(0)
+}
+
+func (m *MockPostgresRepository) MarkActivated(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostgresRepository) CampaignStats(ctx context.Context, since time.Time) ([]dto.CampaignStats, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.CampaignStats), args.Error(1)
+}
+
+func (m *MockPostgresRepository) SetIntro(ctx context.Context, userID int64, intro *entity.Intro) error {
+	args := m.Called(ctx, userID, intro)
 	return args.Error(0)
 }
@@ -3,10 +3,58 @@ package usecase
 import (
 	"app/user/internal/dto"
 	"app/user/internal/entity"
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPhotos — сколько фото может быть в анкете.
+const MaxPhotos = 5
+
+// Размер страницы ListUsers по умолчанию и максимальный.
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+var (
+	ErrTooManyPhotos     = errors.New("too many photos")
+	ErrInvalidPhotoOrder = errors.New("photo order must list every photo exactly once")
+	ErrInvalidTimezone   = errors.New("unknown timezone")
+	ErrInvalidGeo        = errors.New("coordinates out of range")
+	ErrInvalidPrefs      = errors.New("invalid search preferences")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidIntro      = errors.New("intro must be a non-empty voice message or video note")
+	ErrIntroTooLong      = errors.New("intro is too long")
+	ErrIntroTooBig       = errors.New("intro file is too big")
+)
+
+// Ограничения интро: Telegram записывает кружки не длиннее минуты, а запрос с файлом
+// должен уложиться в лимит сообщения gRPC по умолчанию (4 МБ).
+const (
+	MaxIntroDuration = 60 // секунды
+	MaxIntroSize     = 3 << 20
+)
+
+// Размер страницы выдачи кандидатов по умолчанию и максимальный.
+const (
+	defaultCandidatesPage = 20
+	maxCandidatesPage     = 100
+)
+
+// maxCampaign — ограничение длины метки источника (столько же допускает deep-link Telegram).
+const maxCampaign = 64
+
+// Пределы настроек поиска.
+const (
+	maxSeekAge    = 120
+	maxSeekRadius = 1000
 )
 
 type Usecase struct {
@@ -28,6 +76,16 @@ func (uc *Usecase) GetUserByTelegramID(ctx context.Context, telegramID int64) (*
 }
 
 func (uc *Usecase) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if !validGeo(user.Geo) {
+		return nil, ErrInvalidGeo
+	}
+	user.Campaign = strings.TrimSpace(user.Campaign)
+	if c := []rune(user.Campaign); len(c) > maxCampaign {
+		user.Campaign = string(c[:maxCampaign])
+	}
+	if user.ReferrerID < 0 {
+		user.ReferrerID = 0
+	}
 	user, err := uc.repo.Create(ctx, user)
 	if err != nil {
 		return nil, err
@@ -38,6 +96,14 @@ func (uc *Usecase) Create(ctx context.Context, user *entity.User) (*entity.User,
 	return user, nil
 }
 
+// validGeo проверяет, что координаты (если заданы) лежат в допустимых пределах.
+func validGeo(g *entity.GeoPoint) bool {
+	if g == nil {
+		return true
+	}
+	return g.Latitude >= -90 && g.Latitude <= 90 && g.Longitude >= -180 && g.Longitude <= 180
+}
+
 func (uc *Usecase) GetUserByID(ctx context.Context, id int64) (*entity.User, error) {
 	user, err := uc.cache.GetProfile(ctx, id)
 	if err != nil {
@@ -57,6 +123,15 @@ func (uc *Usecase) GetUserByID(ctx context.Context, id int64) (*entity.User, err
 }
 
 func (uc *Usecase) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if user.Timezone != "" {
+		if _, err := time.LoadLocation(user.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+	if !validGeo(user.Geo) {
+		return nil, ErrInvalidGeo
+	}
+
 	input := dto.UpdateProfileInput{
 		Username:    user.Username,
 		Age:         user.Age,
@@ -64,6 +139,9 @@ func (uc *Usecase) Update(ctx context.Context, user *entity.User) (*entity.User,
 		Location:    user.Location,
 		Description: user.Description,
 		IsVisible:   user.IsVisible,
+		Timezone:    user.Timezone,
+		Geo:         user.Geo,
+		TgUsername:  user.TgUsername,
 	}
 
 	updatedUser, err := uc.repo.UpdateProfile(ctx, user.ID, input)
@@ -78,17 +156,63 @@ func (uc *Usecase) Update(ctx context.Context, user *entity.User) (*entity.User,
 	return updatedUser, nil
 }
 
-func (uc *Usecase) GetCandidatProfiles(ctx context.Context, filter dto.CandidateFilter) ([]*entity.User, error) {
+// GetCandidatProfiles возвращает страницу выдачи после cursor и курсор следующей страницы
+// ("" — если это последняя).
+func (uc *Usecase) GetCandidatProfiles(ctx context.Context, filter dto.CandidateFilter, cursor string) ([]*entity.User, string, error) {
+	if cursor != "" {
+		after, err := parseCandidateCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+	if filter.Limit <= 0 || filter.Limit > maxCandidatesPage {
+		filter.Limit = defaultCandidatesPage
+	}
+	limit := filter.Limit
+
+	// берём на одну анкету больше, чтобы понять, есть ли следующая страница
+	filter.Limit++
 	candidates, err := uc.repo.GetCandidates(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	if len(candidates) <= limit {
+		return candidates, "", nil
+	}
+
+	candidates = candidates[:limit]
+	return candidates, candidateCursor(candidates[limit-1]), nil
+}
 
-	if len(candidates) == 0 {
-		return nil, errors.New("no candidates found")
+// candidateCursor кодирует позицию анкеты в выдаче: "<расстояние>:<id>" или "<id>",
+// если расстояние неизвестно.
+func candidateCursor(u *entity.User) string {
+	id := strconv.FormatInt(u.ID, 10)
+	if u.DistanceKm == nil {
+		return id
 	}
+	return strconv.FormatFloat(*u.DistanceKm, 'g', -1, 64) + ":" + id
+}
 
-	return candidates, nil
+func parseCandidateCursor(cursor string) (*dto.CandidateCursor, error) {
+	dist, idStr, hasDist := strings.Cut(cursor, ":")
+	if !hasDist {
+		idStr = dist
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	after := &dto.CandidateCursor{ID: id}
+	if hasDist {
+		d, err := strconv.ParseFloat(dist, 64)
+		if err != nil || d < 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+			return nil, ErrInvalidCursor
+		}
+		after.DistanceKm = &d
+	}
+	return after, nil
 }
 
 func (uc *Usecase) ToggleVisibility(ctx context.Context, userID int64, isVisible bool) error {
@@ -103,18 +227,252 @@ func (uc *Usecase) ToggleVisibility(ctx context.Context, userID int64, isVisible
 	return nil
 }
 
+// UpdateSearchPrefs заменяет настройки поиска пользователя и возвращает обновлённую анкету.
+func (uc *Usecase) UpdateSearchPrefs(ctx context.Context, userID int64, prefs entity.SearchPrefs) (*entity.User, error) {
+	if prefs.MinAge < 0 || prefs.MinAge > maxSeekAge || prefs.MaxAge < 0 || prefs.MaxAge > maxSeekAge ||
+		(prefs.MaxAge > 0 && prefs.MinAge > prefs.MaxAge) ||
+		prefs.RadiusKm < 0 || prefs.RadiusKm > maxSeekRadius {
+		return nil, ErrInvalidPrefs
+	}
+	if err := uc.repo.UpdateSearchPrefs(ctx, userID, prefs); err != nil {
+		return nil, err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+
+	return uc.repo.GetProfile(ctx, userID)
+}
+
+// SetBanned блокирует или разблокирует пользователя (блокировка скрывает анкету).
+func (uc *Usecase) SetBanned(ctx context.Context, userID int64, banned bool) error {
+	if err := uc.repo.SetBanned(ctx, userID, banned); err != nil {
+		return err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return nil
+}
+
+// SetBotBlocked отмечает, что пользователь заблокировал бота (или разблокировал).
+func (uc *Usecase) SetBotBlocked(ctx context.Context, userID int64, blocked bool) error {
+	if err := uc.repo.SetBotBlocked(ctx, userID, blocked); err != nil {
+		return err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return nil
+}
+
+// ListUsers — страница пользователей для рассылок, курсор — id последнего из предыдущей страницы.
+func (uc *Usecase) ListUsers(ctx context.Context, afterID int64, limit int) ([]*entity.User, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+	return uc.repo.ListUsers(ctx, afterID, limit)
+}
+
+func (uc *Usecase) Stats(ctx context.Context, since time.Time) (dto.Stats, error) {
+	return uc.repo.Stats(ctx, since)
+}
+
+// MarkActivated отмечает первую активность пользователя (для статистики кампаний).
+func (uc *Usecase) MarkActivated(ctx context.Context, userID int64) error {
+	if err := uc.repo.MarkActivated(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+
+	return nil
+}
+
+func (uc *Usecase) CampaignStats(ctx context.Context, since time.Time) ([]dto.CampaignStats, error) {
+	return uc.repo.CampaignStats(ctx, since)
+}
+
+// DeleteAccount удаляет фото пользователя из хранилища, а затем саму анкету.
+func (uc *Usecase) DeleteAccount(ctx context.Context, userID int64) error {
+	if err := uc.uploader.RemoveUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return nil
+}
+
+// UploadPhoto загружает новое основное фото. Если галерея заполнена, последнее фото удаляется.
 func (uc *Usecase) UploadPhoto(ctx context.Context, userID int64, file io.Reader) (string, error) {
-	url, err := uc.uploader.Upload(ctx, userID, file)
+	photos, err := uc.repo.ListPhotos(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 
-	if err := uc.repo.UpdatePhoto(ctx, userID, url); err != nil {
+	key, url, err := uc.uploader.Upload(ctx, userID, file)
+	if err != nil {
 		return "", err
 	}
 
+	if _, err := uc.repo.AddPhoto(ctx, userID, entity.Photo{ObjectKey: key, URL: url}, true); err != nil {
+		return "", err
+	}
+
+	for i := len(photos) - 1; i >= MaxPhotos-1; i-- {
+		uc.deletePhoto(ctx, userID, photos[i].ID)
+	}
+
 	if err := uc.cache.Invalidate(ctx, userID); err != nil {
 		log.Println("cache invalidate error:", err)
 	}
 	return url, nil
 }
+
+// AddPhoto добавляет фото в конец галереи.
+func (uc *Usecase) AddPhoto(ctx context.Context, userID int64, file io.Reader) ([]entity.Photo, error) {
+	photos, err := uc.repo.ListPhotos(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(photos) >= MaxPhotos {
+		return nil, ErrTooManyPhotos
+	}
+
+	key, url, err := uc.uploader.Upload(ctx, userID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	photos, err = uc.repo.AddPhoto(ctx, userID, entity.Photo{ObjectKey: key, URL: url}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return photos, nil
+}
+
+func (uc *Usecase) RemovePhoto(ctx context.Context, userID, photoID int64) ([]entity.Photo, error) {
+	removed, err := uc.repo.DeletePhoto(ctx, userID, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.uploader.Remove(ctx, removed.ObjectKey); err != nil {
+		log.Println("photo remove error:", err)
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return uc.repo.ListPhotos(ctx, userID)
+}
+
+// ReorderPhotos задаёт новый порядок фото; photoIDs должен содержать все фото пользователя.
+func (uc *Usecase) ReorderPhotos(ctx context.Context, userID int64, photoIDs []int64) ([]entity.Photo, error) {
+	photos, err := uc.repo.ListPhotos(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !samePhotoSet(photos, photoIDs) {
+		return nil, ErrInvalidPhotoOrder
+	}
+
+	photos, err = uc.repo.ReorderPhotos(ctx, userID, photoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return photos, nil
+}
+
+// SetIntro загружает голосовое или видео-кружок и заменяет им прежнее интро анкеты.
+// Вид, длительность и file_id берутся из intro, ключ и ссылку заполняет загрузчик.
+func (uc *Usecase) SetIntro(ctx context.Context, userID int64, intro entity.Intro, file io.Reader) (*entity.User, error) {
+	switch {
+	case intro.Kind != entity.IntroVoice && intro.Kind != entity.IntroVideoNote, intro.Duration < 0:
+		return nil, ErrInvalidIntro
+	case intro.Duration > MaxIntroDuration:
+		return nil, ErrIntroTooLong
+	}
+	// читаем на байт больше лимита, чтобы отличить файл ровно по лимиту от слишком большого
+	data, err := io.ReadAll(io.LimitReader(file, MaxIntroSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidIntro
+	}
+	if len(data) > MaxIntroSize {
+		return nil, ErrIntroTooBig
+	}
+
+	user, err := uc.repo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	intro.ObjectKey, intro.URL, err = uc.uploader.UploadMedia(ctx, userID, intro.Kind, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SetIntro(ctx, userID, &intro); err != nil {
+		if rmErr := uc.uploader.Remove(ctx, intro.ObjectKey); rmErr != nil {
+			log.Println("intro remove error:", rmErr)
+		}
+		return nil, err
+	}
+	if user.Intro != nil {
+		if err := uc.uploader.Remove(ctx, user.Intro.ObjectKey); err != nil {
+			log.Println("intro remove error:", err)
+		}
+	}
+
+	if err := uc.cache.Invalidate(ctx, userID); err != nil {
+		log.Println("cache invalidate error:", err)
+	}
+	return uc.repo.GetProfile(ctx, userID)
+}
+
+func (uc *Usecase) deletePhoto(ctx context.Context, userID, photoID int64) {
+	removed, err := uc.repo.DeletePhoto(ctx, userID, photoID)
+	if err != nil {
+		log.Println("photo delete error:", err)
+		return
+	}
+	if err := uc.uploader.Remove(ctx, removed.ObjectKey); err != nil {
+		log.Println("photo remove error:", err)
+	}
+}
+
+func samePhotoSet(photos []entity.Photo, ids []int64) bool {
+	if len(photos) != len(ids) {
+		return false
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, p := range photos {
+		if !seen[p.ID] {
+			return false
+		}
+	}
+	return len(seen) == len(ids)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -165,6 +166,34 @@ func TestUseCase_Update(t *testing.T) {
 	redis.AssertExpectations(t)
 }
 
+func TestUseCase_Update_InvalidTimezone(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+
+	_, err := uc.Update(context.Background(), &entity.User{ID: 1, Timezone: "Mars/Olympus"})
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Fatalf("got %v, want ErrInvalidTimezone", err)
+	}
+
+	pg.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything, mock.Anything)
+	redis.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_InvalidGeo(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+	bad := &entity.GeoPoint{Latitude: 91, Longitude: 37.6}
+
+	if _, err := uc.Create(context.Background(), &entity.User{TelegramID: 1, Geo: bad}); !errors.Is(err, ErrInvalidGeo) {
+		t.Fatalf("Create: got %v, want ErrInvalidGeo", err)
+	}
+	if _, err := uc.Update(context.Background(), &entity.User{ID: 1, Geo: bad}); !errors.Is(err, ErrInvalidGeo) {
+		t.Fatalf("Update: got %v, want ErrInvalidGeo", err)
+	}
+
+	pg.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	pg.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything, mock.Anything)
+	redis.AssertNotCalled(t, "SetProfile", mock.Anything, mock.Anything)
+}
+
 func TestUseCase_GetCandidatProfiles(t *testing.T) {
 	uc, pg, _, _ := UCInit()
 
@@ -198,21 +227,93 @@ func TestUseCase_GetCandidatProfiles(t *testing.T) {
 		},
 	}
 
-	// мокаем PG
-	pg.On("GetCandidates", mock.Anything, filter).
+	// мокаем PG: у репозитория просим на одну анкету больше, чтобы узнать о следующей странице
+	repoFilter := filter
+	repoFilter.Limit = 6
+	pg.On("GetCandidates", mock.Anything, repoFilter).
 		Return(expected, nil)
 
-	candidates, err := uc.GetCandidatProfiles(context.Background(), filter)
+	candidates, next, err := uc.GetCandidatProfiles(context.Background(), filter, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if !reflect.DeepEqual(candidates, expected) {
 		t.Errorf("got %+v, want %+v", candidates, expected)
 	}
+	if next != "" {
+		t.Errorf("got next cursor %q for the last page", next)
+	}
 
 	pg.AssertExpectations(t)
 }
 
+func TestUseCase_GetCandidatProfiles_Cursor(t *testing.T) {
+	near, far := 1.5, 12.25
+
+	tests := []struct {
+		name      string
+		cursor    string
+		page      []*entity.User
+		wantAfter *dto.CandidateCursor
+		wantLen   int
+		wantNext  string
+		expectErr error
+	}{
+		{
+			name:     "first page with more",
+			page:     []*entity.User{{ID: 3, DistanceKm: &near}, {ID: 1, DistanceKm: &far}, {ID: 2}},
+			wantLen:  2,
+			wantNext: "12.25:1",
+		},
+		{
+			name:      "after distance",
+			cursor:    "12.25:1",
+			page:      []*entity.User{{ID: 2}, {ID: 5}, {ID: 7}},
+			wantAfter: &dto.CandidateCursor{DistanceKm: &far, ID: 1},
+			wantLen:   2,
+			wantNext:  "5",
+		},
+		{
+			name:      "after profiles without distance",
+			cursor:    "5",
+			page:      []*entity.User{{ID: 7}},
+			wantAfter: &dto.CandidateCursor{ID: 5},
+			wantLen:   1,
+		},
+		{
+			name:      "empty page",
+			cursor:    "7",
+			wantAfter: &dto.CandidateCursor{ID: 7},
+		},
+		{
+			name:      "broken cursor",
+			cursor:    "abc:1",
+			expectErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, pg, _, _ := UCInit()
+
+			// с битым курсором до репозитория не доходим: неожиданный вызов мока — паника
+			if tt.expectErr == nil {
+				pg.On("GetCandidates", mock.Anything, dto.CandidateFilter{Limit: 3, After: tt.wantAfter}).
+					Return(tt.page, nil)
+			}
+
+			list, next, err := uc.GetCandidatProfiles(context.Background(), dto.CandidateFilter{Limit: 2}, tt.cursor)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("got error %v, want %v", err, tt.expectErr)
+			}
+			if len(list) != tt.wantLen || next != tt.wantNext {
+				t.Errorf("got %d candidates and cursor %q, want %d and %q", len(list), next, tt.wantLen, tt.wantNext)
+			}
+			pg.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUseCase_ToggleVisibility(t *testing.T) {
 	uc, pg, redis, _ := UCInit()
 
@@ -274,6 +375,7 @@ func TestUseCase_UploadPhoto(t *testing.T) {
 
 	tests := []struct {
 		name      string
+		existing  []entity.Photo
 		uploadURL string
 		uploadErr error
 		repoErr   error
@@ -312,6 +414,12 @@ func TestUseCase_UploadPhoto(t *testing.T) {
 			cacheErr:  errors.New("redis down"),
 			expectErr: false,
 		},
+		{
+			name:      "full gallery drops last photo",
+			existing:  photosN(MaxPhotos),
+			uploadURL: "http://cdn/pic.jpg",
+			expectErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -320,15 +428,26 @@ func TestUseCase_UploadPhoto(t *testing.T) {
 			redis.ExpectedCalls = nil
 			minio.ExpectedCalls = nil
 
+			pg.On("ListPhotos", mock.Anything, int64(1)).
+				Return(tt.existing, nil)
+
 			// uploader
 			minio.On("Upload", mock.Anything, int64(1), mock.Anything).
-				Return(tt.uploadURL, tt.uploadErr)
+				Return("users/1/pic.jpg", tt.uploadURL, tt.uploadErr)
 
 			if tt.uploadErr == nil {
-				pg.On("UpdatePhoto", mock.Anything, int64(1), tt.uploadURL).
-					Return(tt.repoErr)
+				photo := entity.Photo{ObjectKey: "users/1/pic.jpg", URL: tt.uploadURL}
+				pg.On("AddPhoto", mock.Anything, int64(1), photo, true).
+					Return([]entity.Photo(nil), tt.repoErr)
 
 				if tt.repoErr == nil {
+					if len(tt.existing) >= MaxPhotos {
+						last := tt.existing[len(tt.existing)-1]
+						pg.On("DeletePhoto", mock.Anything, int64(1), last.ID).
+							Return(&last, nil)
+						minio.On("Remove", mock.Anything, last.ObjectKey).
+							Return(nil)
+					}
 					redis.On("Invalidate", mock.Anything, int64(1)).
 						Return(tt.cacheErr)
 				}
@@ -351,3 +470,588 @@ func TestUseCase_UploadPhoto(t *testing.T) {
 		})
 	}
 }
+
+func photosN(n int) []entity.Photo {
+	photos := make([]entity.Photo, n)
+	for i := range photos {
+		photos[i] = entity.Photo{
+			ID:        int64(i + 1),
+			UserID:    1,
+			ObjectKey: "users/1/" + string(rune('a'+i)) + ".jpg",
+			Position:  i,
+			IsPrimary: i == 0,
+		}
+	}
+	return photos
+}
+
+func TestUseCase_AddPhoto(t *testing.T) {
+	uc, pg, redis, minio := UCInit()
+
+	tests := []struct {
+		name      string
+		existing  []entity.Photo
+		uploadErr error
+		repoErr   error
+		wantErr   error
+		expectErr bool
+	}{
+		{
+			name:      "happy-path",
+			existing:  photosN(2),
+			expectErr: false,
+		},
+		{
+			name:      "gallery is full",
+			existing:  photosN(MaxPhotos),
+			wantErr:   ErrTooManyPhotos,
+			expectErr: true,
+		},
+		{
+			name:      "uploader error",
+			existing:  photosN(1),
+			uploadErr: errors.New("upload failed"),
+			expectErr: true,
+		},
+		{
+			name:      "repo error",
+			existing:  photosN(1),
+			repoErr:   errors.New("db error"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+			minio.ExpectedCalls = nil
+
+			pg.On("ListPhotos", mock.Anything, int64(1)).
+				Return(tt.existing, nil)
+
+			if len(tt.existing) < MaxPhotos {
+				minio.On("Upload", mock.Anything, int64(1), mock.Anything).
+					Return("users/1/new.jpg", "http://cdn/new.jpg", tt.uploadErr)
+
+				if tt.uploadErr == nil {
+					photo := entity.Photo{ObjectKey: "users/1/new.jpg", URL: "http://cdn/new.jpg"}
+					pg.On("AddPhoto", mock.Anything, int64(1), photo, false).
+						Return(append(tt.existing, photo), tt.repoErr)
+
+					if tt.repoErr == nil {
+						redis.On("Invalidate", mock.Anything, int64(1)).
+							Return(nil)
+					}
+				}
+			}
+
+			photos, err := uc.AddPhoto(context.Background(), 1, bytes.NewReader([]byte("fake image")))
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !tt.expectErr && len(photos) != len(tt.existing)+1 {
+				t.Errorf("got %d photos, want %d", len(photos), len(tt.existing)+1)
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+			minio.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_RemovePhoto(t *testing.T) {
+	uc, pg, redis, minio := UCInit()
+
+	tests := []struct {
+		name      string
+		repoErr   error
+		removeErr error
+		expectErr bool
+	}{
+		{
+			name:      "happy-path",
+			expectErr: false,
+		},
+		{
+			name:      "repo error",
+			repoErr:   errors.New("photo not found"),
+			expectErr: true,
+		},
+		{
+			name:      "storage error is ignored",
+			removeErr: errors.New("minio down"),
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+			minio.ExpectedCalls = nil
+
+			photos := photosN(3)
+			removed := photos[1]
+
+			if tt.repoErr != nil {
+				pg.On("DeletePhoto", mock.Anything, int64(1), removed.ID).
+					Return(nil, tt.repoErr)
+			} else {
+				pg.On("DeletePhoto", mock.Anything, int64(1), removed.ID).
+					Return(&removed, nil)
+				minio.On("Remove", mock.Anything, removed.ObjectKey).
+					Return(tt.removeErr)
+				redis.On("Invalidate", mock.Anything, int64(1)).
+					Return(nil)
+				pg.On("ListPhotos", mock.Anything, int64(1)).
+					Return([]entity.Photo{photos[0], photos[2]}, nil)
+			}
+
+			got, err := uc.RemovePhoto(context.Background(), 1, removed.ID)
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.expectErr && len(got) != 2 {
+				t.Errorf("got %d photos, want 2", len(got))
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+			minio.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_ReorderPhotos(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+
+	tests := []struct {
+		name      string
+		order     []int64
+		expectErr bool
+	}{
+		{
+			name:      "happy-path",
+			order:     []int64{3, 1, 2},
+			expectErr: false,
+		},
+		{
+			name:      "missing photo",
+			order:     []int64{3, 1},
+			expectErr: true,
+		},
+		{
+			name:      "duplicate photo",
+			order:     []int64{1, 1, 2},
+			expectErr: true,
+		},
+		{
+			name:      "foreign photo",
+			order:     []int64{1, 2, 42},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+
+			pg.On("ListPhotos", mock.Anything, int64(1)).
+				Return(photosN(3), nil)
+
+			if !tt.expectErr {
+				pg.On("ReorderPhotos", mock.Anything, int64(1), tt.order).
+					Return(photosN(3), nil)
+				redis.On("Invalidate", mock.Anything, int64(1)).
+					Return(nil)
+			}
+
+			_, err := uc.ReorderPhotos(context.Background(), 1, tt.order)
+			if tt.expectErr && !errors.Is(err, ErrInvalidPhotoOrder) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidPhotoOrder)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_DeleteAccount(t *testing.T) {
+	uc, pg, redis, minio := UCInit()
+
+	tests := []struct {
+		name       string
+		storageErr error
+		repoErr    error
+		cacheErr   error
+		expectErr  bool
+	}{
+		{
+			name:      "happy-path",
+			expectErr: false,
+		},
+		{
+			name:       "storage error keeps the user",
+			storageErr: errors.New("minio down"),
+			expectErr:  true,
+		},
+		{
+			name:      "repo error",
+			repoErr:   errors.New("user not found"),
+			expectErr: true,
+		},
+		{
+			name:      "cache error ignored",
+			cacheErr:  errors.New("redis down"),
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+			minio.ExpectedCalls = nil
+
+			minio.On("RemoveUser", mock.Anything, int64(1)).
+				Return(tt.storageErr)
+
+			if tt.storageErr == nil {
+				pg.On("Delete", mock.Anything, int64(1)).
+					Return(tt.repoErr)
+
+				if tt.repoErr == nil {
+					redis.On("Invalidate", mock.Anything, int64(1)).
+						Return(tt.cacheErr)
+				}
+			}
+
+			err := uc.DeleteAccount(context.Background(), 1)
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+			minio.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_SetBanned(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+
+	tests := []struct {
+		name      string
+		banned    bool
+		repoErr   error
+		cacheErr  error
+		expectErr bool
+	}{
+		{
+			name:   "ban",
+			banned: true,
+		},
+		{
+			name:   "unban",
+			banned: false,
+		},
+		{
+			name:      "repo error skips cache",
+			banned:    true,
+			repoErr:   errors.New("user not found"),
+			expectErr: true,
+		},
+		{
+			name:     "cache error ignored",
+			banned:   true,
+			cacheErr: errors.New("redis down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+
+			pg.On("SetBanned", mock.Anything, int64(1), tt.banned).
+				Return(tt.repoErr)
+			if tt.repoErr == nil {
+				redis.On("Invalidate", mock.Anything, int64(1)).
+					Return(tt.cacheErr)
+			}
+
+			err := uc.SetBanned(context.Background(), 1, tt.banned)
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_ListUsers(t *testing.T) {
+	uc, pg, _, _ := UCInit()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "default limit", limit: 0, wantLimit: 100},
+		{name: "custom limit", limit: 50, wantLimit: 50},
+		{name: "limit capped", limit: 5000, wantLimit: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+
+			want := []*entity.User{{ID: 11, TelegramID: 1011}}
+			pg.On("ListUsers", mock.Anything, int64(10), tt.wantLimit).
+				Return(want, nil)
+
+			got, err := uc.ListUsers(context.Background(), 10, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 1 || got[0].ID != 11 {
+				t.Errorf("unexpected users: %+v", got)
+			}
+
+			pg.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_UpdateSearchPrefs(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+
+	tests := []struct {
+		name      string
+		prefs     entity.SearchPrefs
+		repoErr   error
+		expectErr error
+	}{
+		{
+			name:  "defaults",
+			prefs: entity.SearchPrefs{},
+		},
+		{
+			name:  "anyone in any city",
+			prefs: entity.SearchPrefs{Gender: entity.SeekAnyone, MinAge: 20, MaxAge: 35, AnyCity: true},
+		},
+		{
+			name:      "min age above max",
+			prefs:     entity.SearchPrefs{MinAge: 40, MaxAge: 30},
+			expectErr: ErrInvalidPrefs,
+		},
+		{
+			name:      "negative radius",
+			prefs:     entity.SearchPrefs{RadiusKm: -5},
+			expectErr: ErrInvalidPrefs,
+		},
+		{
+			name:      "repo error",
+			prefs:     entity.SearchPrefs{RadiusKm: 25},
+			repoErr:   errors.New("user not found"),
+			expectErr: errors.New("user not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+
+			// невалидные настройки не доходят до репозитория: неожиданный вызов мока — паника
+			if !errors.Is(tt.expectErr, ErrInvalidPrefs) {
+				pg.On("UpdateSearchPrefs", mock.Anything, int64(1), tt.prefs).Return(tt.repoErr)
+			}
+			if tt.expectErr == nil {
+				redis.On("Invalidate", mock.Anything, int64(1)).Return(nil)
+				pg.On("GetProfile", mock.Anything, int64(1)).Return(&entity.User{ID: 1, Prefs: tt.prefs}, nil)
+			}
+
+			u, err := uc.UpdateSearchPrefs(context.Background(), 1, tt.prefs)
+			if tt.expectErr != nil {
+				if err == nil || err.Error() != tt.expectErr.Error() {
+					t.Fatalf("got %v, want %v", err, tt.expectErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if u.Prefs != tt.prefs {
+					t.Errorf("got prefs %+v, want %+v", u.Prefs, tt.prefs)
+				}
+			}
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Create_Campaign(t *testing.T) {
+	uc, pg, redis, _ := UCInit()
+
+	long := strings.Repeat("я", 70)
+	pg.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Campaign == strings.Repeat("я", 64) && u.ReferrerID == 0
+	})).Return(&entity.User{ID: 1, Campaign: strings.Repeat("я", 64)}, nil)
+	redis.On("SetProfile", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := uc.Create(context.Background(), &entity.User{TelegramID: 42, Campaign: "  " + long + " ", ReferrerID: -7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pg.AssertExpectations(t)
+}
+
+func TestUseCase_SetIntro(t *testing.T) {
+	uc, pg, redis, minio := UCInit()
+
+	old := &entity.Intro{Kind: entity.IntroVoice, ObjectKey: "users/1/old.ogg", URL: "http://cdn/old.ogg", Duration: 5}
+
+	tests := []struct {
+		name      string
+		intro     entity.Intro
+		file      []byte
+		existing  *entity.Intro
+		uploadErr error
+		repoErr   error
+		wantErr   error
+		expectErr bool
+	}{
+		{
+			name:  "happy-path",
+			intro: entity.Intro{Kind: entity.IntroVideoNote, Duration: 12, FileID: "tg-file"},
+			file:  []byte("mp4 bytes"),
+		},
+		{
+			name:     "replaces previous intro",
+			intro:    entity.Intro{Kind: entity.IntroVoice, Duration: 30},
+			file:     []byte("ogg bytes"),
+			existing: old,
+		},
+		{
+			name:      "unknown kind",
+			intro:     entity.Intro{Kind: "photo", Duration: 3},
+			file:      []byte("jpeg bytes"),
+			wantErr:   ErrInvalidIntro,
+			expectErr: true,
+		},
+		{
+			name:      "empty file",
+			intro:     entity.Intro{Kind: entity.IntroVoice, Duration: 3},
+			wantErr:   ErrInvalidIntro,
+			expectErr: true,
+		},
+		{
+			name:      "too long",
+			intro:     entity.Intro{Kind: entity.IntroVoice, Duration: MaxIntroDuration + 1},
+			file:      []byte("ogg bytes"),
+			wantErr:   ErrIntroTooLong,
+			expectErr: true,
+		},
+		{
+			name:      "too big",
+			intro:     entity.Intro{Kind: entity.IntroVideoNote, Duration: 10},
+			file:      make([]byte, MaxIntroSize+1),
+			wantErr:   ErrIntroTooBig,
+			expectErr: true,
+		},
+		{
+			name:      "uploader error",
+			intro:     entity.Intro{Kind: entity.IntroVoice, Duration: 3},
+			file:      []byte("ogg bytes"),
+			uploadErr: errors.New("upload failed"),
+			expectErr: true,
+		},
+		{
+			name:      "repo error removes uploaded file",
+			intro:     entity.Intro{Kind: entity.IntroVoice, Duration: 3},
+			file:      []byte("ogg bytes"),
+			existing:  old,
+			repoErr:   errors.New("db error"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg.ExpectedCalls = nil
+			redis.ExpectedCalls = nil
+			minio.ExpectedCalls = nil
+
+			if tt.wantErr == nil {
+				pg.On("GetProfile", mock.Anything, int64(1)).
+					Return(&entity.User{ID: 1, Intro: tt.existing}, nil).Once()
+
+				minio.On("UploadMedia", mock.Anything, int64(1), tt.intro.Kind, mock.Anything).
+					Return("users/1/new", "http://cdn/new", tt.uploadErr)
+
+				if tt.uploadErr == nil {
+					saved := tt.intro
+					saved.ObjectKey, saved.URL = "users/1/new", "http://cdn/new"
+					pg.On("SetIntro", mock.Anything, int64(1), &saved).
+						Return(tt.repoErr)
+
+					switch {
+					case tt.repoErr != nil:
+						minio.On("Remove", mock.Anything, "users/1/new").Return(nil)
+					default:
+						if tt.existing != nil {
+							minio.On("Remove", mock.Anything, tt.existing.ObjectKey).Return(nil)
+						}
+						redis.On("Invalidate", mock.Anything, int64(1)).Return(nil)
+						pg.On("GetProfile", mock.Anything, int64(1)).
+							Return(&entity.User{ID: 1, Intro: &saved}, nil).Once()
+					}
+				}
+			}
+
+			u, err := uc.SetIntro(context.Background(), 1, tt.intro, bytes.NewReader(tt.file))
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !tt.expectErr && (u.Intro == nil || u.Intro.URL != "http://cdn/new" || u.Intro.Kind != tt.intro.Kind) {
+				t.Errorf("unexpected intro: %+v", u.Intro)
+			}
+
+			pg.AssertExpectations(t)
+			redis.AssertExpectations(t)
+			minio.AssertExpectations(t)
+		})
+	}
+}
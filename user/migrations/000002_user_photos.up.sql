@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS user_photos (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT       NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    object_key   TEXT         NOT NULL,
+    url          TEXT         NOT NULL,
+    position     INTEGER      NOT NULL,
+    is_primary   BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_photos_user ON user_photos(user_id, position);
+
+-- переносим уже загруженные фото как основные
+INSERT INTO user_photos (user_id, object_key, url, position, is_primary)
+SELECT id, COALESCE(substring(photo_url FROM '(users/[0-9]+/[^?]+)'), ''), photo_url, 0, TRUE
+FROM users
+WHERE photo_url IS NOT NULL AND photo_url <> '';
@@ -0,0 +1,2 @@
+-- часовой пояс пользователя (IANA, например Europe/Moscow); пустая строка — не указан
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- заблокированный администратором пользователь скрыт из поиска и не может пользоваться ботом
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_banned BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+-- пользователь заблокировал бота: рассылки ему не отправляются, пока он снова не напишет боту
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bot_blocked BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS longitude;
+ALTER TABLE users DROP COLUMN IF EXISTS latitude;
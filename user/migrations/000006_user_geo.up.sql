@@ -0,0 +1,3 @@
+-- геопозиция, которой пользователь поделился в Telegram; NULL — ищем только по городу
+ALTER TABLE users ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
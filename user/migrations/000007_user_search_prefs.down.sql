@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN IF EXISTS seek_any_city;
+ALTER TABLE users DROP COLUMN IF EXISTS seek_radius_km;
+ALTER TABLE users DROP COLUMN IF EXISTS seek_max_age;
+ALTER TABLE users DROP COLUMN IF EXISTS seek_min_age;
+ALTER TABLE users DROP COLUMN IF EXISTS seek_gender;
@@ -0,0 +1,6 @@
+-- настройки поиска; нулевые значения — поиск по умолчанию
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seek_gender TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seek_min_age INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seek_max_age INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seek_radius_km INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seek_any_city BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_campaign;
+ALTER TABLE users DROP COLUMN IF EXISTS activated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS campaign;
+ALTER TABLE users DROP COLUMN IF EXISTS referrer_id;
@@ -0,0 +1,6 @@
+-- откуда пришёл пользователь: deep-link /start и первый лайк для подсчёта активации
+ALTER TABLE users ADD COLUMN IF NOT EXISTS referrer_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS campaign TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS activated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_campaign ON users (campaign);
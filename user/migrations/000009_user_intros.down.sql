@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN IF EXISTS intro_duration;
+ALTER TABLE users DROP COLUMN IF EXISTS intro_file_id;
+ALTER TABLE users DROP COLUMN IF EXISTS intro_url;
+ALTER TABLE users DROP COLUMN IF EXISTS intro_key;
+ALTER TABLE users DROP COLUMN IF EXISTS intro_kind;
@@ -0,0 +1,6 @@
+-- голосовое или видео-кружок к анкете; файл лежит в MinIO, file_id — копия в Telegram
+ALTER TABLE users ADD COLUMN IF NOT EXISTS intro_kind TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS intro_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS intro_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS intro_file_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS intro_duration INT NOT NULL DEFAULT 0;
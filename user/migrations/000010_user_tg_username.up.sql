@@ -0,0 +1,2 @@
+-- имя пользователя в Telegram: ссылка https://t.me/<имя> открывает чат даже при закрытых пересылках
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tg_username TEXT NOT NULL DEFAULT '';
@@ -67,14 +67,21 @@ func (x *GetByTelegramIDRequest) GetTelegramId() int64 {
 }
 
 type RegisterUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TelegramId    int64                  `protobuf:"varint,1,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Age           int32                  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
-	Gender        string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
-	Location      string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	IsVisible     bool                   `protobuf:"varint,7,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TelegramId  int64                  `protobuf:"varint,1,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	Username    string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Age         int32                  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	Gender      string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
+	Location    string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Description string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	IsVisible   bool                   `protobuf:"varint,7,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	Geo         *GeoPoint              `protobuf:"bytes,8,opt,name=geo,proto3" json:"geo,omitempty"`
+	// Кто пригласил пользователя (id в user service); 0 или несуществующий — никто.
+	ReferrerId int64 `protobuf:"varint,9,opt,name=referrer_id,json=referrerId,proto3" json:"referrer_id,omitempty"`
+	// Метка источника из deep-link /start (реклама, канал, "referral" для приглашений).
+	Campaign string `protobuf:"bytes,10,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// Имя пользователя в Telegram без "@", если оно есть.
+	TgUsername    string `protobuf:"bytes,11,opt,name=tg_username,json=tgUsername,proto3" json:"tg_username,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -158,6 +165,34 @@ func (x *RegisterUserRequest) GetIsVisible() bool {
 	return false
 }
 
+func (x *RegisterUserRequest) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *RegisterUserRequest) GetReferrerId() int64 {
+	if x != nil {
+		return x.ReferrerId
+	}
+	return 0
+}
+
+func (x *RegisterUserRequest) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *RegisterUserRequest) GetTgUsername() string {
+	if x != nil {
+		return x.TgUsername
+	}
+	return ""
+}
+
 type GetProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -202,15 +237,22 @@ func (x *GetProfileRequest) GetUserId() int64 {
 	return 0
 }
 
+// Пустые поля (и age = 0) не меняются.
 type UpdateProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Age           int32                  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
-	Gender        string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
-	Location      string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	IsVisible     bool                   `protobuf:"varint,7,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username    string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Age         int32                  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	Gender      string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
+	Location    string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Description string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	IsVisible   bool                   `protobuf:"varint,7,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	// IANA-имя часового пояса, например "Europe/Moscow". Пустое — не менять.
+	Timezone string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Геопозиция; не задана — не менять.
+	Geo *GeoPoint `protobuf:"bytes,9,opt,name=geo,proto3" json:"geo,omitempty"`
+	// Имя пользователя в Telegram без "@". Пустое — не менять.
+	TgUsername    string `protobuf:"bytes,10,opt,name=tg_username,json=tgUsername,proto3" json:"tg_username,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -294,13 +336,45 @@ func (x *UpdateProfileRequest) GetIsVisible() bool {
 	return false
 }
 
+func (x *UpdateProfileRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *UpdateProfileRequest) GetTgUsername() string {
+	if x != nil {
+		return x.TgUsername
+	}
+	return ""
+}
+
 type GetCandidatesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TargetGender  string                 `protobuf:"bytes,1,opt,name=target_gender,json=targetGender,proto3" json:"target_gender,omitempty"`
-	MinAge        int32                  `protobuf:"varint,2,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
-	MaxAge        int32                  `protobuf:"varint,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
-	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустой — любой пол.
+	TargetGender string `protobuf:"bytes,1,opt,name=target_gender,json=targetGender,proto3" json:"target_gender,omitempty"`
+	MinAge       int32  `protobuf:"varint,2,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge       int32  `protobuf:"varint,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	Location     string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Limit        int32  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Пользователи, которых не нужно показывать (уже оценённые, заблокированные).
+	ExcludeIds []int64 `protobuf:"varint,6,rep,packed,name=exclude_ids,json=excludeIds,proto3" json:"exclude_ids,omitempty"`
+	// Геопозиция ищущего; если задана вместе с radius_km > 0, анкеты с геопозицией
+	// отбираются по расстоянию, а анкеты без неё — по городу.
+	Geo      *GeoPoint `protobuf:"bytes,7,opt,name=geo,proto3" json:"geo,omitempty"`
+	RadiusKm float64   `protobuf:"fixed64,8,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	// Искать без ограничения по городу и расстоянию.
+	AnyCity bool `protobuf:"varint,9,opt,name=any_city,json=anyCity,proto3" json:"any_city,omitempty"`
+	// next_cursor предыдущей страницы; пустой — первая страница.
+	Cursor        string `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -370,28 +444,64 @@ func (x *GetCandidatesRequest) GetLimit() int32 {
 	return 0
 }
 
-type ToggleVisibilityRequest struct {
+func (x *GetCandidatesRequest) GetExcludeIds() []int64 {
+	if x != nil {
+		return x.ExcludeIds
+	}
+	return nil
+}
+
+func (x *GetCandidatesRequest) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *GetCandidatesRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *GetCandidatesRequest) GetAnyCity() bool {
+	if x != nil {
+		return x.AnyCity
+	}
+	return false
+}
+
+func (x *GetCandidatesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Настройки поиска заменяются целиком.
+type UpdateSearchPrefsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	IsVisible     bool                   `protobuf:"varint,2,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	Prefs         *SearchPrefs           `protobuf:"bytes,2,opt,name=prefs,proto3" json:"prefs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToggleVisibilityRequest) Reset() {
-	*x = ToggleVisibilityRequest{}
+func (x *UpdateSearchPrefsRequest) Reset() {
+	*x = UpdateSearchPrefsRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToggleVisibilityRequest) String() string {
+func (x *UpdateSearchPrefsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleVisibilityRequest) ProtoMessage() {}
+func (*UpdateSearchPrefsRequest) ProtoMessage() {}
 
-func (x *ToggleVisibilityRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateSearchPrefsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -403,47 +513,47 @@ func (x *ToggleVisibilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleVisibilityRequest.ProtoReflect.Descriptor instead.
-func (*ToggleVisibilityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateSearchPrefsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSearchPrefsRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ToggleVisibilityRequest) GetUserId() int64 {
+func (x *UpdateSearchPrefsRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *ToggleVisibilityRequest) GetIsVisible() bool {
+func (x *UpdateSearchPrefsRequest) GetPrefs() *SearchPrefs {
 	if x != nil {
-		return x.IsVisible
+		return x.Prefs
 	}
-	return false
+	return nil
 }
 
-type PhotoUploadRequest struct {
+type ToggleVisibilityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	File          []byte                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	IsVisible     bool                   `protobuf:"varint,2,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PhotoUploadRequest) Reset() {
-	*x = PhotoUploadRequest{}
+func (x *ToggleVisibilityRequest) Reset() {
+	*x = ToggleVisibilityRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PhotoUploadRequest) String() string {
+func (x *ToggleVisibilityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhotoUploadRequest) ProtoMessage() {}
+func (*ToggleVisibilityRequest) ProtoMessage() {}
 
-func (x *PhotoUploadRequest) ProtoReflect() protoreflect.Message {
+func (x *ToggleVisibilityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -455,47 +565,48 @@ func (x *PhotoUploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PhotoUploadRequest.ProtoReflect.Descriptor instead.
-func (*PhotoUploadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ToggleVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*ToggleVisibilityRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PhotoUploadRequest) GetUserId() int64 {
+func (x *ToggleVisibilityRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *PhotoUploadRequest) GetFile() []byte {
+func (x *ToggleVisibilityRequest) GetIsVisible() bool {
 	if x != nil {
-		return x.File
+		return x.IsVisible
 	}
-	return nil
+	return false
 }
 
-// -------------------- Responses --------------------
-type UserResponse struct {
+// Загружает новое основное фото (первое в анкете).
+type PhotoUploadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	File          []byte                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserResponse) Reset() {
-	*x = UserResponse{}
+func (x *PhotoUploadRequest) Reset() {
+	*x = PhotoUploadRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserResponse) String() string {
+func (x *PhotoUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserResponse) ProtoMessage() {}
+func (*PhotoUploadRequest) ProtoMessage() {}
 
-func (x *UserResponse) ProtoReflect() protoreflect.Message {
+func (x *PhotoUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -507,39 +618,48 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
-func (*UserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PhotoUploadRequest.ProtoReflect.Descriptor instead.
+func (*PhotoUploadRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UserResponse) GetUser() *User {
+func (x *PhotoUploadRequest) GetUserId() int64 {
 	if x != nil {
-		return x.User
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PhotoUploadRequest) GetFile() []byte {
+	if x != nil {
+		return x.File
 	}
 	return nil
 }
 
-type GetCandidatesResponse struct {
+// Добавляет фото в конец анкеты.
+type AddPhotoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Candidates    []*User                `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	File          []byte                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCandidatesResponse) Reset() {
-	*x = GetCandidatesResponse{}
+func (x *AddPhotoRequest) Reset() {
+	*x = AddPhotoRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCandidatesResponse) String() string {
+func (x *AddPhotoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCandidatesResponse) ProtoMessage() {}
+func (*AddPhotoRequest) ProtoMessage() {}
 
-func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
+func (x *AddPhotoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -551,39 +671,47 @@ func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCandidatesResponse.ProtoReflect.Descriptor instead.
-func (*GetCandidatesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddPhotoRequest.ProtoReflect.Descriptor instead.
+func (*AddPhotoRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetCandidatesResponse) GetCandidates() []*User {
+func (x *AddPhotoRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Candidates
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddPhotoRequest) GetFile() []byte {
+	if x != nil {
+		return x.File
 	}
 	return nil
 }
 
-type ToggleVisibilityResponse struct {
+type RemovePhotoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PhotoId       int64                  `protobuf:"varint,2,opt,name=photo_id,json=photoId,proto3" json:"photo_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToggleVisibilityResponse) Reset() {
-	*x = ToggleVisibilityResponse{}
+func (x *RemovePhotoRequest) Reset() {
+	*x = RemovePhotoRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToggleVisibilityResponse) String() string {
+func (x *RemovePhotoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToggleVisibilityResponse) ProtoMessage() {}
+func (*RemovePhotoRequest) ProtoMessage() {}
 
-func (x *ToggleVisibilityResponse) ProtoReflect() protoreflect.Message {
+func (x *RemovePhotoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -595,39 +723,48 @@ func (x *ToggleVisibilityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToggleVisibilityResponse.ProtoReflect.Descriptor instead.
-func (*ToggleVisibilityResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemovePhotoRequest.ProtoReflect.Descriptor instead.
+func (*RemovePhotoRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ToggleVisibilityResponse) GetSuccess() bool {
+func (x *RemovePhotoRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Success
+		return x.UserId
 	}
-	return false
+	return 0
 }
 
-type PhotoUploadResponse struct {
+func (x *RemovePhotoRequest) GetPhotoId() int64 {
+	if x != nil {
+		return x.PhotoId
+	}
+	return 0
+}
+
+// photo_ids — все фото пользователя в новом порядке, первое становится основным.
+type ReorderPhotosRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PhotoUrl      string                 `protobuf:"bytes,1,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PhotoIds      []int64                `protobuf:"varint,2,rep,packed,name=photo_ids,json=photoIds,proto3" json:"photo_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PhotoUploadResponse) Reset() {
-	*x = PhotoUploadResponse{}
+func (x *ReorderPhotosRequest) Reset() {
+	*x = ReorderPhotosRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PhotoUploadResponse) String() string {
+func (x *ReorderPhotosRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhotoUploadResponse) ProtoMessage() {}
+func (*ReorderPhotosRequest) ProtoMessage() {}
 
-func (x *PhotoUploadResponse) ProtoReflect() protoreflect.Message {
+func (x *ReorderPhotosRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -639,49 +776,47 @@ func (x *PhotoUploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PhotoUploadResponse.ProtoReflect.Descriptor instead.
-func (*PhotoUploadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReorderPhotosRequest.ProtoReflect.Descriptor instead.
+func (*ReorderPhotosRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PhotoUploadResponse) GetPhotoUrl() string {
+func (x *ReorderPhotosRequest) GetUserId() int64 {
 	if x != nil {
-		return x.PhotoUrl
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-// -------------------- Entities --------------------
-type User struct {
+func (x *ReorderPhotosRequest) GetPhotoIds() []int64 {
+	if x != nil {
+		return x.PhotoIds
+	}
+	return nil
+}
+
+// Удаляет анкету и все фото пользователя.
+type DeleteAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TelegramId    int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	Age           int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
-	Gender        string                 `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
-	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	PhotoUrl      string                 `protobuf:"bytes,8,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
-	IsVisible     bool                   `protobuf:"varint,9,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
 	mi := &file_user_proto_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *DeleteAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*DeleteAccountRequest) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -693,77 +828,1465 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_user_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *User) GetId() int64 {
+func (x *DeleteAccountRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *User) GetTelegramId() int64 {
-	if x != nil {
-		return x.TelegramId
-	}
-	return 0
+// -------------------- Responses --------------------
+type UserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+func (x *UserResponse) Reset() {
+	*x = UserResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) GetAge() int32 {
-	if x != nil {
-		return x.Age
-	}
-	return 0
+func (x *UserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *User) GetGender() string {
+func (*UserResponse) ProtoMessage() {}
+
+func (x *UserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[12]
 	if x != nil {
-		return x.Gender
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetLocation() string {
-	if x != nil {
-		return x.Location
-	}
-	return ""
+// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
+func (*UserResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *User) GetDescription() string {
+func (x *UserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetCandidatesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Candidates []*User                `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Пустой, если анкет больше нет.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandidatesResponse) Reset() {
+	*x = GetCandidatesResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandidatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandidatesResponse) ProtoMessage() {}
+
+func (x *GetCandidatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandidatesResponse.ProtoReflect.Descriptor instead.
+func (*GetCandidatesResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetCandidatesResponse) GetCandidates() []*User {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *GetCandidatesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ToggleVisibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleVisibilityResponse) Reset() {
+	*x = ToggleVisibilityResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleVisibilityResponse) ProtoMessage() {}
+
+func (x *ToggleVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*ToggleVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ToggleVisibilityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type PhotoUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhotoUrl      string                 `protobuf:"bytes,1,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhotoUploadResponse) Reset() {
+	*x = PhotoUploadResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhotoUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhotoUploadResponse) ProtoMessage() {}
+
+func (x *PhotoUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhotoUploadResponse.ProtoReflect.Descriptor instead.
+func (*PhotoUploadResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PhotoUploadResponse) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+type PhotosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Photos        []*Photo               `protobuf:"bytes,1,rep,name=photos,proto3" json:"photos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhotosResponse) Reset() {
+	*x = PhotosResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhotosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhotosResponse) ProtoMessage() {}
+
+func (x *PhotosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhotosResponse.ProtoReflect.Descriptor instead.
+func (*PhotosResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PhotosResponse) GetPhotos() []*Photo {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// -------------------- Entities --------------------
+type User struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TelegramId  int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	Username    string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Age         int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	Gender      string                 `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
+	Location    string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Description string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	PhotoUrl    string                 `protobuf:"bytes,8,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	IsVisible   bool                   `protobuf:"varint,9,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Photos      []*Photo               `protobuf:"bytes,11,rep,name=photos,proto3" json:"photos,omitempty"`
+	Timezone    string                 `protobuf:"bytes,12,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	IsBanned    bool                   `protobuf:"varint,13,opt,name=is_banned,json=isBanned,proto3" json:"is_banned,omitempty"`
+	BotBlocked  bool                   `protobuf:"varint,14,opt,name=bot_blocked,json=botBlocked,proto3" json:"bot_blocked,omitempty"`
+	Geo         *GeoPoint              `protobuf:"bytes,15,opt,name=geo,proto3" json:"geo,omitempty"`
+	SearchPrefs *SearchPrefs           `protobuf:"bytes,16,opt,name=search_prefs,json=searchPrefs,proto3" json:"search_prefs,omitempty"`
+	ReferrerId  int64                  `protobuf:"varint,17,opt,name=referrer_id,json=referrerId,proto3" json:"referrer_id,omitempty"`
+	Campaign    string                 `protobuf:"bytes,18,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// Пользователь хоть раз поставил лайк.
+	Activated bool   `protobuf:"varint,19,opt,name=activated,proto3" json:"activated,omitempty"`
+	Intro     *Intro `protobuf:"bytes,20,opt,name=intro,proto3" json:"intro,omitempty"`
+	// Имя пользователя в Telegram без "@"; пустое — неизвестно.
+	TgUsername    string `protobuf:"bytes,21,opt,name=tg_username,json=tgUsername,proto3" json:"tg_username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_user_proto_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetTelegramId() int64 {
+	if x != nil {
+		return x.TelegramId
+	}
+	return 0
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *User) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *User) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *User) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *User) GetIsVisible() bool {
+	if x != nil {
+		return x.IsVisible
+	}
+	return false
+}
+
+func (x *User) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *User) GetPhotos() []*Photo {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+func (x *User) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *User) GetIsBanned() bool {
+	if x != nil {
+		return x.IsBanned
+	}
+	return false
+}
+
+func (x *User) GetBotBlocked() bool {
+	if x != nil {
+		return x.BotBlocked
+	}
+	return false
+}
+
+func (x *User) GetGeo() *GeoPoint {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *User) GetSearchPrefs() *SearchPrefs {
+	if x != nil {
+		return x.SearchPrefs
+	}
+	return nil
+}
+
+func (x *User) GetReferrerId() int64 {
+	if x != nil {
+		return x.ReferrerId
+	}
+	return 0
+}
+
+func (x *User) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *User) GetActivated() bool {
+	if x != nil {
+		return x.Activated
+	}
+	return false
+}
+
+func (x *User) GetIntro() *Intro {
+	if x != nil {
+		return x.Intro
+	}
+	return nil
+}
+
+func (x *User) GetTgUsername() string {
+	if x != nil {
+		return x.TgUsername
+	}
+	return ""
+}
+
+// Нулевые значения — поиск по умолчанию: противоположный пол, возраст ±3 года,
+// свой город (или радиус по умолчанию, если есть геопозиция).
+type SearchPrefs struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "" — противоположный пол, "any" — любой, иначе — искомый пол.
+	Gender        string `protobuf:"bytes,1,opt,name=gender,proto3" json:"gender,omitempty"`
+	MinAge        int32  `protobuf:"varint,2,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge        int32  `protobuf:"varint,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	RadiusKm      int32  `protobuf:"varint,4,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	AnyCity       bool   `protobuf:"varint,5,opt,name=any_city,json=anyCity,proto3" json:"any_city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPrefs) Reset() {
+	*x = SearchPrefs{}
+	mi := &file_user_proto_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPrefs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPrefs) ProtoMessage() {}
+
+func (x *SearchPrefs) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPrefs.ProtoReflect.Descriptor instead.
+func (*SearchPrefs) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchPrefs) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *SearchPrefs) GetMinAge() int32 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *SearchPrefs) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *SearchPrefs) GetRadiusKm() int32 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *SearchPrefs) GetAnyCity() bool {
+	if x != nil {
+		return x.AnyCity
+	}
+	return false
+}
+
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_user_proto_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GeoPoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Photo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	IsPrimary     bool                   `protobuf:"varint,4,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Photo) Reset() {
+	*x = Photo{}
+	mi := &file_user_proto_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Photo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Photo) ProtoMessage() {}
+
+func (x *Photo) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Photo.ProtoReflect.Descriptor instead.
+func (*Photo) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Photo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Photo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Photo) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Photo) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+// Голосовое сообщение или видео-кружок анкеты.
+type Intro struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "voice" или "video_note".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Url  string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Длительность в секундах.
+	Duration int32 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// file_id в Telegram, по которому бот отправляет интро без скачивания.
+	FileId        string `protobuf:"bytes,4,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Intro) Reset() {
+	*x = Intro{}
+	mi := &file_user_proto_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Intro) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intro) ProtoMessage() {}
+
+func (x *Intro) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intro.ProtoReflect.Descriptor instead.
+func (*Intro) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Intro) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Intro) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Intro) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Intro) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// Блокировка скрывает анкету; разблокировка видимость не возвращает.
+type SetBannedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Banned        bool                   `protobuf:"varint,2,opt,name=banned,proto3" json:"banned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBannedRequest) Reset() {
+	*x = SetBannedRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBannedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBannedRequest) ProtoMessage() {}
+
+func (x *SetBannedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBannedRequest.ProtoReflect.Descriptor instead.
+func (*SetBannedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetBannedRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetBannedRequest) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+type SetBannedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBannedResponse) Reset() {
+	*x = SetBannedResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBannedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBannedResponse) ProtoMessage() {}
+
+func (x *SetBannedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBannedResponse.ProtoReflect.Descriptor instead.
+func (*SetBannedResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{24}
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начало периода для registered, unix-время в секундах.
+	Since         int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetStatsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Registered    int64                  `protobuf:"varint,2,opt,name=registered,proto3" json:"registered,omitempty"`
+	Visible       int64                  `protobuf:"varint,3,opt,name=visible,proto3" json:"visible,omitempty"`
+	Banned        int64                  `protobuf:"varint,4,opt,name=banned,proto3" json:"banned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetRegistered() int64 {
+	if x != nil {
+		return x.Registered
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetVisible() int64 {
+	if x != nil {
+		return x.Visible
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetBanned() int64 {
+	if x != nil {
+		return x.Banned
+	}
+	return 0
+}
+
+// Пользователь заблокировал бота: рассылки ему не отправляются.
+type SetBotBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Blocked       bool                   `protobuf:"varint,2,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBotBlockedRequest) Reset() {
+	*x = SetBotBlockedRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBotBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBotBlockedRequest) ProtoMessage() {}
+
+func (x *SetBotBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBotBlockedRequest.ProtoReflect.Descriptor instead.
+func (*SetBotBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetBotBlockedRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetBotBlockedRequest) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+type SetBotBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBotBlockedResponse) Reset() {
+	*x = SetBotBlockedResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBotBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBotBlockedResponse) ProtoMessage() {}
+
+func (x *SetBotBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBotBlockedResponse.ProtoReflect.Descriptor instead.
+func (*SetBotBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{28}
+}
+
+// Страница пользователей, которым можно писать (для рассылок), по возрастанию id.
+type ListUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id последнего пользователя предыдущей страницы, 0 — с начала.
+	AfterId int64 `protobuf:"varint,1,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	// 0 — 100, больше 1000 не отдаётся.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListUsersRequest) GetAfterId() int64 {
 	if x != nil {
-		return x.Description
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// Отмечает, что пользователь стал активным (первый лайк). Повторные вызовы ничего не меняют.
+type MarkActivatedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkActivatedRequest) Reset() {
+	*x = MarkActivatedRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkActivatedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkActivatedRequest) ProtoMessage() {}
+
+func (x *MarkActivatedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkActivatedRequest.ProtoReflect.Descriptor instead.
+func (*MarkActivatedRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MarkActivatedRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type MarkActivatedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkActivatedResponse) Reset() {
+	*x = MarkActivatedResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkActivatedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkActivatedResponse) ProtoMessage() {}
+
+func (x *MarkActivatedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkActivatedResponse.ProtoReflect.Descriptor instead.
+func (*MarkActivatedResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{32}
+}
+
+// since — unix-время; считаются пользователи, зарегистрированные начиная с него (0 — за всё время).
+type GetCampaignStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         int64                  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignStatsRequest) Reset() {
+	*x = GetCampaignStatsRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignStatsRequest) ProtoMessage() {}
+
+func (x *GetCampaignStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignStatsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetCampaignStatsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type CampaignStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустая метка — пользователи, пришедшие без deep-link.
+	Campaign      string `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Registered    int64  `protobuf:"varint,2,opt,name=registered,proto3" json:"registered,omitempty"`
+	Activated     int64  `protobuf:"varint,3,opt,name=activated,proto3" json:"activated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignStats) Reset() {
+	*x = CampaignStats{}
+	mi := &file_user_proto_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStats) ProtoMessage() {}
+
+func (x *CampaignStats) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStats.ProtoReflect.Descriptor instead.
+func (*CampaignStats) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CampaignStats) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
 	}
 	return ""
 }
 
-func (x *User) GetPhotoUrl() string {
+func (x *CampaignStats) GetRegistered() int64 {
 	if x != nil {
-		return x.PhotoUrl
+		return x.Registered
+	}
+	return 0
+}
+
+func (x *CampaignStats) GetActivated() int64 {
+	if x != nil {
+		return x.Activated
+	}
+	return 0
+}
+
+type GetCampaignStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaigns     []*CampaignStats       `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignStatsResponse) Reset() {
+	*x = GetCampaignStatsResponse{}
+	mi := &file_user_proto_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignStatsResponse) ProtoMessage() {}
+
+func (x *GetCampaignStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignStatsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCampaignStatsResponse) GetCampaigns() []*CampaignStats {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+// Заменяет интро анкеты. Пустой файл, неизвестный вид, длительность больше минуты
+// или размер больше 3 МБ — INVALID_ARGUMENT.
+type SetIntroRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	File          []byte                 `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Duration      int32                  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	FileId        string                 `protobuf:"bytes,5,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIntroRequest) Reset() {
+	*x = SetIntroRequest{}
+	mi := &file_user_proto_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIntroRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIntroRequest) ProtoMessage() {}
+
+func (x *SetIntroRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIntroRequest.ProtoReflect.Descriptor instead.
+func (*SetIntroRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetIntroRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetIntroRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *User) GetIsVisible() bool {
+func (x *SetIntroRequest) GetFile() []byte {
 	if x != nil {
-		return x.IsVisible
+		return x.File
 	}
-	return false
+	return nil
 }
 
-func (x *User) GetCreatedAt() string {
+func (x *SetIntroRequest) GetDuration() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SetIntroRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
 	}
 	return ""
 }
@@ -775,7 +2298,7 @@ const file_user_proto_user_proto_rawDesc = "" +
 	"\x15user/proto/user.proto\x12\x04user\"9\n" +
 	"\x16GetByTelegramIDRequest\x12\x1f\n" +
 	"\vtelegram_id\x18\x01 \x01(\x03R\n" +
-	"telegramId\"\xd9\x01\n" +
+	"telegramId\"\xd9\x02\n" +
 	"\x13RegisterUserRequest\x12\x1f\n" +
 	"\vtelegram_id\x18\x01 \x01(\x03R\n" +
 	"telegramId\x12\x1a\n" +
@@ -785,9 +2308,16 @@ const file_user_proto_user_proto_rawDesc = "" +
 	"\blocation\x18\x05 \x01(\tR\blocation\x12 \n" +
 	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1d\n" +
 	"\n" +
-	"is_visible\x18\a \x01(\bR\tisVisible\",\n" +
+	"is_visible\x18\a \x01(\bR\tisVisible\x12 \n" +
+	"\x03geo\x18\b \x01(\v2\x0e.user.GeoPointR\x03geo\x12\x1f\n" +
+	"\vreferrer_id\x18\t \x01(\x03R\n" +
+	"referrerId\x12\x1a\n" +
+	"\bcampaign\x18\n" +
+	" \x01(\tR\bcampaign\x12\x1f\n" +
+	"\vtg_username\x18\v \x01(\tR\n" +
+	"tgUsername\",\n" +
 	"\x11GetProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xd2\x01\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xb1\x02\n" +
 	"\x14UpdateProfileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
@@ -796,32 +2326,64 @@ const file_user_proto_user_proto_rawDesc = "" +
 	"\blocation\x18\x05 \x01(\tR\blocation\x12 \n" +
 	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1d\n" +
 	"\n" +
-	"is_visible\x18\a \x01(\bR\tisVisible\"\x9f\x01\n" +
+	"is_visible\x18\a \x01(\bR\tisVisible\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12 \n" +
+	"\x03geo\x18\t \x01(\v2\x0e.user.GeoPointR\x03geo\x12\x1f\n" +
+	"\vtg_username\x18\n" +
+	" \x01(\tR\n" +
+	"tgUsername\"\xb2\x02\n" +
 	"\x14GetCandidatesRequest\x12#\n" +
 	"\rtarget_gender\x18\x01 \x01(\tR\ftargetGender\x12\x17\n" +
 	"\amin_age\x18\x02 \x01(\x05R\x06minAge\x12\x17\n" +
 	"\amax_age\x18\x03 \x01(\x05R\x06maxAge\x12\x1a\n" +
 	"\blocation\x18\x04 \x01(\tR\blocation\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\"Q\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vexclude_ids\x18\x06 \x03(\x03R\n" +
+	"excludeIds\x12 \n" +
+	"\x03geo\x18\a \x01(\v2\x0e.user.GeoPointR\x03geo\x12\x1b\n" +
+	"\tradius_km\x18\b \x01(\x01R\bradiusKm\x12\x19\n" +
+	"\bany_city\x18\t \x01(\bR\aanyCity\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\"\\\n" +
+	"\x18UpdateSearchPrefsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12'\n" +
+	"\x05prefs\x18\x02 \x01(\v2\x11.user.SearchPrefsR\x05prefs\"Q\n" +
 	"\x17ToggleVisibilityRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
 	"\n" +
 	"is_visible\x18\x02 \x01(\bR\tisVisible\"A\n" +
 	"\x12PhotoUploadRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
-	"\x04file\x18\x02 \x01(\fR\x04file\".\n" +
+	"\x04file\x18\x02 \x01(\fR\x04file\">\n" +
+	"\x0fAddPhotoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\fR\x04file\"H\n" +
+	"\x12RemovePhotoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x19\n" +
+	"\bphoto_id\x18\x02 \x01(\x03R\aphotoId\"L\n" +
+	"\x14ReorderPhotosRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\tphoto_ids\x18\x02 \x03(\x03R\bphotoIds\"/\n" +
+	"\x14DeleteAccountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\".\n" +
 	"\fUserResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"C\n" +
+	".user.UserR\x04user\"d\n" +
 	"\x15GetCandidatesResponse\x12*\n" +
 	"\n" +
 	"candidates\x18\x01 \x03(\v2\n" +
 	".user.UserR\n" +
-	"candidates\"4\n" +
+	"candidates\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"4\n" +
 	"\x18ToggleVisibilityResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"2\n" +
 	"\x13PhotoUploadResponse\x12\x1b\n" +
-	"\tphoto_url\x18\x01 \x01(\tR\bphotoUrl\"\x96\x02\n" +
+	"\tphoto_url\x18\x01 \x01(\tR\bphotoUrl\"5\n" +
+	"\x0ePhotosResponse\x12#\n" +
+	"\x06photos\x18\x01 \x03(\v2\v.user.PhotoR\x06photos\"1\n" +
+	"\x15DeleteAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8c\x05\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vtelegram_id\x18\x02 \x01(\x03R\n" +
@@ -836,7 +2398,84 @@ const file_user_proto_user_proto_rawDesc = "" +
 	"is_visible\x18\t \x01(\bR\tisVisible\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\tR\tcreatedAt2\xee\x03\n" +
+	" \x01(\tR\tcreatedAt\x12#\n" +
+	"\x06photos\x18\v \x03(\v2\v.user.PhotoR\x06photos\x12\x1a\n" +
+	"\btimezone\x18\f \x01(\tR\btimezone\x12\x1b\n" +
+	"\tis_banned\x18\r \x01(\bR\bisBanned\x12\x1f\n" +
+	"\vbot_blocked\x18\x0e \x01(\bR\n" +
+	"botBlocked\x12 \n" +
+	"\x03geo\x18\x0f \x01(\v2\x0e.user.GeoPointR\x03geo\x124\n" +
+	"\fsearch_prefs\x18\x10 \x01(\v2\x11.user.SearchPrefsR\vsearchPrefs\x12\x1f\n" +
+	"\vreferrer_id\x18\x11 \x01(\x03R\n" +
+	"referrerId\x12\x1a\n" +
+	"\bcampaign\x18\x12 \x01(\tR\bcampaign\x12\x1c\n" +
+	"\tactivated\x18\x13 \x01(\bR\tactivated\x12!\n" +
+	"\x05intro\x18\x14 \x01(\v2\v.user.IntroR\x05intro\x12\x1f\n" +
+	"\vtg_username\x18\x15 \x01(\tR\n" +
+	"tgUsername\"\x8f\x01\n" +
+	"\vSearchPrefs\x12\x16\n" +
+	"\x06gender\x18\x01 \x01(\tR\x06gender\x12\x17\n" +
+	"\amin_age\x18\x02 \x01(\x05R\x06minAge\x12\x17\n" +
+	"\amax_age\x18\x03 \x01(\x05R\x06maxAge\x12\x1b\n" +
+	"\tradius_km\x18\x04 \x01(\x05R\bradiusKm\x12\x19\n" +
+	"\bany_city\x18\x05 \x01(\bR\aanyCity\"D\n" +
+	"\bGeoPoint\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"d\n" +
+	"\x05Photo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x04 \x01(\bR\tisPrimary\"b\n" +
+	"\x05Intro\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1a\n" +
+	"\bduration\x18\x03 \x01(\x05R\bduration\x12\x17\n" +
+	"\afile_id\x18\x04 \x01(\tR\x06fileId\"C\n" +
+	"\x10SetBannedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06banned\x18\x02 \x01(\bR\x06banned\"\x13\n" +
+	"\x11SetBannedResponse\"'\n" +
+	"\x0fGetStatsRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\"z\n" +
+	"\x10GetStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1e\n" +
+	"\n" +
+	"registered\x18\x02 \x01(\x03R\n" +
+	"registered\x12\x18\n" +
+	"\avisible\x18\x03 \x01(\x03R\avisible\x12\x16\n" +
+	"\x06banned\x18\x04 \x01(\x03R\x06banned\"I\n" +
+	"\x14SetBotBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x18\n" +
+	"\ablocked\x18\x02 \x01(\bR\ablocked\"\x17\n" +
+	"\x15SetBotBlockedResponse\"C\n" +
+	"\x10ListUsersRequest\x12\x19\n" +
+	"\bafter_id\x18\x01 \x01(\x03R\aafterId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"5\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\"/\n" +
+	"\x14MarkActivatedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x17\n" +
+	"\x15MarkActivatedResponse\"/\n" +
+	"\x17GetCampaignStatsRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\"i\n" +
+	"\rCampaignStats\x12\x1a\n" +
+	"\bcampaign\x18\x01 \x01(\tR\bcampaign\x12\x1e\n" +
+	"\n" +
+	"registered\x18\x02 \x01(\x03R\n" +
+	"registered\x12\x1c\n" +
+	"\tactivated\x18\x03 \x01(\x03R\tactivated\"M\n" +
+	"\x18GetCampaignStatsResponse\x121\n" +
+	"\tcampaigns\x18\x01 \x03(\v2\x13.user.CampaignStatsR\tcampaigns\"\x87\x01\n" +
+	"\x0fSetIntroRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04file\x18\x03 \x01(\fR\x04file\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x05R\bduration\x12\x17\n" +
+	"\afile_id\x18\x05 \x01(\tR\x06fileId2\x91\n" +
+	"\n" +
 	"\vUserService\x12C\n" +
 	"\x0fGetByTelegramID\x12\x1c.user.GetByTelegramIDRequest\x1a\x12.user.UserResponse\x12=\n" +
 	"\fRegisterUser\x12\x19.user.RegisterUserRequest\x1a\x12.user.UserResponse\x129\n" +
@@ -845,7 +2484,19 @@ const file_user_proto_user_proto_rawDesc = "" +
 	"\rUpdateProfile\x12\x1a.user.UpdateProfileRequest\x1a\x12.user.UserResponse\x12H\n" +
 	"\rGetCandidates\x12\x1a.user.GetCandidatesRequest\x1a\x1b.user.GetCandidatesResponse\x12Q\n" +
 	"\x10ToggleVisibility\x12\x1d.user.ToggleVisibilityRequest\x1a\x1e.user.ToggleVisibilityResponse\x12B\n" +
-	"\vPhotoUpload\x12\x18.user.PhotoUploadRequest\x1a\x19.user.PhotoUploadResponseB\x13Z\x11user/proto;userpbb\x06proto3"
+	"\vPhotoUpload\x12\x18.user.PhotoUploadRequest\x1a\x19.user.PhotoUploadResponse\x127\n" +
+	"\bAddPhoto\x12\x15.user.AddPhotoRequest\x1a\x14.user.PhotosResponse\x12=\n" +
+	"\vRemovePhoto\x12\x18.user.RemovePhotoRequest\x1a\x14.user.PhotosResponse\x12A\n" +
+	"\rReorderPhotos\x12\x1a.user.ReorderPhotosRequest\x1a\x14.user.PhotosResponse\x12H\n" +
+	"\rDeleteAccount\x12\x1a.user.DeleteAccountRequest\x1a\x1b.user.DeleteAccountResponse\x12<\n" +
+	"\tSetBanned\x12\x16.user.SetBannedRequest\x1a\x17.user.SetBannedResponse\x129\n" +
+	"\bGetStats\x12\x15.user.GetStatsRequest\x1a\x16.user.GetStatsResponse\x12H\n" +
+	"\rSetBotBlocked\x12\x1a.user.SetBotBlockedRequest\x1a\x1b.user.SetBotBlockedResponse\x12<\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x12G\n" +
+	"\x11UpdateSearchPrefs\x12\x1e.user.UpdateSearchPrefsRequest\x1a\x12.user.UserResponse\x12H\n" +
+	"\rMarkActivated\x12\x1a.user.MarkActivatedRequest\x1a\x1b.user.MarkActivatedResponse\x12Q\n" +
+	"\x10GetCampaignStats\x12\x1d.user.GetCampaignStatsRequest\x1a\x1e.user.GetCampaignStatsResponse\x125\n" +
+	"\bSetIntro\x12\x15.user.SetIntroRequest\x1a\x12.user.UserResponseB\x13Z\x11user/proto;userpbb\x06proto3"
 
 var (
 	file_user_proto_user_proto_rawDescOnce sync.Once
@@ -859,43 +2510,103 @@ func file_user_proto_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_user_proto_rawDescData
 }
 
-var file_user_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_user_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_user_proto_user_proto_goTypes = []any{
 	(*GetByTelegramIDRequest)(nil),   // 0: user.GetByTelegramIDRequest
 	(*RegisterUserRequest)(nil),      // 1: user.RegisterUserRequest
 	(*GetProfileRequest)(nil),        // 2: user.GetProfileRequest
 	(*UpdateProfileRequest)(nil),     // 3: user.UpdateProfileRequest
 	(*GetCandidatesRequest)(nil),     // 4: user.GetCandidatesRequest
-	(*ToggleVisibilityRequest)(nil),  // 5: user.ToggleVisibilityRequest
-	(*PhotoUploadRequest)(nil),       // 6: user.PhotoUploadRequest
-	(*UserResponse)(nil),             // 7: user.UserResponse
-	(*GetCandidatesResponse)(nil),    // 8: user.GetCandidatesResponse
-	(*ToggleVisibilityResponse)(nil), // 9: user.ToggleVisibilityResponse
-	(*PhotoUploadResponse)(nil),      // 10: user.PhotoUploadResponse
-	(*User)(nil),                     // 11: user.User
+	(*UpdateSearchPrefsRequest)(nil), // 5: user.UpdateSearchPrefsRequest
+	(*ToggleVisibilityRequest)(nil),  // 6: user.ToggleVisibilityRequest
+	(*PhotoUploadRequest)(nil),       // 7: user.PhotoUploadRequest
+	(*AddPhotoRequest)(nil),          // 8: user.AddPhotoRequest
+	(*RemovePhotoRequest)(nil),       // 9: user.RemovePhotoRequest
+	(*ReorderPhotosRequest)(nil),     // 10: user.ReorderPhotosRequest
+	(*DeleteAccountRequest)(nil),     // 11: user.DeleteAccountRequest
+	(*UserResponse)(nil),             // 12: user.UserResponse
+	(*GetCandidatesResponse)(nil),    // 13: user.GetCandidatesResponse
+	(*ToggleVisibilityResponse)(nil), // 14: user.ToggleVisibilityResponse
+	(*PhotoUploadResponse)(nil),      // 15: user.PhotoUploadResponse
+	(*PhotosResponse)(nil),           // 16: user.PhotosResponse
+	(*DeleteAccountResponse)(nil),    // 17: user.DeleteAccountResponse
+	(*User)(nil),                     // 18: user.User
+	(*SearchPrefs)(nil),              // 19: user.SearchPrefs
+	(*GeoPoint)(nil),                 // 20: user.GeoPoint
+	(*Photo)(nil),                    // 21: user.Photo
+	(*Intro)(nil),                    // 22: user.Intro
+	(*SetBannedRequest)(nil),         // 23: user.SetBannedRequest
+	(*SetBannedResponse)(nil),        // 24: user.SetBannedResponse
+	(*GetStatsRequest)(nil),          // 25: user.GetStatsRequest
+	(*GetStatsResponse)(nil),         // 26: user.GetStatsResponse
+	(*SetBotBlockedRequest)(nil),     // 27: user.SetBotBlockedRequest
+	(*SetBotBlockedResponse)(nil),    // 28: user.SetBotBlockedResponse
+	(*ListUsersRequest)(nil),         // 29: user.ListUsersRequest
+	(*ListUsersResponse)(nil),        // 30: user.ListUsersResponse
+	(*MarkActivatedRequest)(nil),     // 31: user.MarkActivatedRequest
+	(*MarkActivatedResponse)(nil),    // 32: user.MarkActivatedResponse
+	(*GetCampaignStatsRequest)(nil),  // 33: user.GetCampaignStatsRequest
+	(*CampaignStats)(nil),            // 34: user.CampaignStats
+	(*GetCampaignStatsResponse)(nil), // 35: user.GetCampaignStatsResponse
+	(*SetIntroRequest)(nil),          // 36: user.SetIntroRequest
 }
 var file_user_proto_user_proto_depIdxs = []int32{
-	11, // 0: user.UserResponse.user:type_name -> user.User
-	11, // 1: user.GetCandidatesResponse.candidates:type_name -> user.User
-	0,  // 2: user.UserService.GetByTelegramID:input_type -> user.GetByTelegramIDRequest
-	1,  // 3: user.UserService.RegisterUser:input_type -> user.RegisterUserRequest
-	2,  // 4: user.UserService.GetProfile:input_type -> user.GetProfileRequest
-	3,  // 5: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
-	4,  // 6: user.UserService.GetCandidates:input_type -> user.GetCandidatesRequest
-	5,  // 7: user.UserService.ToggleVisibility:input_type -> user.ToggleVisibilityRequest
-	6,  // 8: user.UserService.PhotoUpload:input_type -> user.PhotoUploadRequest
-	7,  // 9: user.UserService.GetByTelegramID:output_type -> user.UserResponse
-	7,  // 10: user.UserService.RegisterUser:output_type -> user.UserResponse
-	7,  // 11: user.UserService.GetProfile:output_type -> user.UserResponse
-	7,  // 12: user.UserService.UpdateProfile:output_type -> user.UserResponse
-	8,  // 13: user.UserService.GetCandidates:output_type -> user.GetCandidatesResponse
-	9,  // 14: user.UserService.ToggleVisibility:output_type -> user.ToggleVisibilityResponse
-	10, // 15: user.UserService.PhotoUpload:output_type -> user.PhotoUploadResponse
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	20, // 0: user.RegisterUserRequest.geo:type_name -> user.GeoPoint
+	20, // 1: user.UpdateProfileRequest.geo:type_name -> user.GeoPoint
+	20, // 2: user.GetCandidatesRequest.geo:type_name -> user.GeoPoint
+	19, // 3: user.UpdateSearchPrefsRequest.prefs:type_name -> user.SearchPrefs
+	18, // 4: user.UserResponse.user:type_name -> user.User
+	18, // 5: user.GetCandidatesResponse.candidates:type_name -> user.User
+	21, // 6: user.PhotosResponse.photos:type_name -> user.Photo
+	21, // 7: user.User.photos:type_name -> user.Photo
+	20, // 8: user.User.geo:type_name -> user.GeoPoint
+	19, // 9: user.User.search_prefs:type_name -> user.SearchPrefs
+	22, // 10: user.User.intro:type_name -> user.Intro
+	18, // 11: user.ListUsersResponse.users:type_name -> user.User
+	34, // 12: user.GetCampaignStatsResponse.campaigns:type_name -> user.CampaignStats
+	0,  // 13: user.UserService.GetByTelegramID:input_type -> user.GetByTelegramIDRequest
+	1,  // 14: user.UserService.RegisterUser:input_type -> user.RegisterUserRequest
+	2,  // 15: user.UserService.GetProfile:input_type -> user.GetProfileRequest
+	3,  // 16: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
+	4,  // 17: user.UserService.GetCandidates:input_type -> user.GetCandidatesRequest
+	6,  // 18: user.UserService.ToggleVisibility:input_type -> user.ToggleVisibilityRequest
+	7,  // 19: user.UserService.PhotoUpload:input_type -> user.PhotoUploadRequest
+	8,  // 20: user.UserService.AddPhoto:input_type -> user.AddPhotoRequest
+	9,  // 21: user.UserService.RemovePhoto:input_type -> user.RemovePhotoRequest
+	10, // 22: user.UserService.ReorderPhotos:input_type -> user.ReorderPhotosRequest
+	11, // 23: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
+	23, // 24: user.UserService.SetBanned:input_type -> user.SetBannedRequest
+	25, // 25: user.UserService.GetStats:input_type -> user.GetStatsRequest
+	27, // 26: user.UserService.SetBotBlocked:input_type -> user.SetBotBlockedRequest
+	29, // 27: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	5,  // 28: user.UserService.UpdateSearchPrefs:input_type -> user.UpdateSearchPrefsRequest
+	31, // 29: user.UserService.MarkActivated:input_type -> user.MarkActivatedRequest
+	33, // 30: user.UserService.GetCampaignStats:input_type -> user.GetCampaignStatsRequest
+	36, // 31: user.UserService.SetIntro:input_type -> user.SetIntroRequest
+	12, // 32: user.UserService.GetByTelegramID:output_type -> user.UserResponse
+	12, // 33: user.UserService.RegisterUser:output_type -> user.UserResponse
+	12, // 34: user.UserService.GetProfile:output_type -> user.UserResponse
+	12, // 35: user.UserService.UpdateProfile:output_type -> user.UserResponse
+	13, // 36: user.UserService.GetCandidates:output_type -> user.GetCandidatesResponse
+	14, // 37: user.UserService.ToggleVisibility:output_type -> user.ToggleVisibilityResponse
+	15, // 38: user.UserService.PhotoUpload:output_type -> user.PhotoUploadResponse
+	16, // 39: user.UserService.AddPhoto:output_type -> user.PhotosResponse
+	16, // 40: user.UserService.RemovePhoto:output_type -> user.PhotosResponse
+	16, // 41: user.UserService.ReorderPhotos:output_type -> user.PhotosResponse
+	17, // 42: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
+	24, // 43: user.UserService.SetBanned:output_type -> user.SetBannedResponse
+	26, // 44: user.UserService.GetStats:output_type -> user.GetStatsResponse
+	28, // 45: user.UserService.SetBotBlocked:output_type -> user.SetBotBlockedResponse
+	30, // 46: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	12, // 47: user.UserService.UpdateSearchPrefs:output_type -> user.UserResponse
+	32, // 48: user.UserService.MarkActivated:output_type -> user.MarkActivatedResponse
+	35, // 49: user.UserService.GetCampaignStats:output_type -> user.GetCampaignStatsResponse
+	12, // 50: user.UserService.SetIntro:output_type -> user.UserResponse
+	32, // [32:51] is the sub-list for method output_type
+	13, // [13:32] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_user_proto_init() }
@@ -909,7 +2620,7 @@ func file_user_proto_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_user_proto_rawDesc), len(file_user_proto_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetByTelegramID_FullMethodName  = "/user.UserService/GetByTelegramID"
-	UserService_RegisterUser_FullMethodName     = "/user.UserService/RegisterUser"
-	UserService_GetProfile_FullMethodName       = "/user.UserService/GetProfile"
-	UserService_UpdateProfile_FullMethodName    = "/user.UserService/UpdateProfile"
-	UserService_GetCandidates_FullMethodName    = "/user.UserService/GetCandidates"
-	UserService_ToggleVisibility_FullMethodName = "/user.UserService/ToggleVisibility"
-	UserService_PhotoUpload_FullMethodName      = "/user.UserService/PhotoUpload"
+	UserService_GetByTelegramID_FullMethodName   = "/user.UserService/GetByTelegramID"
+	UserService_RegisterUser_FullMethodName      = "/user.UserService/RegisterUser"
+	UserService_GetProfile_FullMethodName        = "/user.UserService/GetProfile"
+	UserService_UpdateProfile_FullMethodName     = "/user.UserService/UpdateProfile"
+	UserService_GetCandidates_FullMethodName     = "/user.UserService/GetCandidates"
+	UserService_ToggleVisibility_FullMethodName  = "/user.UserService/ToggleVisibility"
+	UserService_PhotoUpload_FullMethodName       = "/user.UserService/PhotoUpload"
+	UserService_AddPhoto_FullMethodName          = "/user.UserService/AddPhoto"
+	UserService_RemovePhoto_FullMethodName       = "/user.UserService/RemovePhoto"
+	UserService_ReorderPhotos_FullMethodName     = "/user.UserService/ReorderPhotos"
+	UserService_DeleteAccount_FullMethodName     = "/user.UserService/DeleteAccount"
+	UserService_SetBanned_FullMethodName         = "/user.UserService/SetBanned"
+	UserService_GetStats_FullMethodName          = "/user.UserService/GetStats"
+	UserService_SetBotBlocked_FullMethodName     = "/user.UserService/SetBotBlocked"
+	UserService_ListUsers_FullMethodName         = "/user.UserService/ListUsers"
+	UserService_UpdateSearchPrefs_FullMethodName = "/user.UserService/UpdateSearchPrefs"
+	UserService_MarkActivated_FullMethodName     = "/user.UserService/MarkActivated"
+	UserService_GetCampaignStats_FullMethodName  = "/user.UserService/GetCampaignStats"
+	UserService_SetIntro_FullMethodName          = "/user.UserService/SetIntro"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -39,6 +51,18 @@ type UserServiceClient interface {
 	GetCandidates(ctx context.Context, in *GetCandidatesRequest, opts ...grpc.CallOption) (*GetCandidatesResponse, error)
 	ToggleVisibility(ctx context.Context, in *ToggleVisibilityRequest, opts ...grpc.CallOption) (*ToggleVisibilityResponse, error)
 	PhotoUpload(ctx context.Context, in *PhotoUploadRequest, opts ...grpc.CallOption) (*PhotoUploadResponse, error)
+	AddPhoto(ctx context.Context, in *AddPhotoRequest, opts ...grpc.CallOption) (*PhotosResponse, error)
+	RemovePhoto(ctx context.Context, in *RemovePhotoRequest, opts ...grpc.CallOption) (*PhotosResponse, error)
+	ReorderPhotos(ctx context.Context, in *ReorderPhotosRequest, opts ...grpc.CallOption) (*PhotosResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	SetBanned(ctx context.Context, in *SetBannedRequest, opts ...grpc.CallOption) (*SetBannedResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	SetBotBlocked(ctx context.Context, in *SetBotBlockedRequest, opts ...grpc.CallOption) (*SetBotBlockedResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	UpdateSearchPrefs(ctx context.Context, in *UpdateSearchPrefsRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	MarkActivated(ctx context.Context, in *MarkActivatedRequest, opts ...grpc.CallOption) (*MarkActivatedResponse, error)
+	GetCampaignStats(ctx context.Context, in *GetCampaignStatsRequest, opts ...grpc.CallOption) (*GetCampaignStatsResponse, error)
+	SetIntro(ctx context.Context, in *SetIntroRequest, opts ...grpc.CallOption) (*UserResponse, error)
 }
 
 type userServiceClient struct {
@@ -119,6 +143,126 @@ func (c *userServiceClient) PhotoUpload(ctx context.Context, in *PhotoUploadRequ
 	return out, nil
 }
 
+func (c *userServiceClient) AddPhoto(ctx context.Context, in *AddPhotoRequest, opts ...grpc.CallOption) (*PhotosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PhotosResponse)
+	err := c.cc.Invoke(ctx, UserService_AddPhoto_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemovePhoto(ctx context.Context, in *RemovePhotoRequest, opts ...grpc.CallOption) (*PhotosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PhotosResponse)
+	err := c.cc.Invoke(ctx, UserService_RemovePhoto_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReorderPhotos(ctx context.Context, in *ReorderPhotosRequest, opts ...grpc.CallOption) (*PhotosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PhotosResponse)
+	err := c.cc.Invoke(ctx, UserService_ReorderPhotos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetBanned(ctx context.Context, in *SetBannedRequest, opts ...grpc.CallOption) (*SetBannedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBannedResponse)
+	err := c.cc.Invoke(ctx, UserService_SetBanned_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetBotBlocked(ctx context.Context, in *SetBotBlockedRequest, opts ...grpc.CallOption) (*SetBotBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBotBlockedResponse)
+	err := c.cc.Invoke(ctx, UserService_SetBotBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateSearchPrefs(ctx context.Context, in *UpdateSearchPrefsRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateSearchPrefs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) MarkActivated(ctx context.Context, in *MarkActivatedRequest, opts ...grpc.CallOption) (*MarkActivatedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkActivatedResponse)
+	err := c.cc.Invoke(ctx, UserService_MarkActivated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetCampaignStats(ctx context.Context, in *GetCampaignStatsRequest, opts ...grpc.CallOption) (*GetCampaignStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCampaignStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetCampaignStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetIntro(ctx context.Context, in *SetIntroRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, UserService_SetIntro_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -130,6 +274,18 @@ type UserServiceServer interface {
 	GetCandidates(context.Context, *GetCandidatesRequest) (*GetCandidatesResponse, error)
 	ToggleVisibility(context.Context, *ToggleVisibilityRequest) (*ToggleVisibilityResponse, error)
 	PhotoUpload(context.Context, *PhotoUploadRequest) (*PhotoUploadResponse, error)
+	AddPhoto(context.Context, *AddPhotoRequest) (*PhotosResponse, error)
+	RemovePhoto(context.Context, *RemovePhotoRequest) (*PhotosResponse, error)
+	ReorderPhotos(context.Context, *ReorderPhotosRequest) (*PhotosResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	SetBanned(context.Context, *SetBannedRequest) (*SetBannedResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	SetBotBlocked(context.Context, *SetBotBlockedRequest) (*SetBotBlockedResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	UpdateSearchPrefs(context.Context, *UpdateSearchPrefsRequest) (*UserResponse, error)
+	MarkActivated(context.Context, *MarkActivatedRequest) (*MarkActivatedResponse, error)
+	GetCampaignStats(context.Context, *GetCampaignStatsRequest) (*GetCampaignStatsResponse, error)
+	SetIntro(context.Context, *SetIntroRequest) (*UserResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -161,6 +317,42 @@ func (UnimplementedUserServiceServer) ToggleVisibility(context.Context, *ToggleV
 func (UnimplementedUserServiceServer) PhotoUpload(context.Context, *PhotoUploadRequest) (*PhotoUploadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PhotoUpload not implemented")
 }
+func (UnimplementedUserServiceServer) AddPhoto(context.Context, *AddPhotoRequest) (*PhotosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPhoto not implemented")
+}
+func (UnimplementedUserServiceServer) RemovePhoto(context.Context, *RemovePhotoRequest) (*PhotosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePhoto not implemented")
+}
+func (UnimplementedUserServiceServer) ReorderPhotos(context.Context, *ReorderPhotosRequest) (*PhotosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderPhotos not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedUserServiceServer) SetBanned(context.Context, *SetBannedRequest) (*SetBannedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBanned not implemented")
+}
+func (UnimplementedUserServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedUserServiceServer) SetBotBlocked(context.Context, *SetBotBlockedRequest) (*SetBotBlockedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBotBlocked not implemented")
+}
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateSearchPrefs(context.Context, *UpdateSearchPrefsRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSearchPrefs not implemented")
+}
+func (UnimplementedUserServiceServer) MarkActivated(context.Context, *MarkActivatedRequest) (*MarkActivatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkActivated not implemented")
+}
+func (UnimplementedUserServiceServer) GetCampaignStats(context.Context, *GetCampaignStatsRequest) (*GetCampaignStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaignStats not implemented")
+}
+func (UnimplementedUserServiceServer) SetIntro(context.Context, *SetIntroRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIntro not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -308,6 +500,222 @@ func _UserService_PhotoUpload_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_AddPhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPhotoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddPhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddPhoto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddPhoto(ctx, req.(*AddPhotoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemovePhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePhotoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemovePhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemovePhoto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemovePhoto(ctx, req.(*RemovePhotoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReorderPhotos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderPhotosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReorderPhotos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReorderPhotos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReorderPhotos(ctx, req.(*ReorderPhotosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetBanned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBannedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetBanned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetBanned_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetBanned(ctx, req.(*SetBannedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetBotBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBotBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetBotBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetBotBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetBotBlocked(ctx, req.(*SetBotBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateSearchPrefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSearchPrefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateSearchPrefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateSearchPrefs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateSearchPrefs(ctx, req.(*UpdateSearchPrefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_MarkActivated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkActivatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).MarkActivated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_MarkActivated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).MarkActivated(ctx, req.(*MarkActivatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetCampaignStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCampaignStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetCampaignStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetCampaignStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetCampaignStats(ctx, req.(*GetCampaignStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetIntro_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIntroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetIntro(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetIntro_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetIntro(ctx, req.(*SetIntroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +751,54 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PhotoUpload",
 			Handler:    _UserService_PhotoUpload_Handler,
 		},
+		{
+			MethodName: "AddPhoto",
+			Handler:    _UserService_AddPhoto_Handler,
+		},
+		{
+			MethodName: "RemovePhoto",
+			Handler:    _UserService_RemovePhoto_Handler,
+		},
+		{
+			MethodName: "ReorderPhotos",
+			Handler:    _UserService_ReorderPhotos_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _UserService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "SetBanned",
+			Handler:    _UserService_SetBanned_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _UserService_GetStats_Handler,
+		},
+		{
+			MethodName: "SetBotBlocked",
+			Handler:    _UserService_SetBotBlocked_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+		{
+			MethodName: "UpdateSearchPrefs",
+			Handler:    _UserService_UpdateSearchPrefs_Handler,
+		},
+		{
+			MethodName: "MarkActivated",
+			Handler:    _UserService_MarkActivated_Handler,
+		},
+		{
+			MethodName: "GetCampaignStats",
+			Handler:    _UserService_GetCampaignStats_Handler,
+		},
+		{
+			MethodName: "SetIntro",
+			Handler:    _UserService_SetIntro_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/proto/user.proto",